@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"net"
 
 	"github.com/gin-gonic/gin"
 
@@ -15,6 +16,19 @@ func main() {
 		log.Fatalf("failed to initialize app: %v", err)
 	}
 
+	// gRPC 與 gin 共用同一組 service，在獨立的 port 上服務
+	if port := container.Config.GRPCPort; port != "" {
+		lis, err := net.Listen("tcp", ":"+port)
+		if err != nil {
+			log.Fatalf("failed to listen for gRPC: %v", err)
+		}
+		go func() {
+			if err := container.GRPCServer.Serve(lis); err != nil {
+				log.Fatalf("failed to run gRPC server: %v", err)
+			}
+		}()
+	}
+
 	r := gin.Default()
 	router.SetupRoutes(r, container)
 
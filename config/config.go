@@ -1,8 +1,13 @@
 package config
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"log"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,14 +19,29 @@ import (
 type Config struct {
 	// App / DB / Auth
 	AppPort   string `mapstructure:"PORT"`       // default: 8080
+	GRPCPort  string `mapstructure:"GRPC_PORT"`  // default: 9090 (empty disables gRPC)
 	DBURL     string `mapstructure:"DB_URL"`     // required
 	JWTSecret string `mapstructure:"JWT_SECRET"` // required
 
+	// Signing key for opaque pagination cursors
+	CursorSecret string `mapstructure:"CURSOR_SECRET"` // default: derived from JWT_SECRET
+
 	// Redis cache for task list
 	RedisAddr     string        `mapstructure:"REDIS_ADDR"`      // default: localhost:6379
 	RedisPassword string        `mapstructure:"REDIS_PASSWORD"`  // default: ""
 	RedisDB       int           `mapstructure:"REDIS_DB"`        // default: 0
 	CacheTTLTasks time.Duration `mapstructure:"CACHE_TTL_TASKS"` // default: 60s (supports "1m", "45s", etc.)
+
+	// Cache backend: redis, memory (in-process LRU), near (LRU in front of Redis) or none
+	CacheBackend       string        `mapstructure:"CACHE_BACKEND"`        // default: redis, or none when REDIS_ADDR is empty
+	CacheMemoryEntries int           `mapstructure:"CACHE_MEMORY_ENTRIES"` // default: 10000
+	CacheNearTTL       time.Duration `mapstructure:"CACHE_NEAR_TTL"`       // default: 5s (max staleness across instances)
+
+	// How long Idempotency-Key responses are kept for replay
+	IdempotencyTTL time.Duration `mapstructure:"IDEMPOTENCY_TTL"` // default: 24h
+
+	// Web origins besides the API's own that may open the board WebSocket (comma-separated)
+	BoardAllowedOrigins []string `mapstructure:"BOARD_ALLOWED_ORIGINS"` // default: same origin only
 }
 
 var (
@@ -54,31 +74,52 @@ func LoadConfig() (*Config, error) {
 
 		// Defaults — safe fallbacks for local/dev.
 		v.SetDefault("PORT", "8080")
+		v.SetDefault("GRPC_PORT", "9090")
 
 		// Redis cache for task list
 		v.SetDefault("REDIS_ADDR", "localhost:6379")
 		v.SetDefault("REDIS_PASSWORD", "")
 		v.SetDefault("REDIS_DB", 0)
 		v.SetDefault("CACHE_TTL_TASKS", "60s")
+		v.SetDefault("CACHE_MEMORY_ENTRIES", 10000)
+		v.SetDefault("CACHE_NEAR_TTL", "5s")
+		v.SetDefault("IDEMPOTENCY_TTL", "24h")
 
 		_ = v.BindEnv("PORT")
+		_ = v.BindEnv("GRPC_PORT")
 		_ = v.BindEnv("DB_URL")
 		_ = v.BindEnv("JWT_SECRET")
+		_ = v.BindEnv("CURSOR_SECRET")
 
 		// Redis cache for task list
 		_ = v.BindEnv("REDIS_ADDR")
 		_ = v.BindEnv("REDIS_PASSWORD")
 		_ = v.BindEnv("REDIS_DB")
 		_ = v.BindEnv("CACHE_TTL_TASKS")
+		_ = v.BindEnv("CACHE_BACKEND")
+		_ = v.BindEnv("CACHE_MEMORY_ENTRIES")
+		_ = v.BindEnv("CACHE_NEAR_TTL")
+		_ = v.BindEnv("IDEMPOTENCY_TTL")
+		_ = v.BindEnv("BOARD_ALLOWED_ORIGINS")
 		var c Config
-		// Enable time.Duration decoding from strings like "60s", "1m".
-		if err := v.Unmarshal(&c, viper.DecodeHook(
+		// Enable time.Duration decoding from strings like "60s", "1m", and comma-separated lists.
+		if err := v.Unmarshal(&c, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
 			mapstructure.StringToTimeDurationHookFunc(),
-		)); err != nil {
+			mapstructure.StringToSliceHookFunc(","),
+		))); err != nil {
 			initErr = err
 			return
 		}
 
+		// viper ignores empty env vars and falls back to the default; an empty GRPC_PORT disables gRPC
+		// and an empty REDIS_ADDR disables Redis.
+		if emptyEnv("GRPC_PORT") {
+			c.GRPCPort = ""
+		}
+		if emptyEnv("REDIS_ADDR") {
+			c.RedisAddr = ""
+		}
+
 		// Basic validation for required fields.
 		if c.DBURL == "" {
 			initErr = errors.New("config: DB_URL is required")
@@ -88,6 +129,18 @@ func LoadConfig() (*Config, error) {
 			initErr = errors.New("config: JWT_SECRET is required")
 			return
 		}
+		switch c.CacheBackend {
+		case "":
+			c.CacheBackend = defaultCacheBackend(&c)
+		case "redis", "memory", "near", "none":
+		default:
+			initErr = errors.New("config: CACHE_BACKEND must be one of redis, memory, near, none")
+			return
+		}
+		c.BoardAllowedOrigins = trimList(c.BoardAllowedOrigins)
+		if c.CursorSecret == "" {
+			c.CursorSecret = deriveSecret(c.JWTSecret, "cursor")
+		}
 
 		cfg = &c
 	})
@@ -98,5 +151,38 @@ func LoadConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// deriveSecret 以 HMAC-SHA256(secret, purpose) 衍生用途專屬的金鑰，避免同一把金鑰同時簽 JWT 與其他資料。
+func deriveSecret(secret, purpose string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// emptyEnv 回傳環境變數是否存在且為空字串（包含 .env 中的 KEY=）。
+func emptyEnv(key string) bool {
+	v, ok := os.LookupEnv(key)
+	return ok && v == ""
+}
+
+// trimList 去掉清單項目前後的空白與空項目，允許 "a, b" 這種寫法。
+func trimList(items []string) []string {
+	var res []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// defaultCacheBackend 是未設定 CACHE_BACKEND 時的快取：有 Redis 時用 redis，否則停用快取而不是讓啟動失敗。
+func defaultCacheBackend(c *Config) string {
+	if c.RedisEnabled() {
+		return "redis"
+	}
+	log.Println("config: REDIS_ADDR is empty, CACHE_BACKEND defaults to none")
+	return "none"
+}
+
 // Optional helpers (nice-to-have)
 func (c *Config) RedisEnabled() bool { return c != nil && c.RedisAddr != "" }
@@ -37,6 +37,15 @@ func TestLoadConfig_FallbackPort(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "8080", c.AppPort)
 	assert.Equal(t, "postgres://localhost:5432/testdb", c.DBURL)
+	// 未設定 CURSOR_SECRET 時衍生出與 JWT_SECRET 不同的金鑰
+	assert.NotEmpty(t, c.CursorSecret)
+	assert.NotEqual(t, "test-secret", c.CursorSecret)
+
+	t.Setenv("CURSOR_SECRET", "cursor-secret")
+	resetConfig()
+	c, err = LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "cursor-secret", c.CursorSecret)
 }
 
 func TestLoadConfig_MissingDBURL(t *testing.T) {
@@ -48,3 +57,76 @@ func TestLoadConfig_MissingDBURL(t *testing.T) {
 
 	t.Logf("error:%v", err)
 }
+
+func TestLoadConfig_CacheBackend(t *testing.T) {
+	t.Setenv("DB_URL", "postgres://localhost:5432/testdb")
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	resetConfig()
+	c, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "redis", c.CacheBackend)
+	assert.Equal(t, 10000, c.CacheMemoryEntries)
+
+	t.Setenv("CACHE_BACKEND", "memcached")
+	resetConfig()
+	_, err = LoadConfig()
+	assert.Error(t, err)
+
+	t.Setenv("CACHE_BACKEND", "memory")
+	resetConfig()
+	c, err = LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "memory", c.CacheBackend)
+}
+
+func TestDefaultCacheBackend(t *testing.T) {
+	assert.Equal(t, "redis", defaultCacheBackend(&Config{RedisAddr: "localhost:6379"}))
+	// 沒有 Redis 時停用快取，不讓 InitApp 因預設值而失敗
+	assert.Equal(t, "none", defaultCacheBackend(&Config{}))
+}
+
+func TestLoadConfig_BoardAllowedOrigins(t *testing.T) {
+	t.Setenv("DB_URL", "postgres://localhost:5432/testdb")
+	t.Setenv("JWT_SECRET", "test-secret")
+	t.Setenv("BOARD_ALLOWED_ORIGINS", "https://app.example.com, https://admin.example.com")
+
+	resetConfig()
+	c, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://app.example.com", "https://admin.example.com"}, c.BoardAllowedOrigins)
+}
+
+func TestLoadConfig_EmptyGRPCPortDisablesGRPC(t *testing.T) {
+	t.Setenv("DB_URL", "postgres://localhost:5432/testdb")
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	resetConfig()
+	c, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "9090", c.GRPCPort)
+
+	t.Setenv("GRPC_PORT", "")
+	resetConfig()
+	c, err = LoadConfig()
+	assert.NoError(t, err)
+	assert.Empty(t, c.GRPCPort)
+}
+
+func TestLoadConfig_EmptyRedisAddrDisablesCache(t *testing.T) {
+	t.Setenv("DB_URL", "postgres://localhost:5432/testdb")
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	resetConfig()
+	c, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:6379", c.RedisAddr)
+	assert.Equal(t, "redis", c.CacheBackend)
+
+	t.Setenv("REDIS_ADDR", "")
+	resetConfig()
+	c, err = LoadConfig()
+	assert.NoError(t, err)
+	assert.False(t, c.RedisEnabled())
+	assert.Equal(t, "none", c.CacheBackend)
+}
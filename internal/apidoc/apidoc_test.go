@@ -0,0 +1,118 @@
+package apidoc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaInner struct {
+	Name string `json:"name"`
+}
+
+type schemaBase struct {
+	ID uint `json:"id"`
+}
+
+type schemaSample struct {
+	schemaBase
+	Title   string       `json:"title" binding:"required,min=3"`
+	Email   string       `json:"email" binding:"omitempty,email"`
+	DueAt   *time.Time   `json:"due_at"`
+	Inner   *schemaInner `json:"inner"`
+	Tags    []string     `json:"tags,omitempty"`
+	Skipped string       `json:"-"`
+}
+
+func TestSchemaBuilder(t *testing.T) {
+	b := newSchemaBuilder()
+	ref := b.of(schemaSample{})
+	require.Equal(t, "#/components/schemas/schemaSample", ref["$ref"])
+
+	obj := b.schemas["schemaSample"].(map[string]any)
+	props := obj["properties"].(map[string]any)
+
+	require.Contains(t, props, "id", "embedded struct is flattened")
+	require.NotContains(t, props, "Skipped")
+	require.Equal(t, []string{"title"}, obj["required"])
+	require.Equal(t, json.Number("3"), props["title"].(map[string]any)["minLength"])
+	require.Equal(t, "email", props["email"].(map[string]any)["format"])
+	require.Equal(t, []string{"string", "null"}, props["due_at"].(map[string]any)["type"])
+	require.Equal(t, "date-time", props["due_at"].(map[string]any)["format"])
+	require.Contains(t, props["inner"], "anyOf")
+	require.Contains(t, b.schemas, "schemaInner")
+}
+
+func TestSpec(t *testing.T) {
+	raw, err := Spec()
+	require.NoError(t, err)
+
+	var doc struct {
+		OpenAPI string                               `json:"openapi"`
+		Paths   map[string]map[string]map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	require.Equal(t, "3.1.0", doc.OpenAPI)
+
+	get := doc.Paths["/v1/tasks/{id}"]["get"]
+	require.Equal(t, "getV1TasksById", get["operationId"])
+	require.Contains(t, get["responses"], "401")
+	require.NotContains(t, get, "deprecated")
+
+	legacy := doc.Paths["/api/tasks/{id}"]["get"]
+	require.Equal(t, "getApiTasksById", legacy["operationId"])
+	require.Equal(t, true, legacy["deprecated"])
+	require.Equal(t, []any{}, doc.Paths["/login"]["post"]["security"], "legacy alias of a public route stays public")
+
+	register := doc.Paths["/v1/register"]["post"]
+	require.Equal(t, []any{}, register["security"])
+	require.NotContains(t, register["responses"], "401", "public route has no auth error")
+}
+
+func TestOperationsAreUnique(t *testing.T) {
+	seen := map[Route]bool{}
+	for _, r := range Routes() {
+		require.False(t, seen[r], "duplicate operation %s %s", r.Method, r.Path)
+		seen[r] = true
+	}
+}
+
+func TestServeUIAsset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/docs", ServeUI)
+	r.GET("/docs/assets/:file", ServeUIAsset)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	page := get("/docs")
+	require.Equal(t, http.StatusOK, page.Code)
+	require.NotContains(t, page.Body.String(), "https://", "docs page must not load assets from a CDN")
+	require.Contains(t, page.Body.String(), "/docs/assets/swagger-ui-bundle.js")
+
+	for file, contentType := range map[string]string{
+		"swagger-ui.css":       "text/css",
+		"swagger-ui-bundle.js": "javascript",
+	} {
+		w := get("/docs/assets/" + file)
+		require.Equal(t, http.StatusOK, w.Code, file)
+		require.NotZero(t, w.Body.Len(), file)
+		require.Contains(t, w.Header().Get("Content-Type"), contentType, file)
+	}
+
+	version := get("/docs/assets/VERSION")
+	require.Equal(t, http.StatusOK, version.Code)
+	require.Regexp(t, `^\d+\.\d+\.\d+\n$`, version.Body.String(), "swagger-ui version is pinned exactly")
+
+	require.Equal(t, http.StatusNotFound, get("/docs/assets/missing.js").Code)
+	require.Equal(t, http.StatusNotFound, get("/docs/assets/..%2Fdocs.html").Code)
+}
@@ -0,0 +1,47 @@
+package apidoc
+
+import (
+	"embed"
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+// uiAssets 是固定版本的 Swagger UI 檔案（版本見 swagger-ui/VERSION，以 make swagger-ui 更新；授權見 swagger-ui/LICENSE）。
+//
+//go:embed swagger-ui
+var uiAssets embed.FS
+
+// ServeSpec 回傳 OpenAPI 文件。
+func ServeSpec(c *gin.Context) {
+	spec, err := Spec()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+}
+
+// ServeUI 回傳 Swagger UI 頁面，頁面從 /openapi.json 載入文件。
+func ServeUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", docsPage)
+}
+
+// ServeUIAsset 回傳內嵌的 Swagger UI 靜態檔，頁面不依賴外部 CDN。
+func ServeUIAsset(c *gin.Context) {
+	name := c.Param("file")
+	data, err := uiAssets.ReadFile(path.Join("swagger-ui", name))
+	if err != nil {
+		problem.Write(c, problem.New(http.StatusNotFound, problem.CodeNotFound, "asset not found"))
+		return
+	}
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Data(http.StatusOK, mime.TypeByExtension(path.Ext(name)), data)
+}
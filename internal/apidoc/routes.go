@@ -0,0 +1,370 @@
+package apidoc
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/jsonpatch"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+// 常用的查詢參數
+var (
+	limitParam  = param{name: "limit", desc: "每頁筆數", schema: map[string]any{"type": "integer", "minimum": 1}}
+	cursorParam = param{name: "cursor", desc: "上一頁回應的 next_cursor"}
+	filterQuery = []param{
+		{name: "status", desc: "以逗號分隔的狀態"},
+		{name: "created_after", schema: dateTime},
+		{name: "created_before", schema: dateTime},
+		{name: "updated_after", schema: dateTime},
+		{name: "updated_before", schema: dateTime},
+		{name: "title_prefix"},
+		{name: "sort", desc: "以逗號分隔，- 表示遞減；可用 id、title、status、position、priority、due_at、created_at、updated_at（沒有 due_at 的任務排在最後），例如 -priority,created_at；未含 id 時自動補上"},
+	}
+	dateTime = map[string]any{"type": "string", "format": "date-time"}
+	// viewQuery 裁剪任務回應的欄位並嵌入關聯，未知的名稱回傳 400
+	viewQuery = []param{
+		{name: "fields", desc: "以逗號分隔要回傳的欄位，例如 id,title,status"},
+		{name: "include", desc: "以逗號分隔要嵌入的關聯：tags、comments、project"},
+	}
+)
+
+var totalCountHeader = map[string]string{"X-Total-Count": "符合篩選條件的總筆數"}
+
+func errorResp(status int, desc string) response {
+	return response{status: status, desc: desc}
+}
+
+var (
+	badRequest = errorResp(http.StatusBadRequest, "請求格式或參數錯誤")
+	forbidden  = errorResp(http.StatusForbidden, "資源不屬於目前使用者")
+	notFound   = errorResp(http.StatusNotFound, "資源不存在")
+)
+
+// operations 必須涵蓋 router.SetupRoutes 註冊的每一條路由（見 router 的測試）；
+// /v1 路由的舊路徑別名由 legacyPath 產生，不需要另外列出。
+var operations = []operation{
+	// Docs
+	{
+		method: http.MethodGet, path: "/openapi.json", tag: "docs", summary: "OpenAPI 文件", public: true,
+		resp: []response{{status: http.StatusOK, desc: "OpenAPI 3.1 文件", schema: map[string]any{"type": "object"}}},
+	},
+	{
+		method: http.MethodGet, path: "/docs", tag: "docs", summary: "API 文件頁面", public: true,
+		resp: []response{{status: http.StatusOK, desc: "HTML 頁面", content: "text/html"}},
+	},
+	{
+		method: http.MethodGet, path: "/docs/assets/:file", tag: "docs", summary: "API 文件頁面使用的內嵌 Swagger UI 檔案", public: true,
+		resp: []response{{status: http.StatusOK, desc: "CSS 或 JavaScript"}, notFound},
+	},
+
+	// Auth
+	{
+		method: http.MethodPost, path: "/v1/register", tag: "auth", summary: "註冊並取得 JWT", public: true,
+		body: handler.RegisterRequest{},
+		resp: []response{
+			{status: http.StatusOK, desc: "註冊成功", body: handler.LoginResponse{}},
+			badRequest,
+			errorResp(http.StatusConflict, "email 已被註冊"),
+		},
+	},
+	{
+		method: http.MethodPost, path: "/v1/login", tag: "auth", summary: "登入並取得 JWT", public: true,
+		body: handler.LoginRequest{},
+		resp: []response{
+			{status: http.StatusOK, desc: "登入成功", body: handler.LoginResponse{}},
+			badRequest,
+			errorResp(http.StatusUnauthorized, "帳號或密碼錯誤"),
+		},
+	},
+	{
+		method: http.MethodGet, path: "/v1/profile", tag: "auth", summary: "目前使用者",
+		resp: []response{{status: http.StatusOK, desc: "使用者 id", body: struct {
+			UserID uint `json:"user_id"`
+		}{}}},
+	},
+
+	// Calendar feed
+	{
+		method: http.MethodGet, path: "/feeds/:file", tag: "feed", summary: "iCalendar 訂閱（檔名為 <token>.ics）", public: true,
+		resp: []response{
+			{status: http.StatusOK, desc: "iCalendar 內容，支援 ETag / If-None-Match", content: "text/calendar"},
+			{status: http.StatusNotModified, desc: "內容未變更"},
+			errorResp(http.StatusNotFound, "token 不存在或已撤銷"),
+		},
+	},
+	{
+		method: http.MethodPost, path: "/v1/feed/token", tag: "feed", summary: "產生新的訂閱網址（舊網址立即失效）",
+		resp: []response{{status: http.StatusCreated, desc: "新的 token 與網址", body: handler.FeedTokenResponse{}}},
+	},
+	{
+		method: http.MethodDelete, path: "/v1/feed/token", tag: "feed", summary: "撤銷訂閱網址",
+		resp: []response{{status: http.StatusNoContent, desc: "已撤銷"}},
+	},
+
+	// GraphQL
+	{
+		method: http.MethodPost, path: "/graphql", tag: "graphql", summary: "GraphQL 查詢（schema 見 internal/graph/schema.graphqls）",
+		body: struct {
+			Query         string         `json:"query"`
+			OperationName string         `json:"operationName,omitempty"`
+			Variables     map[string]any `json:"variables,omitempty"`
+		}{},
+		resp: []response{
+			{status: http.StatusOK, desc: "查詢結果；錯誤放在 errors，extensions.code 與 REST 的 code 相同，超過深度或複雜度限制時為 DEPTH_LIMIT_EXCEEDED / COMPLEXITY_LIMIT_EXCEEDED", schema: map[string]any{"type": "object"}},
+			{status: http.StatusUnprocessableEntity, desc: "查詢語法或欄位錯誤（GraphQL 的 errors 格式）", schema: map[string]any{"type": "object"}},
+		},
+	},
+
+	// Batch
+	{
+		method: http.MethodPost, path: "/v1/batch", tag: "batch", summary: fmt.Sprintf("一次送出多個 API 請求（最多 %d 個、body 上限 %d bytes，每個子請求逾時 %s）", handler.MaxBatchRequests, handler.MaxBatchBytes, handler.DefaultBatchTimeout),
+		body: handler.BatchRequest{},
+		resp: []response{
+			{status: http.StatusOK, desc: "依請求順序的子回應；依賴失敗的子請求為 424，逾時為 504", body: handler.BatchResponse{}},
+			badRequest,
+		},
+	},
+
+	// Real-time
+	{
+		method: http.MethodGet, path: "/v1/stream", tag: "realtime", summary: "以 Server-Sent Events 推送任務異動",
+		header: []param{{name: "Last-Event-ID", desc: "重連時從這個事件之後補送"}},
+		resp:   []response{{status: http.StatusOK, desc: "事件串流（event: task.created 等，data 為任務 JSON）", content: "text/event-stream"}},
+	},
+	{
+		method: http.MethodGet, path: "/v1/boards/ws", tag: "realtime", summary: "專案看板的 WebSocket 協作通道",
+		header: []param{{name: "Sec-WebSocket-Protocol", desc: "無法設定 Authorization header 時改帶 \"gotasker.board.v1, access_token.<JWT>\"；伺服器只回應 gotasker.board.v1"}},
+		resp: []response{
+			{status: http.StatusSwitchingProtocols, desc: "升級為 WebSocket；訊息為 JSON（subscribe / unsubscribe / presence / ping）"},
+			badRequest,
+			{status: http.StatusForbidden, desc: "Origin 不是同源，也不在 BOARD_ALLOWED_ORIGINS 中"},
+		},
+	},
+
+	// Tasks
+	{
+		method: http.MethodPost, path: "/v1/tasks", tag: "tasks", summary: "建立任務",
+		body: handler.CreateTaskRequest{},
+		resp: []response{{status: http.StatusCreated, desc: "已建立", body: handler.TaskResponse{}}, badRequest},
+	},
+	{
+		method: http.MethodGet, path: "/v1/tasks", tag: "tasks", summary: "分頁列出任務",
+		query: append(append([]param{limitParam, cursorParam}, filterQuery...), viewQuery...),
+		resp: []response{
+			{status: http.StatusOK, desc: "一頁任務", body: handler.TaskListResponse{}, headers: totalCountHeader},
+			badRequest,
+		},
+	},
+	{
+		method: http.MethodGet, path: "/v1/tasks/search", tag: "tasks", summary: "全文檢索任務與留言",
+		query: []param{{name: "q", required: true}, limitParam},
+		resp: []response{
+			{status: http.StatusOK, desc: "依相關度排序的結果", body: struct {
+				Items []handler.SearchHitResponse `json:"items"`
+			}{}},
+			badRequest,
+		},
+	},
+	{
+		method: http.MethodGet, path: "/v1/tasks/export", tag: "tasks", summary: "串流匯出所有任務",
+		query: []param{{name: "format", schema: map[string]any{"type": "string", "enum": []string{"csv", "json", "ics"}, "default": "json"}}},
+		resp: []response{
+			{status: http.StatusOK, desc: "匯出檔（Content-Disposition: attachment）", content: "application/octet-stream"},
+			badRequest,
+		},
+	},
+	{
+		method: http.MethodPost, path: "/v1/tasks/bulk", tag: "tasks", summary: "批次操作任務",
+		body: handler.BulkTaskRequest{},
+		resp: []response{
+			{status: http.StatusOK, desc: "每一項的結果", body: handler.BulkTaskResponse{}},
+			{status: http.StatusUnprocessableEntity, desc: "atomic 模式有項目失敗，整批已回滾", body: handler.BulkTaskResponse{}},
+			badRequest,
+		},
+	},
+	{
+		method: http.MethodPost, path: "/v1/tasks/import", tag: "import", summary: "匯入任務（CSV、JSON、Todoist、Trello）",
+		query: []param{
+			{name: "format", schema: map[string]any{"type": "string", "enum": []string{"csv", "json", "todoist", "trello"}}},
+			{name: "dry_run", schema: map[string]any{"type": "boolean"}},
+		},
+		form: map[string]any{
+			"file":    map[string]any{"type": "string", "contentMediaType": "application/octet-stream"},
+			"format":  map[string]any{"type": "string"},
+			"dry_run": map[string]any{"type": "boolean"},
+		},
+		resp: []response{
+			{status: http.StatusCreated, desc: "已匯入", body: service.ImportReport{}},
+			{status: http.StatusOK, desc: "試跑結果", body: service.ImportReport{}},
+			{status: http.StatusAccepted, desc: "列數較多，改為背景工作（Location 指向工作狀態）", body: handler.ImportJobResponse{},
+				headers: map[string]string{"Location": "工作狀態的網址"}},
+			{status: http.StatusUnprocessableEntity, desc: "有無效的列，未寫入任何資料", body: service.ImportReport{}},
+			badRequest,
+			errorResp(http.StatusRequestEntityTooLarge, "檔案太大"),
+		},
+	},
+	{
+		method: http.MethodGet, path: "/v1/tasks/import/jobs/:job_id", tag: "import", summary: "查詢背景匯入工作",
+		resp: []response{{status: http.StatusOK, desc: "工作狀態", body: handler.ImportJobResponse{}}, badRequest, notFound},
+	},
+	{
+		method: http.MethodGet, path: "/v1/tasks/:id", tag: "tasks", summary: "取得任務",
+		query: viewQuery,
+		resp:  []response{{status: http.StatusOK, desc: "任務", body: handler.TaskResponse{}}, badRequest, forbidden, notFound},
+	},
+	{
+		method: http.MethodPut, path: "/v1/tasks/:id", tag: "tasks", summary: "以完整內容取代任務（未提供的欄位會被清空）",
+		body: handler.UpdateTaskRequest{},
+		resp: []response{
+			{status: http.StatusOK, desc: "修改後的任務", body: handler.TaskResponse{}},
+			badRequest, forbidden, notFound,
+			errorResp(http.StatusConflict, "狀態轉換不符合流程"),
+		},
+	},
+	{
+		method: http.MethodPatch, path: "/v1/tasks/:id", tag: "tasks", summary: "以 JSON Merge Patch 或 JSON Patch 修改任務（作用在 PUT 的欄位上）",
+		bodies: map[string]any{
+			jsonpatch.MergePatchContentType: struct {
+				Title    *string    `json:"title,omitempty"`
+				Content  *string    `json:"content,omitempty"`
+				Status   *string    `json:"status,omitempty"`
+				Priority *int       `json:"priority,omitempty"`
+				DueAt    *time.Time `json:"due_at,omitempty"`
+			}{},
+			jsonpatch.JSONPatchContentType: []jsonpatch.Operation{},
+		},
+		resp: []response{
+			{status: http.StatusOK, desc: "修改後的任務", body: handler.TaskResponse{}},
+			errorResp(http.StatusBadRequest, "patch 格式錯誤，或套用後的任務驗證失敗"),
+			forbidden, notFound,
+			errorResp(http.StatusConflict, "patch 無法套用（路徑不存在、test 不符合）或狀態轉換不符合流程"),
+			errorResp(http.StatusUnsupportedMediaType, "Content-Type 不是 merge-patch+json 或 json-patch+json"),
+		},
+	},
+	{
+		method: http.MethodDelete, path: "/v1/tasks/:id", tag: "tasks", summary: "刪除任務",
+		resp: []response{{status: http.StatusNoContent, desc: "已刪除"}, badRequest, forbidden, notFound},
+	},
+	{
+		method: http.MethodPost, path: "/v1/tasks/:id/move", tag: "tasks", summary: "移動任務到另一個任務之前或之後",
+		body: handler.MoveTaskRequest{},
+		resp: []response{{status: http.StatusOK, desc: "移動後的任務", body: handler.TaskResponse{}}, badRequest, forbidden, notFound},
+	},
+	{
+		method: http.MethodGet, path: "/v1/tasks/:id/comments", tag: "comments", summary: "列出任務的留言",
+		resp: []response{{status: http.StatusOK, desc: "留言", body: []handler.CommentResponse{}}, badRequest, forbidden, notFound},
+	},
+	{
+		method: http.MethodPost, path: "/v1/tasks/:id/comments", tag: "comments", summary: "新增留言",
+		body: handler.CreateCommentRequest{},
+		resp: []response{{status: http.StatusCreated, desc: "已新增", body: handler.CommentResponse{}}, badRequest, forbidden, notFound},
+	},
+
+	// Sync
+	{
+		method: http.MethodGet, path: "/v1/sync", tag: "sync", summary: "取得 since 之後的任務異動（含刪除紀錄）",
+		query: []param{{name: "since", desc: "上一次回應的 sync_token；未提供時回傳所有任務"}, limitParam},
+		resp:  []response{{status: http.StatusOK, desc: "異動", body: handler.SyncChangesResponse{}}, badRequest},
+	},
+	{
+		method: http.MethodPost, path: "/v1/sync", tag: "sync", summary: "套用離線時的修改並回報衝突",
+		body: handler.SyncMutationsRequest{},
+		resp: []response{{status: http.StatusOK, desc: "每一筆修改的結果", body: handler.SyncMutationsResponse{}}, badRequest},
+	},
+
+	// Projects
+	{
+		method: http.MethodPost, path: "/v1/projects", tag: "projects", summary: "建立專案",
+		body: handler.CreateProjectRequest{},
+		resp: []response{{status: http.StatusCreated, desc: "已建立", body: handler.ProjectResponse{}}, badRequest},
+	},
+	{
+		method: http.MethodGet, path: "/v1/projects", tag: "projects", summary: "列出擁有或參與的專案",
+		resp: []response{{status: http.StatusOK, desc: "專案", body: []handler.ProjectResponse{}}},
+	},
+	{
+		method: http.MethodGet, path: "/v1/projects/:id/members", tag: "projects", summary: "列出專案成員（擁有者與成員可查看）",
+		resp: []response{{status: http.StatusOK, desc: "成員", body: []handler.ProjectMemberResponse{}}, badRequest, forbidden, notFound},
+	},
+	{
+		method: http.MethodPut, path: "/v1/projects/:id/members/:user_id", tag: "projects", summary: "加入成員（只有擁有者可以），成員可以開啟專案看板",
+		resp: []response{{status: http.StatusNoContent, desc: "已加入"}, badRequest, forbidden, notFound},
+	},
+	{
+		method: http.MethodDelete, path: "/v1/projects/:id/members/:user_id", tag: "projects", summary: "移除成員（擁有者，或成員移除自己）",
+		resp: []response{{status: http.StatusNoContent, desc: "已移除"}, badRequest, forbidden, notFound},
+	},
+
+	// Saved views
+	{
+		method: http.MethodPost, path: "/v1/views", tag: "views", summary: "建立儲存的檢視",
+		body: handler.ViewRequest{},
+		resp: []response{{status: http.StatusCreated, desc: "已建立", body: handler.ViewResponse{}}, badRequest},
+	},
+	{
+		method: http.MethodGet, path: "/v1/views", tag: "views", summary: "列出儲存的檢視",
+		resp: []response{{status: http.StatusOK, desc: "檢視", body: []handler.ViewResponse{}}},
+	},
+	{
+		method: http.MethodGet, path: "/v1/views/:id", tag: "views", summary: "取得檢視",
+		resp: []response{{status: http.StatusOK, desc: "檢視", body: handler.ViewResponse{}}, badRequest, forbidden, notFound},
+	},
+	{
+		method: http.MethodPut, path: "/v1/views/:id", tag: "views", summary: "修改檢視",
+		body: handler.ViewRequest{},
+		resp: []response{{status: http.StatusOK, desc: "修改後的檢視", body: handler.ViewResponse{}}, badRequest, forbidden, notFound},
+	},
+	{
+		method: http.MethodDelete, path: "/v1/views/:id", tag: "views", summary: "刪除檢視",
+		resp: []response{{status: http.StatusNoContent, desc: "已刪除"}, badRequest, forbidden, notFound},
+	},
+	{
+		method: http.MethodGet, path: "/v1/views/:id/tasks", tag: "views", summary: "以檢視的條件分頁列出任務",
+		query: []param{limitParam, cursorParam},
+		resp: []response{
+			{status: http.StatusOK, desc: "一頁任務", body: handler.TaskListResponse{}, headers: totalCountHeader},
+			badRequest, forbidden, notFound,
+		},
+	},
+
+	// Webhooks
+	{
+		method: http.MethodPost, path: "/v1/webhooks", tag: "webhooks", summary: "建立 webhook（自訂 secret 至少 32 bytes；secret 只在此時回傳）",
+		body: handler.CreateWebhookRequest{},
+		resp: []response{{status: http.StatusCreated, desc: "已建立", body: handler.WebhookResponse{}}, badRequest},
+	},
+	{
+		method: http.MethodGet, path: "/v1/webhooks", tag: "webhooks", summary: "列出 webhook",
+		resp: []response{{status: http.StatusOK, desc: "webhook", body: []handler.WebhookResponse{}}},
+	},
+	{
+		method: http.MethodDelete, path: "/v1/webhooks/:id", tag: "webhooks", summary: "刪除 webhook",
+		resp: []response{{status: http.StatusNoContent, desc: "已刪除"}, badRequest, forbidden, notFound},
+	},
+	{
+		method: http.MethodPost, path: "/v1/webhooks/:id/ping", tag: "webhooks", summary: "送出測試事件",
+		resp: []response{{status: http.StatusAccepted, desc: "已排入傳送", body: handler.DeliveryResponse{}}, badRequest, forbidden, notFound},
+	},
+	{
+		method: http.MethodGet, path: "/v1/webhooks/:id/deliveries", tag: "webhooks", summary: "最近的傳送紀錄",
+		resp: []response{{status: http.StatusOK, desc: "傳送紀錄", body: []handler.DeliveryResponse{}}, badRequest, forbidden, notFound},
+	},
+	{
+		method: http.MethodPost, path: "/v1/webhooks/:id/deliveries/:delivery_id/redeliver", tag: "webhooks", summary: "重新傳送",
+		resp: []response{{status: http.StatusAccepted, desc: "已排入傳送", body: handler.DeliveryResponse{}}, badRequest, forbidden, notFound},
+	},
+
+	// Workflow
+	{
+		method: http.MethodGet, path: "/v1/workflow", tag: "workflow", summary: "取得狀態流程",
+		resp: []response{{status: http.StatusOK, desc: "流程（未自訂時為預設流程）", body: handler.WorkflowResponse{}}},
+	},
+	{
+		method: http.MethodPut, path: "/v1/workflow", tag: "workflow", summary: "儲存狀態流程",
+		body: handler.WorkflowRequest{},
+		resp: []response{{status: http.StatusOK, desc: "儲存後的流程", body: handler.WorkflowResponse{}}, badRequest},
+	},
+}
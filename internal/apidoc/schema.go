@@ -0,0 +1,157 @@
+package apidoc
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaBuilder 以 reflection 把 Go struct 轉成 JSON Schema（OpenAPI 3.1），
+// 具名 struct 放進 components.schemas 並以 $ref 引用，確保文件與 handler 的 struct 一致。
+type schemaBuilder struct {
+	schemas map[string]any
+	names   map[reflect.Type]string
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{schemas: map[string]any{}, names: map[reflect.Type]string{}}
+}
+
+// of 回傳 v 的型別對應的 schema。
+func (b *schemaBuilder) of(v any) map[string]any {
+	return b.schema(reflect.TypeOf(v))
+}
+
+func (b *schemaBuilder) schema(t reflect.Type) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		return map[string]any{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(b.schema(t.Elem()))
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]any{"type": "integer"}
+	case reflect.Int64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.object(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + b.register(t)}
+	default:
+		return map[string]any{}
+	}
+}
+
+// register 把具名 struct 加入 components；不同 package 的同名型別以 package 名稱區分。
+func (b *schemaBuilder) register(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	for other := range b.names {
+		if other.Name() == name {
+			pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+			name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+			break
+		}
+	}
+	b.names[t] = name
+	b.schemas[name] = map[string]any{} // 先佔位，遞迴型別才不會無限展開
+	b.schemas[name] = b.object(t)
+	return name
+}
+
+func (b *schemaBuilder) object(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	var required []string
+	b.fields(t, props, &required)
+	obj := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		obj["required"] = required
+	}
+	return obj
+}
+
+// fields 收集 struct 的 JSON 欄位；匿名嵌入的 struct 與 encoding/json 一樣展開到同一層。
+// 必填欄位以 binding:"required" 判斷，只對請求有意義。
+func (b *schemaBuilder) fields(t reflect.Type, props map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			b.fields(f.Type, props, required)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		s := b.schema(f.Type)
+		binding := f.Tag.Get("binding")
+		for _, rule := range strings.Split(binding, ",") {
+			key, val, _ := strings.Cut(rule, "=")
+			switch {
+			case key == "required":
+				*required = append(*required, name)
+			case key == "email":
+				s = withKey(s, "format", "email")
+			case key == "min" && f.Type.Kind() == reflect.String:
+				s = withKey(s, "minLength", json.Number(val))
+			case key == "min" && f.Type.Kind() == reflect.Slice:
+				s = withKey(s, "minItems", json.Number(val))
+			}
+		}
+		props[name] = s
+	}
+}
+
+// nullable 讓 schema 也接受 null；$ref 不能再加 type，因此改用 anyOf。
+func nullable(s map[string]any) map[string]any {
+	if _, ok := s["$ref"]; ok {
+		return map[string]any{"anyOf": []any{s, map[string]any{"type": "null"}}}
+	}
+	typ, ok := s["type"].(string)
+	if !ok {
+		return s
+	}
+	return withKey(s, "type", []string{typ, "null"})
+}
+
+func withKey(s map[string]any, key string, val any) map[string]any {
+	out := make(map[string]any, len(s)+1)
+	for k, v := range s {
+		out[k] = v
+	}
+	out[key] = val
+	return out
+}
@@ -0,0 +1,322 @@
+// Package apidoc 產生 API 的 OpenAPI 3.1 文件並提供文件頁面。
+// 路由清單在 routes.go，請求與回應的 schema 直接由 handler 的 struct 產生。
+package apidoc
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+)
+
+const (
+	contentJSON      = "application/json"
+	contentMultipart = "multipart/form-data"
+)
+
+// Route 是一條已文件化的路由，Path 使用 gin 的語法（/tasks/:id）。
+type Route struct {
+	Method string
+	Path   string
+}
+
+type operation struct {
+	method  string
+	path    string
+	tag     string
+	summary string
+	// public 為 true 表示不需要 JWT
+	public bool
+	query  []param
+	header []param
+	// body 是 JSON 請求的 struct（零值即可）；form 不為 nil 時改用 multipart；
+	// 接受多種格式時以 bodies 列出 content type 對應的 struct
+	body   any
+	form   map[string]any
+	bodies map[string]any
+	resp   []response
+	// successor 不為空表示這是已棄用的舊路徑，值為取代它的 /v1 路徑
+	successor string
+}
+
+type param struct {
+	name     string
+	desc     string
+	schema   map[string]any
+	required bool
+}
+
+type response struct {
+	status int
+	desc   string
+	// body 為 JSON 回應的 struct；content 不為空時表示非 JSON 的回應
+	body    any
+	content string
+	schema  map[string]any
+	headers map[string]string
+}
+
+// Routes 回傳文件涵蓋的所有路由，包含舊路徑別名。
+func Routes() []Route {
+	ops := allOperations()
+	out := make([]Route, 0, len(ops))
+	for _, op := range ops {
+		out = append(out, Route{Method: op.method, Path: op.path})
+	}
+	return out
+}
+
+// allOperations 在 operations 之後加上每條 /v1 路由的舊路徑別名。
+func allOperations() []operation {
+	out := append([]operation(nil), operations...)
+	for _, op := range operations {
+		if path, ok := legacyPath(op); ok {
+			alias := op
+			alias.path, alias.successor = path, op.path
+			out = append(out, alias)
+		}
+	}
+	return out
+}
+
+// legacyPath 回傳 v1 路由未加版本的舊路徑：公開路由直接去掉 /v1，其餘改放在 /api 之下。
+func legacyPath(op operation) (string, bool) {
+	rest, ok := strings.CutPrefix(op.path, "/v1")
+	if !ok {
+		return "", false
+	}
+	if op.public {
+		return rest, true
+	}
+	return "/api" + rest, true
+}
+
+var (
+	specOnce sync.Once
+	specJSON []byte
+	specErr  error
+)
+
+// Spec 回傳 OpenAPI 文件的 JSON；內容只依程式碼決定，產生一次後重複使用。
+func Spec() ([]byte, error) {
+	specOnce.Do(func() {
+		specJSON, specErr = json.Marshal(build())
+	})
+	return specJSON, specErr
+}
+
+func build() map[string]any {
+	b := newSchemaBuilder()
+	errorSchema := b.of(problem.Problem{})
+
+	paths := map[string]any{}
+	for _, op := range allOperations() {
+		path, pathParams := openAPIPath(op.path)
+		item, _ := paths[path].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[path] = item
+		}
+
+		params := make([]any, 0, len(pathParams)+len(op.query)+len(op.header))
+		for _, name := range pathParams {
+			params = append(params, map[string]any{
+				"name": name, "in": "path", "required": true, "schema": pathParamSchema(name),
+			})
+		}
+		for _, p := range op.query {
+			params = append(params, paramObject(p, "query"))
+		}
+		for _, p := range op.header {
+			params = append(params, paramObject(p, "header"))
+		}
+		if !op.public && isMutating(op.method) {
+			params = append(params, paramObject(idempotencyKey, "header"))
+		}
+
+		o := map[string]any{
+			"operationId": operationID(op.method, op.path),
+			"tags":        []string{op.tag},
+			"summary":     op.summary,
+			"responses":   responses(b, op, errorSchema),
+		}
+		if len(params) > 0 {
+			o["parameters"] = params
+		}
+		if op.public {
+			o["security"] = []any{}
+		}
+		if op.successor != "" {
+			o["deprecated"] = true
+			o["description"] = "已棄用，請改用 `" + op.successor + "`；回應帶有 Deprecation 與 Sunset header。"
+		}
+		switch {
+		case op.form != nil:
+			o["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{contentMultipart: map[string]any{
+					"schema": map[string]any{"type": "object", "properties": op.form},
+				}},
+			}
+		case op.body != nil:
+			o["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{contentJSON: map[string]any{"schema": b.of(op.body)}},
+			}
+		case op.bodies != nil:
+			content := map[string]any{}
+			for contentType, body := range op.bodies {
+				content[contentType] = map[string]any{"schema": b.of(body)}
+			}
+			o["requestBody"] = map[string]any{"required": true, "content": content}
+		}
+		item[strings.ToLower(op.method)] = o
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       "GoTasker Pro API",
+			"version":     "1.0.0",
+			"description": "任務管理 API。除了註冊、登入與日曆訂閱外，所有路由都需要 `Authorization: Bearer <JWT>`。錯誤回應一律為 RFC 7807 的 `application/problem+json`，以 `code` 欄位區分錯誤種類。",
+		},
+		"tags":     tags(),
+		"paths":    paths,
+		"security": []any{map[string]any{"bearerAuth": []string{}}},
+		"components": map[string]any{
+			"schemas": b.schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{
+					"type": "http", "scheme": "bearer", "bearerFormat": "JWT",
+					"description": "POST /login 取得的 token。",
+				},
+			},
+		},
+	}
+}
+
+var idempotencyKey = param{
+	name:   "Idempotency-Key",
+	desc:   "重送同一個請求時帶相同的值，伺服器只會執行一次並回放第一次的回應。",
+	schema: map[string]any{"type": "string", "maxLength": 255},
+}
+
+func responses(b *schemaBuilder, op operation, errorSchema map[string]any) map[string]any {
+	out := map[string]any{}
+	for _, r := range op.resp {
+		res := map[string]any{"description": r.desc}
+		switch {
+		case r.content != "":
+			schema := r.schema
+			if schema == nil {
+				schema = map[string]any{"type": "string"}
+			}
+			res["content"] = map[string]any{r.content: map[string]any{"schema": schema}}
+		case r.schema != nil:
+			res["content"] = map[string]any{contentJSON: map[string]any{"schema": r.schema}}
+		case r.body != nil:
+			res["content"] = map[string]any{contentJSON: map[string]any{"schema": b.of(r.body)}}
+		case r.status >= 400:
+			res["content"] = map[string]any{problem.ContentType: map[string]any{"schema": errorSchema}}
+		}
+		if len(r.headers) > 0 {
+			headers := map[string]any{}
+			for name, desc := range r.headers {
+				headers[name] = map[string]any{"description": desc, "schema": map[string]any{"type": "string"}}
+			}
+			res["headers"] = headers
+		}
+		out[strconv.Itoa(r.status)] = res
+	}
+	if !op.public {
+		out["401"] = map[string]any{
+			"description": "缺少或無效的 JWT",
+			"content":     map[string]any{problem.ContentType: map[string]any{"schema": errorSchema}},
+		}
+	}
+	if _, ok := out["500"]; !ok {
+		out["500"] = map[string]any{
+			"description": "伺服器錯誤",
+			"content":     map[string]any{problem.ContentType: map[string]any{"schema": errorSchema}},
+		}
+	}
+	return out
+}
+
+func paramObject(p param, in string) map[string]any {
+	schema := p.schema
+	if schema == nil {
+		schema = map[string]any{"type": "string"}
+	}
+	o := map[string]any{"name": p.name, "in": in, "schema": schema}
+	if p.desc != "" {
+		o["description"] = p.desc
+	}
+	if p.required {
+		o["required"] = true
+	}
+	return o
+}
+
+// openAPIPath 把 /tasks/:id 轉成 /tasks/{id}，並回傳路徑參數名稱。
+func openAPIPath(path string) (string, []string) {
+	segs := strings.Split(path, "/")
+	var names []string
+	for i, s := range segs {
+		if strings.HasPrefix(s, ":") {
+			names = append(names, s[1:])
+			segs[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segs, "/"), names
+}
+
+func pathParamSchema(name string) map[string]any {
+	if name == "id" || strings.HasSuffix(name, "_id") {
+		return map[string]any{"type": "integer", "minimum": 1}
+	}
+	return map[string]any{"type": "string"}
+}
+
+// operationID 由 method 與路徑組成，例如 GET /v1/tasks/:id → getV1TasksById。
+func operationID(method, path string) string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(method))
+	for _, seg := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '.' || r == '_' }) {
+		if strings.HasPrefix(seg, ":") {
+			sb.WriteString("By")
+			seg = seg[1:]
+		}
+		sb.WriteString(strings.ToUpper(seg[:1]) + seg[1:])
+	}
+	return sb.String()
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func tags() []any {
+	seen := map[string]bool{}
+	var names []string
+	for _, op := range operations {
+		if !seen[op.tag] {
+			seen[op.tag] = true
+			names = append(names, op.tag)
+		}
+	}
+	sort.Strings(names)
+	out := make([]any, 0, len(names))
+	for _, n := range names {
+		out = append(out, map[string]any{"name": n})
+	}
+	return out
+}
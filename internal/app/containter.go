@@ -1,25 +1,50 @@
 package app
 
 import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
 	redis "github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 
 	"github.com/SoliMark/gotasker-pro/config"
+	"github.com/SoliMark/gotasker-pro/internal/cache"
 	"github.com/SoliMark/gotasker-pro/internal/db"
+	"github.com/SoliMark/gotasker-pro/internal/graph"
+	"github.com/SoliMark/gotasker-pro/internal/grpcapi"
 	"github.com/SoliMark/gotasker-pro/internal/handler"
 	"github.com/SoliMark/gotasker-pro/internal/middleware"
+	"github.com/SoliMark/gotasker-pro/internal/realtime"
 	"github.com/SoliMark/gotasker-pro/internal/repository"
 	"github.com/SoliMark/gotasker-pro/internal/service"
 	"github.com/SoliMark/gotasker-pro/internal/util"
 )
 
 type Container struct {
-	Config        *config.Config
-	DB            *gorm.DB
-	RedisClient   *redis.Client
-	JWTMiddleware middleware.JWTMiddleware
-	UserHandler   *handler.UserHandler
-	TaskHandler   *handler.TaskHandler
+	Config          *config.Config
+	DB              *gorm.DB
+	RedisClient     *redis.Client
+	Cache           cache.Store
+	JWTMiddleware   middleware.JWTMiddleware
+	IdempotencyMW   middleware.IdempotencyMiddleware
+	ErrorMW         middleware.ErrorMiddleware
+	UserHandler     *handler.UserHandler
+	TaskHandler     *handler.TaskHandler
+	WorkflowHandler *handler.WorkflowHandler
+	CommentHandler  *handler.CommentHandler
+	ViewHandler     *handler.ViewHandler
+	ProjectHandler  *handler.ProjectHandler
+	ImportHandler   *handler.ImportHandler
+	FeedHandler     *handler.FeedHandler
+	WebhookHandler  *handler.WebhookHandler
+	StreamHandler   *handler.StreamHandler
+	BoardHandler    *handler.BoardHandler
+	SyncHandler     *handler.SyncHandler
+	GraphQLHandler  *handler.GraphQLHandler
+	GRPCServer      *grpc.Server
 }
 
 func InitApp() (*Container, error) {
@@ -45,22 +70,176 @@ func InitApp() (*Container, error) {
 		redisClient = db.NewRedisClient(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
 	}
 
+	// Init cache backend for task lists and pages
+	cacheStore, err := newCacheStore(cfg, redisClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Init Idempotency-Key handling (Redis when enabled, otherwise the DB table)
+	idempotencyRepo := repository.NewIdempotencyRepository(dbConn)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo, redisClient, cfg.IdempotencyTTL)
+	idempotencyMW := middleware.Idempotency(idempotencyService)
+	if redisClient == nil {
+		go purgeExpiredIdempotency(idempotencyRepo, time.Hour)
+	}
+
 	// Init Repository → Service → Handler
 	userRepo := repository.NewUserRepository(dbConn)
 	userService := service.NewUserService(userRepo, jwtMaker)
 	userHandler := handler.NewUserHandler(userService)
 
+	// Init Workflow components
+	workflowRepo := repository.NewWorkflowRepository(dbConn)
+	workflowService := service.NewWorkflowService(workflowRepo)
+	workflowHandler := handler.NewWorkflowHandler(workflowService)
+
+	// Init Search index (Postgres tsvector; SQLite falls back to LIKE)
+	searchIndex, err := repository.NewSearchIndex(dbConn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Init Project components
+	projectRepo := repository.NewProjectRepository(dbConn)
+	projectService := service.NewProjectService(projectRepo, userRepo)
+	projectHandler := handler.NewProjectHandler(projectService)
+
+	// Init Webhook components (deliveries are sent by a background dispatcher)
+	webhookRepo := repository.NewWebhookRepository(dbConn)
+	webhookDispatcher := service.NewWebhookDispatcher(webhookRepo, nil)
+	webhookService := service.NewWebhookService(webhookRepo, webhookDispatcher)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	go webhookDispatcher.Run(context.Background())
+
+	// Init real-time stream (Redis pub/sub across instances, in-process otherwise)
+	var broker realtime.Broker
+	if redisClient != nil {
+		broker = realtime.NewRedisBroker(context.Background(), redisClient)
+	} else {
+		broker = realtime.NewMemoryBroker()
+	}
+	streamService := service.NewStreamService(broker)
+	streamHandler := handler.NewStreamHandler(streamService)
+
+	// Init project board collaboration (WebSocket; task changes come from the stream broker)
+	boardService := service.NewBoardService(realtime.NewBoardHub(), broker, projectRepo)
+	boardHandler := handler.NewBoardHandler(boardService, handler.WithAllowedOrigins(cfg.BoardAllowedOrigins...))
+
 	// Init Task components
 	taskRepo := repository.NewTaskRepository(dbConn)
-	taskService := service.NewTaskService(taskRepo, redisClient, cfg.CacheTTLTasks)
-	taskHandler := handler.NewTaskHandler(taskService)
+	taskService := service.NewTaskService(taskRepo, cacheStore, cfg.CacheTTLTasks,
+		service.WithWorkflowRepository(workflowRepo),
+		service.WithCursorCodec(util.NewCursorCodec(cfg.CursorSecret)),
+		service.WithSearchIndex(searchIndex),
+		service.WithProjectRepository(projectRepo),
+		service.WithEventPublisher(webhookService),
+		service.WithEventPublisher(streamService),
+	)
+	syncHandler := handler.NewSyncHandler(taskService)
+
+	// Init Import components
+	importJobRepo := repository.NewImportJobRepository(dbConn)
+	importService := service.NewImportService(taskService, importJobRepo)
+	go failStaleImports(importService, service.StaleImportAfter/2)
+	importHandler := handler.NewImportHandler(importService)
+
+	// Init Calendar feed components
+	feedTokenRepo := repository.NewFeedTokenRepository(dbConn)
+	feedService := service.NewFeedService(feedTokenRepo, taskRepo)
+	feedHandler := handler.NewFeedHandler(feedService)
+
+	// Init Comment components
+	commentRepo := repository.NewCommentRepository(dbConn)
+	commentService := service.NewCommentService(commentRepo, taskRepo, searchIndex)
+	commentHandler := handler.NewCommentHandler(commentService)
+
+	taskHandler := handler.NewTaskHandler(taskService,
+		handler.WithCommentService(commentService),
+		handler.WithProjectService(projectService),
+	)
+
+	// Init Saved view components
+	viewRepo := repository.NewSavedViewRepository(dbConn)
+	viewService := service.NewViewService(viewRepo, taskService, cacheStore, cfg.CacheTTLTasks)
+	viewHandler := handler.NewViewHandler(viewService)
+
+	// Init GraphQL (same services as the REST handlers)
+	graphQLHandler := handler.NewGraphQLHandler(graph.NewServer(
+		graph.NewResolver(userService, taskService, commentService, projectService),
+	))
 
 	return &Container{
-		Config:        cfg,
-		DB:            dbConn,
-		RedisClient:   redisClient,
-		JWTMiddleware: jwtMiddleware,
-		UserHandler:   userHandler,
-		TaskHandler:   taskHandler,
+		Config:          cfg,
+		DB:              dbConn,
+		RedisClient:     redisClient,
+		Cache:           cacheStore,
+		JWTMiddleware:   jwtMiddleware,
+		IdempotencyMW:   idempotencyMW,
+		ErrorMW:         middleware.ErrorHandler(),
+		UserHandler:     userHandler,
+		TaskHandler:     taskHandler,
+		WorkflowHandler: workflowHandler,
+		CommentHandler:  commentHandler,
+		ViewHandler:     viewHandler,
+		ProjectHandler:  projectHandler,
+		ImportHandler:   importHandler,
+		FeedHandler:     feedHandler,
+		WebhookHandler:  webhookHandler,
+		StreamHandler:   streamHandler,
+		BoardHandler:    boardHandler,
+		SyncHandler:     syncHandler,
+		GraphQLHandler:  graphQLHandler,
+		GRPCServer:      grpcapi.NewServer(userService, taskService, jwtMaker),
 	}, nil
 }
+
+// cacheJitter 是寫入快取時 TTL 的隨機抖動比例（±10%）。
+const cacheJitter = 0.1
+
+// newCacheStore 依 CACHE_BACKEND 建立快取；none 時回傳 nil，service 直接查資料庫。
+func newCacheStore(cfg *config.Config, redisClient *redis.Client) (cache.Store, error) {
+	var store cache.Store
+	switch cfg.CacheBackend {
+	case "none":
+		return nil, nil
+	case "memory":
+		store = cache.NewMemoryStore(cfg.CacheMemoryEntries)
+	case "redis", "near":
+		if redisClient == nil {
+			return nil, errors.New("app: CACHE_BACKEND=" + cfg.CacheBackend + " requires REDIS_ADDR")
+		}
+		store = cache.NewRedisStore(redisClient)
+		if cfg.CacheBackend == "near" {
+			store = cache.NewNearStore(cache.NewMemoryStore(cfg.CacheMemoryEntries), store, cfg.CacheNearTTL)
+		}
+	default:
+		return nil, errors.New("app: unknown CACHE_BACKEND " + cfg.CacheBackend)
+	}
+	return cache.WithJitter(store, cacheJitter), nil
+}
+
+// purgeExpiredIdempotency 定期清除資料表中過期的 Idempotency-Key 紀錄（Redis 由 TTL 自動過期）。
+func purgeExpiredIdempotency(repo repository.IdempotencyRepository, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for range ticker.C {
+		if _, err := repo.DeleteExpired(context.Background(), time.Now()); err != nil {
+			log.Printf("idempotency: purge expired: %v", err)
+		}
+	}
+}
+
+// failStaleImports 在啟動時與之後定期把中斷的匯入工作標為 failed，client 不會一直看到 running。
+func failStaleImports(svc service.ImportService, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		if n, err := svc.FailStaleJobs(context.Background()); err != nil {
+			log.Printf("import: fail stale jobs: %v", err)
+		} else if n > 0 {
+			log.Printf("import: marked %d interrupted jobs as failed", n)
+		}
+		<-ticker.C
+	}
+}
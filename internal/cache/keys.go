@@ -1,6 +1,10 @@
 package cache
 
-import "strconv"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
 
 const TasksKeyVersion = "v1"
 
@@ -8,3 +12,80 @@ const TasksKeyVersion = "v1"
 func KeyUserTasks(userID uint) string {
 	return "user:" + strconv.FormatUint(uint64(userID), 10) + ":tasks:" + TasksKeyVersion
 }
+
+// taskKeyVersion 是單筆任務快取的格式版本；v2 的值帶有填入時的世代號。
+const taskKeyVersion = "v2"
+
+// KeyTask 生成單筆任務的快取 key：task:<id>:v2
+// 任務 id 全域唯一，key 不含 user；找不到的任務也會短暫快取。
+func KeyTask(taskID uint) string {
+	return "task:" + strconv.FormatUint(uint64(taskID), 10) + ":" + taskKeyVersion
+}
+
+// KeyTaskOwner 記錄任務的擁有者：task:<id>:owner
+// 寫入時不會刪除它，填入快取時可以在讀取資料庫之前先取得擁有者的世代號。
+func KeyTaskOwner(taskID uint) string {
+	return "task:" + strconv.FormatUint(uint64(taskID), 10) + ":owner"
+}
+
+// KeyUserTasksGen 存放 user 任務分頁快取的世代號：user:<uid>:tasks:gen:v1
+// 寫入時只需 INCR 世代號，舊世代的分頁會自然過期，不必逐一刪除。
+func KeyUserTasksGen(userID uint) string {
+	return "user:" + strconv.FormatUint(uint64(userID), 10) + ":tasks:gen:" + TasksKeyVersion
+}
+
+// KeyUserTasksPage 生成分頁快取 key：user:<uid>:tasks:v1:g<gen>:page:<query>
+// query 為正規化後的查詢（排序、筆數、游標），過長時以雜湊縮短。
+func KeyUserTasksPage(userID uint, gen int64, query string) string {
+	return "user:" + strconv.FormatUint(uint64(userID), 10) + ":tasks:" + TasksKeyVersion +
+		":g" + strconv.FormatInt(gen, 10) + ":page:" + shortHash(query)
+}
+
+// KeyUserTasksCount 生成任務總數快取 key：user:<uid>:tasks:v1:g<gen>:count
+func KeyUserTasksCount(userID uint, gen int64) string {
+	return "user:" + strconv.FormatUint(uint64(userID), 10) + ":tasks:" + TasksKeyVersion +
+		":g" + strconv.FormatInt(gen, 10) + ":count"
+}
+
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
+
+// KeyUserViewGen 存放單一 saved view 的世代號：user:<uid>:views:<vid>:gen:v1
+// view 的條件被修改或刪除時遞增，讓該 view 的舊分頁失效。
+func KeyUserViewGen(userID, viewID uint) string {
+	return "user:" + strconv.FormatUint(uint64(userID), 10) + ":views:" +
+		strconv.FormatUint(uint64(viewID), 10) + ":gen:" + TasksKeyVersion
+}
+
+// KeyUserViewPage 生成 saved view 分頁快取 key：user:<uid>:views:<vid>:v1:g<taskGen>.<viewGen>:page:<query>
+// 同時帶任務世代號與 view 世代號，任務寫入或 view 修改都會讓頁面失效。
+func KeyUserViewPage(userID, viewID uint, taskGen, viewGen int64, query string) string {
+	return "user:" + strconv.FormatUint(uint64(userID), 10) + ":views:" +
+		strconv.FormatUint(uint64(viewID), 10) + ":" + TasksKeyVersion +
+		":g" + strconv.FormatInt(taskGen, 10) + "." + strconv.FormatInt(viewGen, 10) + ":page:" + shortHash(query)
+}
+
+// KeyIdempotency 生成 Idempotency-Key 的快取 key：user:<uid>:idem:v1:<hash(key)>
+func KeyIdempotency(userID uint, key string) string {
+	return "user:" + strconv.FormatUint(uint64(userID), 10) + ":idem:" + TasksKeyVersion + ":" + shortHash(key)
+}
+
+// KeyUserEventSeq 存放使用者即時事件的流水號：user:<uid>:events:seq:v1
+func KeyUserEventSeq(userID uint) string {
+	return "user:" + strconv.FormatUint(uint64(userID), 10) + ":events:seq:" + TasksKeyVersion
+}
+
+// KeyUserEventLog 保存最近的即時事件（供 Last-Event-ID 補送）：user:<uid>:events:log:v1
+func KeyUserEventLog(userID uint) string {
+	return "user:" + strconv.FormatUint(uint64(userID), 10) + ":events:log:" + TasksKeyVersion
+}
+
+// ChannelUserEvents 是使用者即時事件的 pub/sub channel：user:<uid>:events:v1
+func ChannelUserEvents(userID uint) string {
+	return "user:" + strconv.FormatUint(uint64(userID), 10) + ":events:" + TasksKeyVersion
+}
+
+// ChannelUserEventsPattern 訂閱所有使用者的即時事件 channel。
+const ChannelUserEventsPattern = "user:*:events:" + TasksKeyVersion
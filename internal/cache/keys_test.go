@@ -1,6 +1,7 @@
 package cache_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/SoliMark/gotasker-pro/internal/cache"
@@ -12,3 +13,54 @@ func TestKeyUserTasks(t *testing.T) {
 		t.Fatalf("got %q", k)
 	}
 }
+
+func TestKeyTask(t *testing.T) {
+	if k := cache.KeyTask(42); k != "task:42:v2" {
+		t.Fatalf("got %q", k)
+	}
+}
+
+func TestKeyUserTasksPage(t *testing.T) {
+	a := cache.KeyUserTasksPage(42, 3, "created_at|50|")
+	b := cache.KeyUserTasksPage(42, 3, "created_at|50|cursor")
+	c := cache.KeyUserTasksPage(42, 4, "created_at|50|")
+
+	if !strings.HasPrefix(a, "user:42:tasks:v1:g3:page:") {
+		t.Fatalf("got %q", a)
+	}
+	if a == b || a == c {
+		t.Fatalf("keys should differ by query and generation: %q %q %q", a, b, c)
+	}
+	if k := cache.KeyUserTasksGen(42); k != "user:42:tasks:gen:v1" {
+		t.Fatalf("got %q", k)
+	}
+	if k := cache.KeyUserTasksCount(42, 3); k != "user:42:tasks:v1:g3:count" {
+		t.Fatalf("got %q", k)
+	}
+}
+
+func TestKeyUserViewPage(t *testing.T) {
+	a := cache.KeyUserViewPage(42, 7, 3, 1, "50|")
+	b := cache.KeyUserViewPage(42, 7, 3, 2, "50|")
+	c := cache.KeyUserViewPage(42, 7, 4, 1, "50|")
+
+	if !strings.HasPrefix(a, "user:42:views:7:v1:g3.1:page:") {
+		t.Fatalf("got %q", a)
+	}
+	if a == b || a == c {
+		t.Fatalf("keys should differ by task and view generation: %q %q %q", a, b, c)
+	}
+	if k := cache.KeyUserViewGen(42, 7); k != "user:42:views:7:gen:v1" {
+		t.Fatalf("got %q", k)
+	}
+}
+
+func TestKeyIdempotency(t *testing.T) {
+	a := cache.KeyIdempotency(42, "abc")
+	if !strings.HasPrefix(a, "user:42:idem:v1:") {
+		t.Fatalf("got %q", a)
+	}
+	if a == cache.KeyIdempotency(43, "abc") || a == cache.KeyIdempotency(42, "abd") {
+		t.Fatalf("keys should differ by user and key")
+	}
+}
@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MemoryStore 是程序內、以筆數為上限的 LRU 快取，只適合單一 instance 或作為 NearStore 的近端。
+// Incr 的計數器與一般的值一起計入上限；計數器被淘汰後重新建立時從 counterFloor 之後開始，
+// 世代號因此不會回到被淘汰前用過的值，舊世代的頁面不會重新被讀到。
+type MemoryStore struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List // 最近使用的在前面
+	items    map[string]*list.Element
+	now      func() time.Time
+	// counterFloor 是被淘汰的計數器中最大的值
+	counterFloor int64
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time // 零值表示不過期
+	counter   bool      // 由 Incr 寫入
+}
+
+// NewMemoryStore 建立最多保存 capacity 筆的 LRU；capacity <= 0 時視為 1。
+func NewMemoryStore(capacity int) *MemoryStore {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &MemoryStore{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    map[string]*list.Element{},
+		now:      time.Now,
+	}
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.items[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	e := el.Value.(*memoryEntry)
+	if !e.expiresAt.IsZero() && !s.now().Before(e.expiresAt) {
+		s.remove(el)
+		return nil, ErrMiss
+	}
+	s.ll.MoveToFront(el)
+	return e.value, nil
+}
+
+func (s *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = s.now().Add(ttl)
+	}
+	// 複製一份，呼叫端之後修改 slice 不影響快取內容
+	value = append([]byte(nil), value...)
+
+	if el, ok := s.items[key]; ok {
+		e := el.Value.(*memoryEntry)
+		e.value, e.expiresAt, e.counter = value, expiresAt, false
+		s.ll.MoveToFront(el)
+		return nil
+	}
+	s.push(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	return nil
+}
+
+func (s *MemoryStore) Del(_ context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := s.items[key]; ok {
+			s.remove(el)
+		}
+	}
+	return nil
+}
+
+// DelPattern 以 path.Match 比對；key 不含 / 時與 Redis 的 glob 行為相同。
+func (s *MemoryStore) DelPattern(_ context.Context, pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, el := range s.items {
+		if ok, _ := path.Match(pattern, key); ok {
+			s.remove(el)
+		}
+	}
+	return nil
+}
+
+// Incr 遞增計數器；key 原本是一般的值時與 Redis 相同，需為十進位整數，且保留原本的過期時間。
+func (s *MemoryStore) Incr(_ context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.items[key]; ok {
+		e := el.Value.(*memoryEntry)
+		if e.expiresAt.IsZero() || s.now().Before(e.expiresAt) {
+			n, err := strconv.ParseInt(string(e.value), 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			n++
+			e.value, e.counter = strconv.AppendInt(nil, n, 10), true
+			s.ll.MoveToFront(el)
+			return n, nil
+		}
+		s.remove(el)
+	}
+	n := s.counterFloor + 1
+	s.push(&memoryEntry{key: key, value: strconv.AppendInt(nil, n, 10), counter: true})
+	return n, nil
+}
+
+// Len 回傳目前保存的筆數（含計數器），過期但尚未被讀到的也會計入。
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ll.Len()
+}
+
+// push 加入新的一筆，超過上限時淘汰最久未使用的，並記下被淘汰計數器的值。
+func (s *MemoryStore) push(e *memoryEntry) {
+	s.items[e.key] = s.ll.PushFront(e)
+	for s.ll.Len() > s.capacity {
+		el := s.ll.Back()
+		if old := el.Value.(*memoryEntry); old.counter {
+			if n, err := strconv.ParseInt(string(old.value), 10, 64); err == nil && n > s.counterFloor {
+				s.counterFloor = n
+			}
+		}
+		s.remove(el)
+	}
+}
+
+func (s *MemoryStore) remove(el *list.Element) {
+	s.ll.Remove(el)
+	delete(s.items, el.Value.(*memoryEntry).key)
+}
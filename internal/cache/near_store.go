@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// NearStore 是兩層快取：先讀程序內的 local，miss 時讀共用的 remote 並回填 local。
+// 本 instance 的寫入會同步更新兩層；其他 instance 的寫入只會在 local 的 TTL（最多 localTTL）到期後才看得到，
+// 因此 localTTL 就是跨 instance 可接受的最長過期時間。
+type NearStore struct {
+	local    Store
+	remote   Store
+	localTTL time.Duration
+}
+
+func NewNearStore(local, remote Store, localTTL time.Duration) *NearStore {
+	return &NearStore{local: local, remote: remote, localTTL: localTTL}
+}
+
+func (s *NearStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if b, err := s.local.Get(ctx, key); err == nil {
+		return b, nil
+	}
+	b, err := s.remote.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	_ = s.local.Set(ctx, key, b, s.localTTL)
+	return b, nil
+}
+
+func (s *NearStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := s.remote.Set(ctx, key, value, ttl); err != nil {
+		// 遠端寫入失敗時不能留下舊值
+		_ = s.local.Del(ctx, key)
+		return err
+	}
+	return s.local.Set(ctx, key, value, s.capLocal(ttl))
+}
+
+func (s *NearStore) Del(ctx context.Context, keys ...string) error {
+	return errors.Join(s.local.Del(ctx, keys...), s.remote.Del(ctx, keys...))
+}
+
+func (s *NearStore) DelPattern(ctx context.Context, pattern string) error {
+	return errors.Join(s.local.DelPattern(ctx, pattern), s.remote.DelPattern(ctx, pattern))
+}
+
+// Incr 只在 remote 計數，之後刪除 local 的舊值，下次讀取時再回填。
+func (s *NearStore) Incr(ctx context.Context, key string) (int64, error) {
+	n, err := s.remote.Incr(ctx, key)
+	_ = s.local.Del(ctx, key)
+	return n, err
+}
+
+func (s *NearStore) capLocal(ttl time.Duration) time.Duration {
+	if ttl <= 0 || ttl > s.localTTL {
+		return s.localTTL
+	}
+	return ttl
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrMiss 表示 key 不存在或已過期。
+var ErrMiss = errors.New("cache miss")
+
+// Store 是快取後端。ttl <= 0 表示不過期；pattern 使用與 Redis SCAN MATCH 相同的 glob（* 與 ?）。
+// Incr 用於世代號等計數器，值以十進位字串保存，可用 GetInt64 讀取。
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Del(ctx context.Context, keys ...string) error
+	DelPattern(ctx context.Context, pattern string) error
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// GetInt64 讀取 Incr 或以十進位字串寫入的值；不存在時回傳 ErrMiss。
+func GetInt64(ctx context.Context, s Store, key string) (int64, error) {
+	b, err := s.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(b), 10, 64)
+}
+
+// SetInt64 以十進位字串寫入 n，與 Incr 的格式相同。
+func SetInt64(ctx context.Context, s Store, key string, n int64, ttl time.Duration) error {
+	return s.Set(ctx, key, strconv.AppendInt(nil, n, 10), ttl)
+}
+
+// WithJitter 讓寫入的 TTL 加上 ±ratio 的隨機抖動，避免同時寫入的 key 同時過期。
+func WithJitter(s Store, ratio float64) Store {
+	return &jitterStore{Store: s, ratio: ratio}
+}
+
+type jitterStore struct {
+	Store
+	ratio  float64
+	jitter Jitter
+}
+
+func (s *jitterStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.Store.Set(ctx, key, value, s.jitter.TTL(ttl, s.ratio))
+}
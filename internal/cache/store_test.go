@@ -0,0 +1,213 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/SoliMark/gotasker-pro/internal/cache"
+)
+
+func newRedisStore(t *testing.T) (*cache.RedisStore, *miniredis.Miniredis) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mr.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return cache.NewRedisStore(rdb), mr
+}
+
+// 每種 Store 都要有相同的行為。
+func TestStoreContract(t *testing.T) {
+	stores := map[string]func(t *testing.T) cache.Store{
+		"memory": func(t *testing.T) cache.Store { return cache.NewMemoryStore(100) },
+		"redis": func(t *testing.T) cache.Store {
+			s, _ := newRedisStore(t)
+			return s
+		},
+		"near": func(t *testing.T) cache.Store {
+			s, _ := newRedisStore(t)
+			return cache.NewNearStore(cache.NewMemoryStore(100), s, time.Second)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newStore(t)
+
+			if _, err := s.Get(ctx, "missing"); !errors.Is(err, cache.ErrMiss) {
+				t.Fatalf("want ErrMiss, got %v", err)
+			}
+
+			if err := s.Set(ctx, "user:1:tasks:v1", []byte("a"), time.Minute); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := s.Get(ctx, "user:1:tasks:v1"); err != nil || string(got) != "a" {
+				t.Fatalf("got %q, %v", got, err)
+			}
+
+			if n, err := s.Incr(ctx, "gen"); err != nil || n != 1 {
+				t.Fatalf("got %d, %v", n, err)
+			}
+			if n, _ := s.Incr(ctx, "gen"); n != 2 {
+				t.Fatalf("got %d", n)
+			}
+			if n, err := cache.GetInt64(ctx, s, "gen"); err != nil || n != 2 {
+				t.Fatalf("got %d, %v", n, err)
+			}
+			if err := cache.SetInt64(ctx, s, "count", 7, time.Minute); err != nil {
+				t.Fatal(err)
+			}
+			if n, _ := s.Incr(ctx, "count"); n != 8 {
+				t.Fatalf("Incr after SetInt64: got %d", n)
+			}
+
+			_ = s.Set(ctx, "user:1:tasks:v1:g1:page:x", []byte("p"), 0)
+			_ = s.Set(ctx, "user:2:tasks:v1", []byte("b"), 0)
+			if err := s.DelPattern(ctx, "user:1:*"); err != nil {
+				t.Fatal(err)
+			}
+			for _, key := range []string{"user:1:tasks:v1", "user:1:tasks:v1:g1:page:x"} {
+				if _, err := s.Get(ctx, key); !errors.Is(err, cache.ErrMiss) {
+					t.Fatalf("%s should be deleted, got %v", key, err)
+				}
+			}
+			if _, err := s.Get(ctx, "user:2:tasks:v1"); err != nil {
+				t.Fatalf("user:2 should be kept: %v", err)
+			}
+
+			if err := s.Del(ctx, "user:2:tasks:v1", "gen"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.Get(ctx, "gen"); !errors.Is(err, cache.ErrMiss) {
+				t.Fatalf("gen should be deleted, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	s := cache.NewMemoryStore(2)
+
+	_ = s.Set(ctx, "a", []byte("1"), 0)
+	_ = s.Set(ctx, "b", []byte("2"), 0)
+	_, _ = s.Get(ctx, "a") // a 變成最近使用
+	_ = s.Set(ctx, "c", []byte("3"), 0)
+
+	if _, err := s.Get(ctx, "b"); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("b should be evicted, got %v", err)
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, err := s.Get(ctx, key); err != nil {
+			t.Fatalf("%s should be kept: %v", key, err)
+		}
+	}
+	if s.Len() != 2 {
+		t.Fatalf("len = %d", s.Len())
+	}
+}
+
+func TestMemoryStoreEvictedCounterDoesNotRestart(t *testing.T) {
+	ctx := context.Background()
+	s := cache.NewMemoryStore(2)
+
+	for i := 0; i < 5; i++ {
+		_, _ = s.Incr(ctx, "gen:1")
+	}
+	// 計數器也計入上限，大量不同的 key 不會讓記憶體無限成長
+	for i := 0; i < 100; i++ {
+		_, _ = s.Incr(ctx, "gen:other:"+strconv.Itoa(i))
+	}
+	if s.Len() != 2 {
+		t.Fatalf("len = %d", s.Len())
+	}
+	if _, err := s.Get(ctx, "gen:1"); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("gen:1 should be evicted, got %v", err)
+	}
+	// 重新建立的世代號不會回到 1..5，舊世代的頁面不會再被讀到
+	if n, _ := s.Incr(ctx, "gen:1"); n <= 5 {
+		t.Fatalf("recreated counter reused an old value: %d", n)
+	}
+
+	// 明確刪除與 Redis 相同，從 1 重新開始
+	s = cache.NewMemoryStore(10)
+	_, _ = s.Incr(ctx, "gen")
+	_ = s.Del(ctx, "gen")
+	if n, _ := s.Incr(ctx, "gen"); n != 1 {
+		t.Fatalf("got %d after Del", n)
+	}
+}
+
+func TestMemoryStoreExpires(t *testing.T) {
+	ctx := context.Background()
+	s := cache.NewMemoryStore(10)
+
+	_ = s.Set(ctx, "k", []byte("v"), 20*time.Millisecond)
+	if _, err := s.Get(ctx, "k"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err := s.Get(ctx, "k"); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("want ErrMiss after TTL, got %v", err)
+	}
+}
+
+func TestNearStoreStalenessIsBoundedByLocalTTL(t *testing.T) {
+	ctx := context.Background()
+	remote, _ := newRedisStore(t)
+	a := cache.NewNearStore(cache.NewMemoryStore(10), remote, 50*time.Millisecond)
+	b := cache.NewNearStore(cache.NewMemoryStore(10), remote, 50*time.Millisecond)
+
+	_ = a.Set(ctx, "k", []byte("v1"), time.Minute)
+	if got, _ := b.Get(ctx, "k"); string(got) != "v1" {
+		t.Fatalf("b got %q", got)
+	}
+
+	// a 的寫入立即對 a 生效；b 在近端過期前仍讀到舊值
+	_ = a.Set(ctx, "k", []byte("v2"), time.Minute)
+	if got, _ := a.Get(ctx, "k"); string(got) != "v2" {
+		t.Fatalf("a got %q", got)
+	}
+	if got, _ := b.Get(ctx, "k"); string(got) != "v1" {
+		t.Fatalf("b should still see the local copy, got %q", got)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if got, _ := b.Get(ctx, "k"); string(got) != "v2" {
+		t.Fatalf("b got %q after local TTL", got)
+	}
+
+	// Incr 不會讓近端留下舊的世代號
+	_, _ = a.Incr(ctx, "gen")
+	_, _ = a.Get(ctx, "gen")
+	_, _ = a.Incr(ctx, "gen")
+	if n, _ := cache.GetInt64(ctx, a, "gen"); n != 2 {
+		t.Fatalf("gen = %d", n)
+	}
+}
+
+func TestWithJitter(t *testing.T) {
+	ctx := context.Background()
+	s, mr := newRedisStore(t)
+	j := cache.WithJitter(s, 0.1)
+
+	base := time.Minute
+	for i := 0; i < 20; i++ {
+		_ = j.Set(ctx, "k", []byte("v"), base)
+		if ttl := mr.TTL("k"); ttl < base-base/10 || ttl > base+base/10 {
+			t.Fatalf("ttl %v out of range", ttl)
+		}
+	}
+	if n, _ := j.Incr(ctx, "gen"); n != 1 {
+		t.Fatalf("Incr should pass through, got %d", n)
+	}
+}
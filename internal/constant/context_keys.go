@@ -3,7 +3,3 @@ package constant
 const (
 	ContextUserIDKey = "user_id"
 )
-
-const (
-	ErrorKey = "error"
-)
@@ -5,8 +5,30 @@ const (
 	HeaderContentType   = "Content-Type"
 	HeaderAccept        = "Accept"
 	HeaderUserAgent     = "User-Agent"
+	HeaderTotalCount    = "X-Total-Count"
+	HeaderLink          = "Link"
+	HeaderLocation      = "Location"
+
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	HeaderWebhookEvent     = "X-GoTasker-Event"
+	HeaderWebhookDelivery  = "X-GoTasker-Delivery"
+	HeaderWebhookSignature = "X-GoTasker-Signature"
 )
 
 const (
 	ContentTypeJSON = "application/json"
 )
+
+// 瀏覽器的 WebSocket API 無法設定 Authorization header，改以 Sec-WebSocket-Protocol 帶 JWT：
+// client 同時提供 WebSocketProtocolBoard 與 "access_token.<JWT>"，伺服器只回應前者。
+// 不使用 query 參數，避免 token 被寫進 access log。
+const (
+	HeaderWebSocketProtocol      = "Sec-WebSocket-Protocol"
+	WebSocketProtocolBoard       = "gotasker.board.v1"
+	WebSocketTokenProtocolPrefix = "access_token."
+)
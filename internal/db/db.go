@@ -20,7 +20,25 @@ func NewDB(dsn string) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to connect DB: %w", err)
 	}
 
-	err = db.AutoMigrate(&model.User{})
+	err = db.AutoMigrate(
+		&model.User{},
+		&model.Task{},
+		&model.Workflow{},
+		&model.WorkflowState{},
+		&model.WorkflowTransition{},
+		&model.Comment{},
+		&model.SavedView{},
+		&model.Project{},
+		&model.ProjectMember{},
+		&model.Tag{},
+		&model.IdempotencyRecord{},
+		&model.ImportJob{},
+		&model.FeedToken{},
+		&model.Webhook{},
+		&model.WebhookDelivery{},
+		&model.SyncCounter{},
+		&model.TaskTombstone{},
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to auto migrate: %w", err)
 	}
@@ -0,0 +1,65 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+// CSVHeader 是 CSV 匯出的欄位順序。
+var CSVHeader = []string{
+	"id", "title", "content", "status", "priority", "due_at", "project_id", "completed_at", "created_at", "updated_at",
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) Begin() error {
+	return e.w.Write(CSVHeader)
+}
+
+func (e *csvEncoder) Encode(t *model.Task) error {
+	r := NewRecord(t)
+	projectID := ""
+	if r.ProjectID != nil {
+		projectID = strconv.FormatUint(uint64(*r.ProjectID), 10)
+	}
+	err := e.w.Write([]string{
+		strconv.FormatUint(uint64(r.ID), 10),
+		r.Title,
+		r.Content,
+		r.Status,
+		strconv.Itoa(r.Priority),
+		formatOptionalTime(r.DueAt),
+		projectID,
+		formatOptionalTime(r.CompletedAt),
+		r.CreatedAt.UTC().Format(time.RFC3339),
+		r.UpdatedAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	// csv.Writer 會緩衝，定期 flush 讓資料真的串流出去
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) End() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,81 @@
+// Package export 將任務編碼成 CSV、JSON 或 iCalendar，逐筆寫出以支援串流。
+package export
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	FormatICS  = "ics"
+)
+
+// Encoder 逐筆寫出任務：先呼叫 Begin，每筆任務呼叫 Encode，最後呼叫 End。
+type Encoder interface {
+	Begin() error
+	Encode(t *model.Task) error
+	End() error
+}
+
+// NewEncoder 依格式建立 Encoder。
+func NewEncoder(format string, w io.Writer) (Encoder, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVEncoder(w), nil
+	case FormatJSON:
+		return newJSONEncoder(w), nil
+	case FormatICS:
+		return newICSEncoder(w), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// ContentType 回傳格式對應的 MIME type。
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatICS:
+		return "text/calendar; charset=utf-8"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// Record 是 CSV / JSON 匯出的欄位，兩種格式使用相同的欄位名稱。
+type Record struct {
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Content     string     `json:"content"`
+	Status      string     `json:"status"`
+	Priority    int        `json:"priority"`
+	DueAt       *time.Time `json:"due_at"`
+	ProjectID   *uint      `json:"project_id"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// NewRecord 把任務轉成對外欄位，webhook 的 payload 也使用相同格式。
+func NewRecord(t *model.Task) Record {
+	return Record{
+		ID:          t.ID,
+		Title:       t.Title,
+		Content:     t.Content,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		DueAt:       t.DueAt,
+		ProjectID:   t.ProjectID,
+		CompletedAt: t.CompletedAt,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+func sampleTasks() []*model.Task {
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2024, 3, 5, 17, 30, 0, 0, time.FixedZone("UTC+8", 8*3600))
+	done := created.Add(time.Hour)
+	return []*model.Task{
+		{ID: 1, Title: "Write report, v2", Content: "line1\nline2; more", Status: "pending", Priority: 1, DueAt: &due, CreatedAt: created, UpdatedAt: created},
+		{ID: 2, Title: "Ship", Status: "done", CompletedAt: &done, CreatedAt: created, UpdatedAt: done},
+	}
+}
+
+func encodeAll(t *testing.T, format string) string {
+	var buf bytes.Buffer
+	enc, err := NewEncoder(format, &buf)
+	require.NoError(t, err)
+	require.NoError(t, enc.Begin())
+	for _, task := range sampleTasks() {
+		require.NoError(t, enc.Encode(task))
+	}
+	require.NoError(t, enc.End())
+	return buf.String()
+}
+
+func TestCSVEncoder(t *testing.T) {
+	rows, err := csv.NewReader(strings.NewReader(encodeAll(t, FormatCSV))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, CSVHeader, rows[0])
+	assert.Equal(t, "Write report, v2", rows[1][1])
+	assert.Equal(t, "line1\nline2; more", rows[1][2])
+	assert.Equal(t, "2024-03-05T09:30:00Z", rows[1][5])
+	assert.Equal(t, "", rows[2][5])
+}
+
+func TestJSONEncoder(t *testing.T) {
+	var records []Record
+	require.NoError(t, json.Unmarshal([]byte(encodeAll(t, FormatJSON)), &records))
+	require.Len(t, records, 2)
+	assert.Equal(t, 1, records[0].Priority)
+	assert.NotNil(t, records[1].CompletedAt)
+
+	// 沒有任務時仍是合法的 JSON array
+	var buf bytes.Buffer
+	enc, _ := NewEncoder(FormatJSON, &buf)
+	require.NoError(t, enc.Begin())
+	require.NoError(t, enc.End())
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestICSEncoder(t *testing.T) {
+	out := encodeAll(t, FormatICS)
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Equal(t, 2, strings.Count(out, "BEGIN:VTODO"))
+	assert.Contains(t, out, "UID:task-1@gotasker-pro\r\n")
+	assert.Contains(t, out, `SUMMARY:Write report\, v2`)
+	assert.Contains(t, out, `DESCRIPTION:line1\nline2\; more`)
+	assert.Contains(t, out, "DUE:20240305T093000Z\r\n")
+	assert.Contains(t, out, "PRIORITY:1\r\n")
+	assert.Contains(t, out, "STATUS:NEEDS-ACTION\r\n")
+	assert.Contains(t, out, "STATUS:COMPLETED\r\nCOMPLETED:20240301T100000Z\r\n")
+}
+
+func TestICSLineFolding(t *testing.T) {
+	var buf bytes.Buffer
+	e := newICSEncoder(&buf)
+	e.line("SUMMARY:" + strings.Repeat("任務", 40))
+	require.NoError(t, e.w.Flush())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Greater(t, len(lines), 1)
+	var joined strings.Builder
+	for i, l := range lines {
+		assert.LessOrEqual(t, len(l), icsMaxLine)
+		if i > 0 {
+			require.True(t, strings.HasPrefix(l, " "))
+			l = l[1:]
+		}
+		joined.WriteString(l)
+	}
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("任務", 40), joined.String())
+}
+
+func TestNewEncoder_UnknownFormat(t *testing.T) {
+	_, err := NewEncoder("xml", &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestFeedEncoder(t *testing.T) {
+	render := func() string {
+		var buf bytes.Buffer
+		enc := NewFeedEncoder(&buf, "My tasks")
+		require.NoError(t, enc.Begin())
+		for _, task := range sampleTasks() {
+			require.NoError(t, enc.Encode(task))
+		}
+		require.NoError(t, enc.End())
+		return buf.String()
+	}
+
+	out := render()
+	assert.Contains(t, out, "X-WR-CALNAME:My tasks\r\n")
+	assert.Contains(t, out, "REFRESH-INTERVAL;VALUE=DURATION:PT1H\r\n")
+	// 只有有截止時間的任務會產生事件
+	assert.Equal(t, 1, strings.Count(out, "BEGIN:VEVENT"))
+	assert.Equal(t, 2, strings.Count(out, "BEGIN:VTODO"))
+	assert.Contains(t, out, "UID:due-task-1@gotasker-pro\r\nDTSTAMP:20240301T090000Z\r\nDTSTART:20240305T093000Z\r\n")
+	// 內容沒變時輸出相同，ETag 才會穩定
+	assert.Equal(t, out, render())
+}
@@ -0,0 +1,149 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+const (
+	icsProdID    = "-//gotasker-pro//tasks//EN"
+	icsTimestamp = "20060102T150405Z"
+	icsMaxLine   = 75
+)
+
+// feedRefresh 建議訂閱端的輪詢間隔（REFRESH-INTERVAL / X-PUBLISHED-TTL）。
+const feedRefresh = "PT1H"
+
+// icsEncoder 依 RFC 5545 寫出 VCALENDAR，每個任務一個 VTODO。
+// feed 模式另外為有截止時間的任務寫出 VEVENT（Google Calendar、Outlook 只顯示事件），
+// 並以任務的 UpdatedAt 作為 DTSTAMP，讓內容不變時輸出完全相同、可以比對 ETag。
+type icsEncoder struct {
+	w    *bufio.Writer
+	now  time.Time
+	feed bool
+	name string
+}
+
+func newICSEncoder(w io.Writer) *icsEncoder {
+	return &icsEncoder{w: bufio.NewWriter(w), now: time.Now()}
+}
+
+// NewFeedEncoder 建立訂閱用的 iCalendar Encoder，name 為日曆顯示名稱。
+func NewFeedEncoder(w io.Writer, name string) Encoder {
+	return &icsEncoder{w: bufio.NewWriter(w), feed: true, name: name}
+}
+
+func (e *icsEncoder) Begin() error {
+	e.line("BEGIN:VCALENDAR")
+	e.line("VERSION:2.0")
+	e.line("PRODID:" + icsProdID)
+	e.line("CALSCALE:GREGORIAN")
+	if e.feed {
+		e.line("METHOD:PUBLISH")
+		e.line("X-WR-CALNAME:" + escapeICSText(e.name))
+		e.line("REFRESH-INTERVAL;VALUE=DURATION:" + feedRefresh)
+		e.line("X-PUBLISHED-TTL:" + feedRefresh)
+	}
+	return e.w.Flush()
+}
+
+func (e *icsEncoder) stamp(t *model.Task) string {
+	if e.feed {
+		return t.UpdatedAt.UTC().Format(icsTimestamp)
+	}
+	return e.now.UTC().Format(icsTimestamp)
+}
+
+func (e *icsEncoder) Encode(t *model.Task) error {
+	if e.feed && t.DueAt != nil {
+		e.event(t)
+	}
+	e.line("BEGIN:VTODO")
+	e.line("UID:" + taskUID(t))
+	e.line("DTSTAMP:" + e.stamp(t))
+	e.line("CREATED:" + t.CreatedAt.UTC().Format(icsTimestamp))
+	e.line("LAST-MODIFIED:" + t.UpdatedAt.UTC().Format(icsTimestamp))
+	e.line("SUMMARY:" + escapeICSText(t.Title))
+	if t.Content != "" {
+		e.line("DESCRIPTION:" + escapeICSText(t.Content))
+	}
+	if t.DueAt != nil {
+		e.line("DUE:" + t.DueAt.UTC().Format(icsTimestamp))
+	}
+	if t.Priority > 0 {
+		e.line("PRIORITY:" + strconv.Itoa(t.Priority))
+	}
+	if t.CompletedAt != nil {
+		e.line("STATUS:COMPLETED")
+		e.line("COMPLETED:" + t.CompletedAt.UTC().Format(icsTimestamp))
+	} else {
+		e.line("STATUS:NEEDS-ACTION")
+	}
+	if t.Status != "" {
+		// 保留流程中的狀態名稱，讓支援的 client 可以顯示
+		e.line("X-GOTASKER-STATUS:" + escapeICSText(t.Status))
+	}
+	e.line("END:VTODO")
+	return e.w.Flush()
+}
+
+// event 把任務的截止時間寫成一個零長度的 VEVENT；UID 與 VTODO 不同，避免 client 視為同一個物件。
+func (e *icsEncoder) event(t *model.Task) {
+	e.line("BEGIN:VEVENT")
+	e.line("UID:due-" + taskUID(t))
+	e.line("DTSTAMP:" + e.stamp(t))
+	e.line("DTSTART:" + t.DueAt.UTC().Format(icsTimestamp))
+	e.line("DTEND:" + t.DueAt.UTC().Format(icsTimestamp))
+	e.line("SUMMARY:" + escapeICSText(t.Title))
+	if t.Content != "" {
+		e.line("DESCRIPTION:" + escapeICSText(t.Content))
+	}
+	e.line("TRANSP:TRANSPARENT")
+	e.line("END:VEVENT")
+}
+
+func taskUID(t *model.Task) string {
+	return "task-" + strconv.FormatUint(uint64(t.ID), 10) + "@gotasker-pro"
+}
+
+func (e *icsEncoder) End() error {
+	e.line("END:VCALENDAR")
+	return e.w.Flush()
+}
+
+// line 寫出一行內容，超過 75 octets 時依 RFC 5545 折行（CRLF 後接一個空白），且不切斷 UTF-8 字元。
+func (e *icsEncoder) line(s string) {
+	limit := icsMaxLine
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(s[cut]) {
+			cut--
+		}
+		e.w.WriteString(s[:cut])
+		e.w.WriteString("\r\n ")
+		s = s[cut:]
+		limit = icsMaxLine - 1 // 續行開頭的空白也算在 75 octets 內
+	}
+	e.w.WriteString(s)
+	e.w.WriteString("\r\n")
+}
+
+func isUTF8Start(b byte) bool { return b&0xC0 != 0x80 }
+
+var icsTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+func escapeICSText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
@@ -0,0 +1,43 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+// jsonEncoder 寫出 JSON array，每筆任務各自編碼，不需把整份陣列留在記憶體。
+type jsonEncoder struct {
+	w     io.Writer
+	first bool
+}
+
+func newJSONEncoder(w io.Writer) *jsonEncoder {
+	return &jsonEncoder{w: w, first: true}
+}
+
+func (e *jsonEncoder) Begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonEncoder) Encode(t *model.Task) error {
+	b, err := json.Marshal(NewRecord(t))
+	if err != nil {
+		return err
+	}
+	if !e.first {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.first = false
+	_, err = e.w.Write(b)
+	return err
+}
+
+func (e *jsonEncoder) End() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
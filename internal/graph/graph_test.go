@@ -0,0 +1,182 @@
+package graph_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/graph"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
+)
+
+type graphEnv struct {
+	users    *mock_service.MockUserService
+	tasks    *mock_service.MockTaskService
+	comments *mock_service.MockCommentService
+	projects *mock_service.MockProjectService
+	server   http.Handler
+}
+
+func newGraphEnv(t *testing.T) *graphEnv {
+	ctrl := gomock.NewController(t)
+	env := &graphEnv{
+		users:    mock_service.NewMockUserService(ctrl),
+		tasks:    mock_service.NewMockTaskService(ctrl),
+		comments: mock_service.NewMockCommentService(ctrl),
+		projects: mock_service.NewMockProjectService(ctrl),
+	}
+	env.server = graph.NewServer(graph.NewResolver(env.users, env.tasks, env.comments, env.projects))
+	return env
+}
+
+type gqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+// query 以使用者 1 的身分執行查詢。
+func (e *graphEnv) query(t *testing.T, q string) (int, gqlResponse) {
+	return e.queryAs(t, graph.WithUserID(context.Background(), 1), q)
+}
+
+func (e *graphEnv) queryAs(t *testing.T, ctx context.Context, q string) (int, gqlResponse) {
+	body, _ := json.Marshal(map[string]any{"query": q})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)).WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	e.server.ServeHTTP(w, req)
+
+	var res gqlResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res), w.Body.String())
+	return w.Code, res
+}
+
+func TestTasksQuery_BatchesRelations(t *testing.T) {
+	env := newGraphEnv(t)
+	project := uint(7)
+	tasks := []*model.Task{
+		{ID: 1, UserID: 1, Title: "a", ProjectID: &project},
+		{ID: 2, UserID: 1, Title: "b", ProjectID: &project},
+		{ID: 3, UserID: 1, Title: "c"},
+	}
+
+	env.tasks.EXPECT().ListTaskPage(gomock.Any(), uint(1), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uint, req service.TaskPageRequest) (*service.TaskPage, error) {
+			assert.Equal(t, 3, req.Limit)
+			assert.Equal(t, []string{"pending"}, req.Filter.Statuses)
+			return &service.TaskPage{Tasks: tasks, NextCursor: "next", Total: 10}, nil
+		})
+	// 每種關聯只查詢一次，而不是每個任務各查一次
+	env.tasks.EXPECT().ListTaskTags(gomock.Any(), uint(1), gomock.InAnyOrder([]uint{1, 2, 3})).
+		Return(map[uint][]*model.Tag{1: {{ID: 4, Name: "work"}}}, nil)
+	env.comments.EXPECT().ListCommentsByTasks(gomock.Any(), uint(1), gomock.InAnyOrder([]uint{1, 2, 3})).
+		Return(map[uint][]*model.Comment{2: {{ID: 9, TaskID: 2, UserID: 1, Body: "hi"}}}, nil)
+	env.projects.EXPECT().GetProjects(gomock.Any(), uint(1), []uint{7}).
+		Return([]*model.Project{{ID: 7, UserID: 1, Name: "home"}}, nil)
+	env.users.EXPECT().GetUsers(gomock.Any(), []uint{1}).
+		Return([]*model.User{{ID: 1, Username: "alice"}}, nil)
+
+	code, res := env.query(t, `{
+		tasks(first: 3, filter: {status: ["pending"]}) {
+			totalCount
+			nextCursor
+			nodes {
+				id
+				title
+				owner { username }
+				project { name }
+				tags { name }
+				comments { body author { username } }
+			}
+		}
+	}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"tasks":{"totalCount":10,"nextCursor":"next","nodes":[
+		{"id":"1","title":"a","owner":{"username":"alice"},"project":{"name":"home"},"tags":[{"name":"work"}],"comments":[]},
+		{"id":"2","title":"b","owner":{"username":"alice"},"project":{"name":"home"},"tags":[],"comments":[{"body":"hi","author":{"username":"alice"}}]},
+		{"id":"3","title":"c","owner":{"username":"alice"},"project":null,"tags":[],"comments":[]}
+	]}}`, string(res.Data))
+}
+
+func TestTasksQuery_BatchesSubtasks(t *testing.T) {
+	env := newGraphEnv(t)
+	parent := uint(1)
+	env.tasks.EXPECT().ListTaskPage(gomock.Any(), uint(1), gomock.Any()).
+		Return(&service.TaskPage{Tasks: []*model.Task{
+			{ID: 1, UserID: 1, Title: "a"},
+			{ID: 2, UserID: 1, Title: "b"},
+		}}, nil)
+	env.tasks.EXPECT().ListSubtasks(gomock.Any(), uint(1), gomock.InAnyOrder([]uint{1, 2})).
+		Return(map[uint][]*model.Task{1: {{ID: 5, UserID: 1, Title: "a.1", ParentID: &parent}}}, nil)
+
+	code, res := env.query(t, `{ tasks { nodes { id subtasks { id title parentId } } } }`)
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"tasks":{"nodes":[
+		{"id":"1","subtasks":[{"id":"5","title":"a.1","parentId":"1"}]},
+		{"id":"2","subtasks":[]}
+	]}}`, string(res.Data))
+}
+
+func TestTaskQuery_Errors(t *testing.T) {
+	env := newGraphEnv(t)
+
+	env.tasks.EXPECT().GetTask(gomock.Any(), uint(1)).Return(nil, nil)
+	_, res := env.query(t, `{ task(id: 1) { id } }`)
+	require.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"task":null}`, string(res.Data))
+
+	env.tasks.EXPECT().GetTask(gomock.Any(), uint(2)).Return(&model.Task{ID: 2, UserID: 2}, nil)
+	_, res = env.query(t, `{ task(id: 2) { id } }`)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "permission_denied", res.Errors[0].Extensions["code"])
+
+	// 未知錯誤不回傳內部訊息
+	env.tasks.EXPECT().GetTask(gomock.Any(), uint(3)).Return(nil, errors.New("db: connection refused"))
+	_, res = env.query(t, `{ task(id: 3) { id } }`)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "internal server error", res.Errors[0].Message)
+	assert.Equal(t, "internal_error", res.Errors[0].Extensions["code"])
+
+	_, res = env.query(t, `{ tasks(first: 0) { totalCount } }`)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "invalid_request", res.Errors[0].Extensions["code"])
+}
+
+func TestQueryLimits(t *testing.T) {
+	env := newGraphEnv(t)
+
+	// 超過限制時不會呼叫任何 service
+	_, res := env.query(t, `{ tasks(first: 200) { nodes { id title content status priority tags { name } comments { body } } } }`)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "COMPLEXITY_LIMIT_EXCEEDED", res.Errors[0].Extensions["code"])
+
+	// introspection 不受深度限制
+	code, res := env.query(t, `{ __schema { types { fields { type { ofType { ofType { ofType { name } } } } } } } }`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, res.Errors)
+
+	code, _ = env.query(t, `{ tasks { nodes { missing } } }`)
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
+
+func TestUnauthenticated(t *testing.T) {
+	env := newGraphEnv(t)
+	_, res := env.queryAs(t, context.Background(), `{ me { id } }`)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "unauthorized", res.Errors[0].Message)
+}
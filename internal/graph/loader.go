@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+// loaderWait 是收集同一批 key 的等待時間；gqlgen 會平行解析列表中每個元素的欄位。
+const loaderWait = 2 * time.Millisecond
+
+// loader 把同一個請求中的多次 Load 合併成一次 fetch（DataLoader 模式），
+// 已載入過的 key 直接重用結果。每個請求使用自己的 loader，不會跨請求快取。
+type loader[K comparable, V any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending *batch[K, V]
+	batches map[K]*batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	keys   []K
+	done   chan struct{}
+	values map[K]V
+	err    error
+}
+
+func newLoader[K comparable, V any](ctx context.Context, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{ctx: ctx, fetch: fetch, batches: map[K]*batch[K, V]{}}
+}
+
+// Load 回傳 key 對應的值；fetch 沒有回傳該 key 時為零值。
+func (l *loader[K, V]) Load(key K) (V, error) {
+	l.mu.Lock()
+	b, ok := l.batches[key]
+	if !ok {
+		if l.pending == nil {
+			l.pending = &batch[K, V]{done: make(chan struct{})}
+			time.AfterFunc(loaderWait, l.dispatch)
+		}
+		b = l.pending
+		b.keys = append(b.keys, key)
+		l.batches[key] = b
+	}
+	l.mu.Unlock()
+
+	<-b.done
+	return b.values[key], b.err
+}
+
+func (l *loader[K, V]) dispatch() {
+	l.mu.Lock()
+	b := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	b.values, b.err = l.fetch(l.ctx, b.keys)
+	close(b.done)
+}
+
+// loaders 是單一請求使用的所有 loader。
+type loaders struct {
+	users    *loader[uint, *model.User]
+	projects *loader[uint, *model.Project]
+	subtasks *loader[uint, []*model.Task]
+	tags     *loader[uint, []*model.Tag]
+	comments *loader[uint, []*model.Comment]
+}
+
+type loadersKey struct{}
+
+func (r *Resolver) newLoaders(ctx context.Context, userID uint) *loaders {
+	return &loaders{
+		users: newLoader(ctx, func(ctx context.Context, ids []uint) (map[uint]*model.User, error) {
+			users, err := r.users.GetUsers(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			res := make(map[uint]*model.User, len(users))
+			for _, u := range users {
+				res[u.ID] = u
+			}
+			return res, nil
+		}),
+		projects: newLoader(ctx, func(ctx context.Context, ids []uint) (map[uint]*model.Project, error) {
+			projects, err := r.projects.GetProjects(ctx, userID, ids)
+			if err != nil {
+				return nil, err
+			}
+			res := make(map[uint]*model.Project, len(projects))
+			for _, p := range projects {
+				res[p.ID] = p
+			}
+			return res, nil
+		}),
+		subtasks: newLoader(ctx, func(ctx context.Context, taskIDs []uint) (map[uint][]*model.Task, error) {
+			return r.tasks.ListSubtasks(ctx, userID, taskIDs)
+		}),
+		tags: newLoader(ctx, func(ctx context.Context, taskIDs []uint) (map[uint][]*model.Tag, error) {
+			return r.tasks.ListTaskTags(ctx, userID, taskIDs)
+		}),
+		comments: newLoader(ctx, func(ctx context.Context, taskIDs []uint) (map[uint][]*model.Comment, error) {
+			return r.comments.ListCommentsByTasks(ctx, userID, taskIDs)
+		}),
+	}
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// loadUser 載入使用者；使用者不存在時視為內部錯誤（任務與留言一定有擁有者）。
+func loadUser(ctx context.Context, id uint) (*model.User, error) {
+	user, err := loadersFrom(ctx).users.Load(id)
+	if err == nil && user == nil {
+		err = fmt.Errorf("user %d not found", id)
+	}
+	return user, err
+}
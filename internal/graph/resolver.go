@@ -0,0 +1,33 @@
+// Package graph 以 GraphQL 提供任務、專案與使用者的查詢，與 REST API 共用同一組 service。
+// schema 定義在 schema.graphqls，generated.go 由 gqlgen 產生（make graphql）。
+package graph
+
+import (
+	"context"
+
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+// Resolver 是所有 resolver 的根。
+type Resolver struct {
+	users    service.UserService
+	tasks    service.TaskService
+	comments service.CommentService
+	projects service.ProjectService
+}
+
+func NewResolver(users service.UserService, tasks service.TaskService, comments service.CommentService, projects service.ProjectService) *Resolver {
+	return &Resolver{users: users, tasks: tasks, comments: comments, projects: projects}
+}
+
+type userIDKey struct{}
+
+// WithUserID 帶入已通過 JWT 驗證的使用者；沒有使用者的請求一律回 unauthorized。
+func WithUserID(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+func userIDFrom(ctx context.Context) (uint, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(uint)
+	return userID, ok
+}
@@ -0,0 +1,134 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+// Author is the resolver for the author field.
+func (r *commentResolver) Author(ctx context.Context, obj *model.Comment) (*model.User, error) {
+	return loadUser(ctx, obj.UserID)
+}
+
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
+	userID, _ := userIDFrom(ctx)
+	return loadUser(ctx, userID)
+}
+
+// Task is the resolver for the task field.
+func (r *queryResolver) Task(ctx context.Context, id uint) (*model.Task, error) {
+	userID, _ := userIDFrom(ctx)
+	task, err := r.tasks.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, nil
+	}
+	if task.UserID != userID {
+		return nil, service.ErrPermissionDenied
+	}
+	return task, nil
+}
+
+// Tasks is the resolver for the tasks field.
+func (r *queryResolver) Tasks(ctx context.Context, first *int, after *string, filter *repository.TaskFilter, sort *string) (*service.TaskPage, error) {
+	userID, _ := userIDFrom(ctx)
+	req := service.TaskPageRequest{Limit: service.DefaultPageLimit}
+	if first != nil {
+		if *first < 1 || *first > service.MaxPageLimit {
+			return nil, problem.BadRequest("invalid first")
+		}
+		req.Limit = *first
+	}
+	if after != nil {
+		req.Cursor = *after
+	}
+	if filter != nil {
+		req.Filter = *filter
+	}
+	if sort != nil {
+		req.Sort = *sort
+	}
+	return r.tasks.ListTaskPage(ctx, userID, req)
+}
+
+// Projects is the resolver for the projects field.
+func (r *queryResolver) Projects(ctx context.Context) ([]*model.Project, error) {
+	userID, _ := userIDFrom(ctx)
+	return r.projects.ListProjects(ctx, userID)
+}
+
+// Owner is the resolver for the owner field.
+func (r *taskResolver) Owner(ctx context.Context, obj *model.Task) (*model.User, error) {
+	return loadUser(ctx, obj.UserID)
+}
+
+// Project is the resolver for the project field.
+func (r *taskResolver) Project(ctx context.Context, obj *model.Task) (*model.Project, error) {
+	if obj.ProjectID == nil {
+		return nil, nil
+	}
+	return loadersFrom(ctx).projects.Load(*obj.ProjectID)
+}
+
+// Subtasks is the resolver for the subtasks field.
+func (r *taskResolver) Subtasks(ctx context.Context, obj *model.Task) ([]*model.Task, error) {
+	subtasks, err := loadersFrom(ctx).subtasks.Load(obj.ID)
+	if subtasks == nil && err == nil {
+		subtasks = []*model.Task{}
+	}
+	return subtasks, err
+}
+
+// Tags is the resolver for the tags field.
+func (r *taskResolver) Tags(ctx context.Context, obj *model.Task) ([]*model.Tag, error) {
+	tags, err := loadersFrom(ctx).tags.Load(obj.ID)
+	if tags == nil && err == nil {
+		tags = []*model.Tag{}
+	}
+	return tags, err
+}
+
+// Comments is the resolver for the comments field.
+func (r *taskResolver) Comments(ctx context.Context, obj *model.Task) ([]*model.Comment, error) {
+	comments, err := loadersFrom(ctx).comments.Load(obj.ID)
+	if comments == nil && err == nil {
+		comments = []*model.Comment{}
+	}
+	return comments, err
+}
+
+// NextCursor is the resolver for the nextCursor field.
+func (r *taskConnectionResolver) NextCursor(ctx context.Context, obj *service.TaskPage) (*string, error) {
+	if obj.NextCursor == "" {
+		return nil, nil
+	}
+	return &obj.NextCursor, nil
+}
+
+// Comment returns CommentResolver implementation.
+func (r *Resolver) Comment() CommentResolver { return &commentResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Task returns TaskResolver implementation.
+func (r *Resolver) Task() TaskResolver { return &taskResolver{r} }
+
+// TaskConnection returns TaskConnectionResolver implementation.
+func (r *Resolver) TaskConnection() TaskConnectionResolver { return &taskConnectionResolver{r} }
+
+type commentResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type taskResolver struct{ *Resolver }
+type taskConnectionResolver struct{ *Resolver }
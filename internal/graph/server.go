@@ -0,0 +1,126 @@
+package graph
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+const (
+	// MaxDepth 是查詢的最大巢狀層數（不含 introspection 欄位）。
+	MaxDepth = 6
+	// MaxComplexity 是查詢的最大複雜度：每個欄位計 1，tasks 內的欄位乘上 first。
+	MaxComplexity = 1000
+
+	errDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+)
+
+// NewServer 建立 GraphQL 的 http.Handler；請求的 context 必須先以 WithUserID 帶入目前使用者。
+func NewServer(r *Resolver) *handler.Server {
+	cfg := Config{Resolvers: r}
+	cfg.Complexity.Query.Tasks = func(childComplexity int, first *int, _ *string, _ *repository.TaskFilter, _ *string) int {
+		n := 1
+		if first != nil && *first > 1 {
+			n = *first
+		}
+		return 1 + n*childComplexity
+	}
+
+	srv := handler.New(NewExecutableSchema(cfg))
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.FixedComplexityLimit(MaxComplexity))
+	srv.Use(depthLimit{max: MaxDepth})
+	srv.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		userID, ok := userIDFrom(ctx)
+		if !ok {
+			return graphql.OneShot(graphql.ErrorResponse(ctx, "unauthorized"))
+		}
+		return next(context.WithValue(ctx, loadersKey{}, r.newLoaders(ctx, userID)))
+	})
+	srv.SetErrorPresenter(presentError)
+	return srv
+}
+
+// presentError 讓 resolver 回傳的 service 錯誤與 REST 使用相同的 code（放在 extensions.code）；
+// 未知錯誤只記錄在 log，回應中不包含內部訊息。
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	// 語法、驗證與查詢限制的錯誤由 gqlgen 產生，原樣回傳
+	if gqlErr.Err == nil {
+		return gqlErr
+	}
+
+	p := middleware.ToProblem(gqlErr.Err)
+	if p.Status >= http.StatusInternalServerError {
+		log.Printf("graphql %s: %v", gqlErr.Path, gqlErr.Err)
+		p = problem.New(p.Status, p.Code, "internal server error")
+	}
+	gqlErr.Message = p.Detail
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]any{}
+	}
+	gqlErr.Extensions["code"] = p.Code
+	return gqlErr
+}
+
+// depthLimit 拒絕巢狀層數超過 max 的查詢，避免 introspection 以外的深層查詢放大成大量的資料庫存取。
+type depthLimit struct {
+	max int
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = depthLimit{}
+
+func (depthLimit) ExtensionName() string { return "DepthLimit" }
+
+func (depthLimit) Validate(graphql.ExecutableSchema) error { return nil }
+
+func (d depthLimit) MutateOperationContext(_ context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if opCtx.Operation == nil {
+		return nil
+	}
+	if depth := selectionDepth(opCtx.Operation.SelectionSet); depth > d.max {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.max)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+	return nil
+}
+
+func selectionDepth(set ast.SelectionSet) int {
+	depth := 0
+	for _, sel := range set {
+		var d int
+		switch s := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			d = 1 + selectionDepth(s.SelectionSet)
+		case *ast.InlineFragment:
+			d = selectionDepth(s.SelectionSet)
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				d = selectionDepth(s.Definition.SelectionSet)
+			}
+		}
+		depth = max(depth, d)
+	}
+	return depth
+}
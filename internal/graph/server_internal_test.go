@@ -0,0 +1,40 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+func TestDepthLimit(t *testing.T) {
+	schema := NewExecutableSchema(Config{}).Schema()
+	limit := depthLimit{max: 3}
+
+	cases := []struct {
+		query string
+		ok    bool
+	}{
+		{`{ tasks { nodes { id } } }`, true},
+		{`{ tasks { nodes { comments { body } } } }`, false},
+		{`{ tasks { nodes { ...f } } } fragment f on Task { tags { name } }`, false},
+		{`{ tasks { ... on TaskConnection { nodes { id } } } }`, true},
+	}
+	for _, tc := range cases {
+		doc, errs := gqlparser.LoadQuery(schema, tc.query)
+		require.Empty(t, errs, tc.query)
+		opCtx := &graphql.OperationContext{Doc: doc, Operation: doc.Operations[0]}
+
+		err := limit.MutateOperationContext(context.Background(), opCtx)
+		if tc.ok {
+			assert.Nil(t, err, tc.query)
+		} else {
+			require.NotNil(t, err, tc.query)
+			assert.Equal(t, errDepthLimit, err.Extensions["code"])
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	pb "github.com/SoliMark/gotasker-pro/internal/pb/gotasker/v1"
+	"github.com/SoliMark/gotasker-pro/internal/util"
+)
+
+// publicMethods 不需要 token；server reflection 以前綴判斷。
+var publicMethods = map[string]bool{
+	pb.UserService_Register_FullMethodName: true,
+	pb.UserService_Login_FullMethodName:    true,
+}
+
+const reflectionPrefix = "/grpc.reflection."
+
+type userIDKey struct{}
+
+// userIDFrom 回傳 auth interceptor 驗證過的使用者 id。
+func userIDFrom(ctx context.Context) (uint, bool) {
+	id, ok := ctx.Value(userIDKey{}).(uint)
+	return id, ok
+}
+
+// authenticator 驗證 metadata 的 authorization: Bearer <JWT>，與 REST 的 JWT middleware 使用同一把 key。
+type authenticator struct {
+	jwtMaker *util.JWTMaker
+}
+
+func (a *authenticator) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authedStream{ServerStream: ss, ctx: ctx})
+}
+
+func (a *authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if publicMethods[method] || strings.HasPrefix(method, reflectionPrefix) {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(strings.ToLower(constant.HeaderAuthorization))
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata is missing")
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+	}
+	claims, err := a.jwtMaker.VerifyToken(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	return context.WithValue(ctx, userIDKey{}, claims.UserID), nil
+}
+
+// authedStream 讓 stream handler 拿到帶有使用者 id 的 context。
+type authedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authedStream) Context() context.Context { return s.ctx }
@@ -0,0 +1,81 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+// knownErrors 把 service 的 sentinel error 對應到 gRPC status code；
+// 未列出的錯誤一律視為 Internal，訊息不回傳給 client。
+var knownErrors = []struct {
+	err  error
+	code codes.Code
+}{
+	{service.ErrInvalidCredential, codes.Unauthenticated},
+	{service.ErrEmailTaken, codes.AlreadyExists},
+	{service.ErrPermissionDenied, codes.PermissionDenied},
+	{service.ErrTaskNotFound, codes.NotFound},
+	{service.ErrInvalidStatus, codes.InvalidArgument},
+	{service.ErrInvalidPriority, codes.InvalidArgument},
+	{service.ErrInvalidSort, codes.InvalidArgument},
+	{service.ErrInvalidCursor, codes.InvalidArgument},
+	{service.ErrInvalidTransition, codes.FailedPrecondition},
+}
+
+// knownFieldErrors 是單一欄位驗證失敗的 sentinel error，以 InvalidArgument 回應並在 details 帶上欄位。
+var knownFieldErrors = []struct {
+	err   error
+	field string
+}{
+	{service.ErrTitleRequired, "title"},
+}
+
+func errorUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(info.FullMethod, err)
+	}
+	return resp, nil
+}
+
+func errorStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := handler(srv, ss); err != nil {
+		return toStatus(info.FullMethod, err)
+	}
+	return nil
+}
+
+func toStatus(method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	for _, known := range knownFieldErrors {
+		if errors.Is(err, known.err) {
+			st := status.New(codes.InvalidArgument, err.Error())
+			if detailed, derr := st.WithDetails(&errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: known.field, Description: err.Error()}},
+			}); derr == nil {
+				st = detailed
+			}
+			return st.Err()
+		}
+	}
+	for _, known := range knownErrors {
+		if errors.Is(err, known.err) {
+			return status.Error(known.code, err.Error())
+		}
+	}
+	log.Printf("grpc %s: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,27 @@
+// Package grpcapi 以 gRPC 提供使用者與任務的操作，與 REST API 共用同一組 service。
+// 方法直接回傳 service 的錯誤，由 interceptor 轉成 gRPC status。
+package grpcapi
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	pb "github.com/SoliMark/gotasker-pro/internal/pb/gotasker/v1"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/util"
+)
+
+// NewServer 建立註冊好 UserService、TaskService 與 server reflection 的 gRPC server。
+func NewServer(users service.UserService, tasks service.TaskService, jwtMaker *util.JWTMaker, opts ...grpc.ServerOption) *grpc.Server {
+	auth := &authenticator{jwtMaker: jwtMaker}
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(auth.unary, errorUnaryInterceptor),
+		grpc.ChainStreamInterceptor(auth.stream, errorStreamInterceptor),
+	}, opts...)
+
+	s := grpc.NewServer(opts...)
+	pb.RegisterUserServiceServer(s, &userServer{users: users})
+	pb.RegisterTaskServiceServer(s, &taskServer{tasks: tasks})
+	reflection.Register(s)
+	return s
+}
@@ -0,0 +1,234 @@
+package grpcapi_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/SoliMark/gotasker-pro/internal/grpcapi"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	pb "github.com/SoliMark/gotasker-pro/internal/pb/gotasker/v1"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
+	"github.com/SoliMark/gotasker-pro/internal/util"
+)
+
+type testEnv struct {
+	conn  *grpc.ClientConn
+	users *mock_service.MockUserService
+	tasks *mock_service.MockTaskService
+	jwt   *util.JWTMaker
+}
+
+// newTestEnv 以 bufconn 啟動 gRPC server，不需要網路。
+func newTestEnv(t *testing.T) *testEnv {
+	ctrl := gomock.NewController(t)
+	env := &testEnv{
+		users: mock_service.NewMockUserService(ctrl),
+		tasks: mock_service.NewMockTaskService(ctrl),
+		jwt:   util.NewJWTMaker("test_secret_key"),
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpcapi.NewServer(env.users, env.tasks, env.jwt)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	env.conn = conn
+	return env
+}
+
+func (e *testEnv) authed(t *testing.T, userID uint) context.Context {
+	token, err := e.jwt.GenerateToken(userID, time.Minute)
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestUserService_Login(t *testing.T) {
+	env := newTestEnv(t)
+	client := pb.NewUserServiceClient(env.conn)
+	ctx := context.Background()
+
+	env.users.EXPECT().AuthenticateUser(gomock.Any(), "a@example.com", "password123").Return("jwt.token", nil)
+	res, err := client.Login(ctx, &pb.LoginRequest{Email: "a@example.com", Password: "password123"})
+	require.NoError(t, err)
+	assert.Equal(t, "jwt.token", res.GetToken())
+
+	// 帳號不存在與密碼錯誤回應相同
+	env.users.EXPECT().AuthenticateUser(gomock.Any(), "nobody@example.com", "password123").Return("", service.ErrUserNotFound)
+	_, err = client.Login(ctx, &pb.LoginRequest{Email: "nobody@example.com", Password: "password123"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.Login(ctx, &pb.LoginRequest{Email: "not-an-email"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUserService_Register(t *testing.T) {
+	env := newTestEnv(t)
+	client := pb.NewUserServiceClient(env.conn)
+	req := &pb.RegisterRequest{Username: "alice", Email: "a@example.com", Password: "password123"}
+
+	env.users.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(service.ErrEmailTaken)
+	_, err := client.Register(context.Background(), req)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = client.Register(context.Background(), &pb.RegisterRequest{Username: "alice", Email: "a@example.com", Password: "short"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestTaskService_Auth(t *testing.T) {
+	env := newTestEnv(t)
+	client := pb.NewTaskServiceClient(env.conn)
+
+	_, err := client.GetTask(context.Background(), &pb.GetTaskRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid")
+	_, err = client.GetTask(ctx, &pb.GetTaskRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestTaskService_GetTask(t *testing.T) {
+	env := newTestEnv(t)
+	client := pb.NewTaskServiceClient(env.conn)
+	ctx := env.authed(t, 1)
+
+	due := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+	env.tasks.EXPECT().GetTask(gomock.Any(), uint(1)).
+		Return(&model.Task{ID: 1, UserID: 1, Title: "write docs", Status: "pending", DueAt: &due}, nil)
+	task, err := client.GetTask(ctx, &pb.GetTaskRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "write docs", task.GetTitle())
+	assert.True(t, due.Equal(task.GetDueAt().AsTime()))
+	assert.Nil(t, task.GetCompletedAt())
+
+	env.tasks.EXPECT().GetTask(gomock.Any(), uint(2)).Return(nil, nil)
+	_, err = client.GetTask(ctx, &pb.GetTaskRequest{Id: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	env.tasks.EXPECT().GetTask(gomock.Any(), uint(3)).Return(&model.Task{ID: 3, UserID: 2}, nil)
+	_, err = client.GetTask(ctx, &pb.GetTaskRequest{Id: 3})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// 未預期的錯誤不會把內部訊息傳給 client
+	env.tasks.EXPECT().GetTask(gomock.Any(), uint(4)).Return(nil, errors.New("db: connection refused"))
+	_, err = client.GetTask(ctx, &pb.GetTaskRequest{Id: 4})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.NotContains(t, status.Convert(err).Message(), "connection refused")
+}
+
+func TestTaskService_ListAndMutate(t *testing.T) {
+	env := newTestEnv(t)
+	client := pb.NewTaskServiceClient(env.conn)
+	ctx := env.authed(t, 1)
+
+	env.tasks.EXPECT().ListTaskPage(gomock.Any(), uint(1), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uint, req service.TaskPageRequest) (*service.TaskPage, error) {
+			assert.Equal(t, service.DefaultPageLimit, req.Limit)
+			assert.Equal(t, []string{"pending"}, req.Filter.Statuses)
+			return &service.TaskPage{Tasks: []*model.Task{{ID: 1, UserID: 1}}, NextCursor: "next", Total: 3}, nil
+		})
+	list, err := client.ListTasks(ctx, &pb.ListTasksRequest{Statuses: []string{"pending"}})
+	require.NoError(t, err)
+	assert.Len(t, list.GetTasks(), 1)
+	assert.Equal(t, "next", list.GetNextCursor())
+	assert.EqualValues(t, 3, list.GetTotal())
+
+	_, err = client.ListTasks(ctx, &pb.ListTasksRequest{Limit: service.MaxPageLimit + 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	env.tasks.EXPECT().ListTaskPage(gomock.Any(), uint(1), gomock.Any()).Return(nil, service.ErrInvalidCursor)
+	_, err = client.ListTasks(ctx, &pb.ListTasksRequest{Cursor: "bogus"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	env.tasks.EXPECT().CreateTask(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, task *model.Task) error {
+			assert.Equal(t, uint(1), task.UserID)
+			task.ID = 9
+			return nil
+		})
+	created, err := client.CreateTask(ctx, &pb.CreateTaskRequest{Title: "new"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 9, created.GetId())
+
+	title := "renamed"
+	env.tasks.EXPECT().GetTask(gomock.Any(), uint(9)).Return(&model.Task{ID: 9, UserID: 1, Title: "new", Content: "keep"}, nil)
+	env.tasks.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil)
+	updated, err := client.UpdateTask(ctx, &pb.UpdateTaskRequest{Id: 9, Title: &title})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", updated.GetTitle())
+	assert.Equal(t, "keep", updated.GetContent())
+
+	due := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+	env.tasks.EXPECT().GetTask(gomock.Any(), uint(9)).Return(&model.Task{ID: 9, UserID: 1, Title: "new", DueAt: &due}, nil)
+	env.tasks.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, task *model.Task) error {
+			assert.Nil(t, task.DueAt)
+			return nil
+		})
+	updated, err = client.UpdateTask(ctx, &pb.UpdateTaskRequest{Id: 9, ClearDueAt: true})
+	require.NoError(t, err)
+	assert.Nil(t, updated.GetDueAt())
+
+	_, err = client.UpdateTask(ctx, &pb.UpdateTaskRequest{Id: 9, ClearDueAt: true, DueAt: timestamppb.New(due)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// 空白標題與 REST 一樣回報 title 欄位
+	blank := "  "
+	env.tasks.EXPECT().GetTask(gomock.Any(), uint(9)).Return(&model.Task{ID: 9, UserID: 1, Title: "new"}, nil)
+	env.tasks.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(service.ErrTitleRequired)
+	_, err = client.UpdateTask(ctx, &pb.UpdateTaskRequest{Id: 9, Title: &blank})
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	violations := st.Details()[0].(*errdetails.BadRequest).GetFieldViolations()
+	require.Len(t, violations, 1)
+	assert.Equal(t, "title", violations[0].GetField())
+
+	_, err = client.CreateTask(ctx, &pb.CreateTaskRequest{Title: " "})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	env.tasks.EXPECT().DeleteTask(gomock.Any(), uint(1), uint(9)).Return(service.ErrTaskNotFound)
+	_, err = client.DeleteTask(ctx, &pb.DeleteTaskRequest{Id: 9})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestReflection(t *testing.T) {
+	env := newTestEnv(t)
+	stream, err := rpb.NewServerReflectionClient(env.conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	}))
+	res, err := stream.Recv()
+	require.NoError(t, err)
+
+	var names []string
+	for _, s := range res.GetListServicesResponse().GetService() {
+		names = append(names, s.GetName())
+	}
+	assert.Contains(t, names, "gotasker.v1.UserService")
+	assert.Contains(t, names, "gotasker.v1.TaskService")
+}
@@ -0,0 +1,193 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	pb "github.com/SoliMark/gotasker-pro/internal/pb/gotasker/v1"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+type taskServer struct {
+	pb.UnimplementedTaskServiceServer
+	tasks service.TaskService
+}
+
+func (s *taskServer) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.Task, error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.GetTitle()) == "" {
+		return nil, service.ErrTitleRequired
+	}
+
+	task := &model.Task{
+		UserID:   userID,
+		Title:    req.GetTitle(),
+		Content:  req.GetContent(),
+		Priority: int(req.GetPriority()),
+		DueAt:    fromTimestamp(req.GetDueAt()),
+	}
+	if err := s.tasks.CreateTask(ctx, task); err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+func (s *taskServer) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.Task, error) {
+	task, err := s.ownedTask(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+func (s *taskServer) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = service.DefaultPageLimit
+	}
+	if limit < 1 || limit > service.MaxPageLimit {
+		return nil, status.Error(codes.InvalidArgument, "invalid limit")
+	}
+
+	page, err := s.tasks.ListTaskPage(ctx, userID, service.TaskPageRequest{
+		Filter: service.TaskFilter{
+			Statuses:      req.GetStatuses(),
+			TitlePrefix:   req.GetTitlePrefix(),
+			CreatedAfter:  fromTimestamp(req.GetCreatedAfter()),
+			CreatedBefore: fromTimestamp(req.GetCreatedBefore()),
+			UpdatedAfter:  fromTimestamp(req.GetUpdatedAfter()),
+			UpdatedBefore: fromTimestamp(req.GetUpdatedBefore()),
+		},
+		Sort:   req.GetSort(),
+		Limit:  limit,
+		Cursor: req.GetCursor(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListTasksResponse{Tasks: make([]*pb.Task, 0, len(page.Tasks)), NextCursor: page.NextCursor, Total: page.Total}
+	for _, t := range page.Tasks {
+		res.Tasks = append(res.Tasks, toTask(t))
+	}
+	return res, nil
+}
+
+func (s *taskServer) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.Task, error) {
+	if req.GetClearDueAt() && req.DueAt != nil {
+		return nil, status.Error(codes.InvalidArgument, "due_at and clear_due_at are mutually exclusive")
+	}
+	task, err := s.ownedTask(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if req.Title != nil {
+		task.Title = req.GetTitle()
+	}
+	if req.Content != nil {
+		task.Content = req.GetContent()
+	}
+	if req.Status != nil {
+		task.Status = req.GetStatus()
+	}
+	if req.Priority != nil {
+		task.Priority = int(req.GetPriority())
+	}
+	if req.GetClearDueAt() {
+		task.DueAt = nil
+	} else if req.DueAt != nil {
+		task.DueAt = fromTimestamp(req.GetDueAt())
+	}
+
+	if err := s.tasks.UpdateTask(ctx, task); err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+func (s *taskServer) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*emptypb.Empty, error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tasks.DeleteTask(ctx, userID, uint(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ownedTask 取得任務並確認屬於目前的使用者，規則與 REST 的 GET /v1/tasks/:id 相同。
+func (s *taskServer) ownedTask(ctx context.Context, id uint64) (*model.Task, error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.tasks.GetTask(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, service.ErrTaskNotFound
+	}
+	if task.UserID != userID {
+		return nil, service.ErrPermissionDenied
+	}
+	return task, nil
+}
+
+func requireUser(ctx context.Context) (uint, error) {
+	userID, ok := userIDFrom(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	return userID, nil
+}
+
+func toTask(t *model.Task) *pb.Task {
+	res := &pb.Task{
+		Id:          uint64(t.ID),
+		Title:       t.Title,
+		Content:     t.Content,
+		Status:      t.Status,
+		Position:    t.Position,
+		Priority:    int32(t.Priority),
+		DueAt:       toTimestamp(t.DueAt),
+		CompletedAt: toTimestamp(t.CompletedAt),
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+		UpdatedAt:   timestamppb.New(t.UpdatedAt),
+	}
+	if t.ProjectID != nil {
+		id := uint64(*t.ProjectID)
+		res.ProjectId = &id
+	}
+	return res
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
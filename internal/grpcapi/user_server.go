@@ -0,0 +1,63 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	pb "github.com/SoliMark/gotasker-pro/internal/pb/gotasker/v1"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+const minPasswordLength = 8
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	users service.UserService
+}
+
+// Register 與 REST 的 POST /v1/register 相同：建立帳號後直接回傳 token。
+func (s *userServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.AuthResponse, error) {
+	switch {
+	case req.GetUsername() == "":
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	case !validEmail(req.GetEmail()):
+		return nil, status.Error(codes.InvalidArgument, "email must be a valid email address")
+	case len(req.GetPassword()) < minPasswordLength:
+		return nil, status.Error(codes.InvalidArgument, "password must be at least 8 characters")
+	}
+
+	user := &model.User{Username: req.GetUsername(), Email: req.GetEmail(), PasswordHash: req.GetPassword()}
+	if err := s.users.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	token, err := s.users.AuthenticateUser(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AuthResponse{Token: token}, nil
+}
+
+func (s *userServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.AuthResponse, error) {
+	if !validEmail(req.GetEmail()) || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email and password are required")
+	}
+	token, err := s.users.AuthenticateUser(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		// 帳號不存在與密碼錯誤回應相同，避免洩漏哪些 email 已註冊
+		if errors.Is(err, service.ErrUserNotFound) {
+			err = service.ErrInvalidCredential
+		}
+		return nil, err
+	}
+	return &pb.AuthResponse{Token: token}, nil
+}
+
+func validEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+)
+
+const (
+	MaxBatchRequests    = 20
+	DefaultBatchTimeout = 10 * time.Second
+	// MaxBatchBytes 是整個批次 body 的上限，在解析 JSON 之前套用。
+	MaxBatchBytes = 1 << 20
+)
+
+// BatchHandler 把多個子請求交給同一個 gin engine 執行，子請求沿用呼叫者的 Authorization。
+type BatchHandler struct {
+	engine      http.Handler
+	maxRequests int
+	maxBytes    int64
+	timeout     time.Duration
+}
+
+// BatchHandlerOption 調整批次的上限。
+type BatchHandlerOption func(*BatchHandler)
+
+// WithBatchTimeout 設定每個子請求的逾時。
+func WithBatchTimeout(d time.Duration) BatchHandlerOption {
+	return func(h *BatchHandler) { h.timeout = d }
+}
+
+// WithMaxBatchBytes 設定批次 body 的大小上限。
+func WithMaxBatchBytes(n int64) BatchHandlerOption {
+	return func(h *BatchHandler) { h.maxBytes = n }
+}
+
+// WithMaxBatchRequests 設定一次批次最多幾個子請求。
+func WithMaxBatchRequests(n int) BatchHandlerOption {
+	return func(h *BatchHandler) { h.maxRequests = n }
+}
+
+func NewBatchHandler(engine http.Handler, opts ...BatchHandlerOption) *BatchHandler {
+	h := &BatchHandler{engine: engine, maxRequests: MaxBatchRequests, maxBytes: MaxBatchBytes, timeout: DefaultBatchTimeout}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+type BatchRequest struct {
+	Requests []BatchSubRequest `json:"requests" binding:"required,min=1,dive"`
+}
+
+// BatchSubRequest 的 path 是完整路徑（例如 /v1/tasks/1?fields=id）；
+// depends_on 列出必須先成功（2xx/3xx）的子請求 id，未列出依賴的子請求會同時執行。
+type BatchSubRequest struct {
+	ID        string            `json:"id"`
+	Method    string            `json:"method" binding:"required"`
+	Path      string            `json:"path" binding:"required"`
+	Headers   map[string]string `json:"headers"`
+	Body      json.RawMessage   `json:"body"`
+	DependsOn []string          `json:"depends_on"`
+}
+
+// BatchSubResponse 的 body 在回應是 JSON 時直接嵌入，否則為字串。
+type BatchSubResponse struct {
+	ID      string            `json:"id,omitempty"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+type BatchResponse struct {
+	Responses []BatchSubResponse `json:"responses"`
+}
+
+// batchSubRequestKey 標記子請求的 context，Batch 據此拒絕巢狀批次；
+// 不比對路徑字串，因為編碼過的路徑（例如 /v1/%62atch）也會路由到 Batch。
+type batchSubRequestKey struct{}
+
+// Batch 依序回傳每個子請求的結果；子請求失敗不影響批次本身的 200。
+func (h *BatchHandler) Batch(c *gin.Context) {
+	if c.Request.Context().Value(batchSubRequestKey{}) != nil {
+		_ = c.Error(problem.BadRequest("batch requests cannot be nested"))
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes)
+	var req BatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			_ = c.Error(problem.New(http.StatusRequestEntityTooLarge, problem.CodePayloadTooLarge, "batch body too large"))
+			return
+		}
+		_ = c.Error(problem.FromBinding(err))
+		return
+	}
+	if len(req.Requests) > h.maxRequests {
+		_ = c.Error(problem.New(http.StatusBadRequest, problem.CodeInvalidBatch,
+			fmt.Sprintf("a batch can contain at most %d requests", h.maxRequests)))
+		return
+	}
+	deps, err := batchDependencies(req.Requests)
+	if err != nil {
+		_ = c.Error(problem.New(http.StatusBadRequest, problem.CodeInvalidBatch, err.Error()))
+		return
+	}
+
+	results := make([]BatchSubResponse, len(req.Requests))
+	done := make([]chan struct{}, len(req.Requests))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for i, sub := range req.Requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[i])
+			for _, d := range deps[i] {
+				<-done[d]
+				if results[d].Status >= http.StatusBadRequest {
+					results[i] = problemResponse(sub, problem.New(http.StatusFailedDependency, problem.CodeDependencyFailed,
+						fmt.Sprintf("request %q failed", req.Requests[d].ID)))
+					return
+				}
+			}
+			results[i] = h.run(c, sub)
+		}()
+	}
+	wg.Wait()
+
+	c.JSON(http.StatusOK, BatchResponse{Responses: results})
+}
+
+// batchDependencies 把 depends_on 轉成索引，並檢查 id 重複、未知的 id 與循環依賴。
+func batchDependencies(reqs []BatchSubRequest) ([][]int, error) {
+	index := make(map[string]int, len(reqs))
+	for i, r := range reqs {
+		if r.ID == "" {
+			continue
+		}
+		if _, dup := index[r.ID]; dup {
+			return nil, fmt.Errorf("duplicate request id %q", r.ID)
+		}
+		index[r.ID] = i
+	}
+
+	deps := make([][]int, len(reqs))
+	for i, r := range reqs {
+		for _, id := range r.DependsOn {
+			d, ok := index[id]
+			if !ok {
+				return nil, fmt.Errorf("requests[%d] depends on unknown id %q", i, id)
+			}
+			deps[i] = append(deps[i], d)
+		}
+	}
+
+	// 0 未拜訪、1 拜訪中、2 完成；遇到拜訪中的節點表示有循環
+	state := make([]int, len(reqs))
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		switch state[i] {
+		case 1:
+			return false
+		case 2:
+			return true
+		}
+		state[i] = 1
+		for _, d := range deps[i] {
+			if !visit(d) {
+				return false
+			}
+		}
+		state[i] = 2
+		return true
+	}
+	for i := range reqs {
+		if !visit(i) {
+			return nil, fmt.Errorf("requests have a dependency cycle involving %q", reqs[i].ID)
+		}
+	}
+	return deps, nil
+}
+
+// run 以呼叫者的身分執行一個子請求；逾時時回傳 504，handler 仍在背景結束並寫入自己的 recorder。
+func (h *BatchHandler) run(c *gin.Context, sub BatchSubRequest) BatchSubResponse {
+	if !strings.HasPrefix(sub.Path, "/") {
+		return problemResponse(sub, problem.BadRequest("path must start with /"))
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	ctx = context.WithValue(ctx, batchSubRequestKey{}, true)
+	defer cancel()
+
+	r, err := http.NewRequestWithContext(ctx, strings.ToUpper(sub.Method), sub.Path, bytes.NewReader(sub.Body))
+	if err != nil {
+		return problemResponse(sub, problem.BadRequest("invalid request: "+err.Error()))
+	}
+	for k, v := range sub.Headers {
+		r.Header.Set(k, v)
+	}
+	if len(sub.Body) > 0 && r.Header.Get(constant.HeaderContentType) == "" {
+		r.Header.Set(constant.HeaderContentType, "application/json")
+	}
+	r.Header.Set(constant.HeaderAuthorization, c.GetHeader(constant.HeaderAuthorization))
+	r.RemoteAddr = c.Request.RemoteAddr
+
+	rec := newBatchRecorder()
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		h.engine.ServeHTTP(rec, r)
+	}()
+
+	select {
+	case <-finished:
+		return rec.response(sub.ID)
+	case <-ctx.Done():
+		return problemResponse(sub, problem.New(http.StatusGatewayTimeout, problem.CodeTimeout,
+			fmt.Sprintf("request did not finish within %s", h.timeout)))
+	}
+}
+
+// problemResponse 是子請求沒有交給 engine 執行時的錯誤回應，instance 為子請求的路徑。
+func problemResponse(sub BatchSubRequest, p *problem.Problem) BatchSubResponse {
+	if p.Instance == "" {
+		p.Instance, _, _ = strings.Cut(sub.Path, "?")
+	}
+	body, _ := json.Marshal(p)
+	return BatchSubResponse{
+		ID:      sub.ID,
+		Status:  p.Status,
+		Headers: map[string]string{constant.HeaderContentType: problem.ContentType},
+		Body:    body,
+	}
+}
+
+// batchRecorder 是子請求的 http.ResponseWriter；實作 Flush 是因為 gin 的 SSE 會呼叫它。
+type batchRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBatchRecorder() *batchRecorder {
+	return &batchRecorder{header: http.Header{}}
+}
+
+func (r *batchRecorder) Header() http.Header { return r.header }
+
+func (r *batchRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *batchRecorder) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(b)
+}
+
+func (r *batchRecorder) Flush() {}
+
+func (r *batchRecorder) response(id string) BatchSubResponse {
+	status := r.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	res := BatchSubResponse{ID: id, Status: status}
+	if len(r.header) > 0 {
+		res.Headers = make(map[string]string, len(r.header))
+		for k := range r.header {
+			res.Headers[k] = r.header.Get(k)
+		}
+	}
+	if r.body.Len() == 0 {
+		return res
+	}
+	if isJSONContent(r.header.Get(constant.HeaderContentType)) && json.Valid(r.body.Bytes()) {
+		res.Body = r.body.Bytes()
+	} else {
+		res.Body, _ = json.Marshal(r.body.String())
+	}
+	return res
+}
+
+// isJSONContent 判斷 application/json 與 application/*+json（例如 problem+json）。
+func isJSONContent(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,183 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+)
+
+func setupBatchRouter(order *[]string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ErrorHandler())
+
+	var mu sync.Mutex
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		*order = append(*order, name)
+	}
+
+	auth := func(c *gin.Context) {
+		if c.GetHeader(constant.HeaderAuthorization) != "Bearer good" {
+			problem.Write(c, problem.Unauthorized("invalid or expired token"))
+			return
+		}
+		c.Set(constant.ContextUserIDKey, uint(1))
+	}
+	api := r.Group("/v1", auth)
+	api.GET("/me", func(c *gin.Context) {
+		time.Sleep(10 * time.Millisecond)
+		record("me")
+		c.JSON(http.StatusOK, gin.H{"user_id": c.MustGet(constant.ContextUserIDKey)})
+	})
+	api.POST("/items", func(c *gin.Context) {
+		var body struct {
+			Name string `json:"name" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			_ = c.Error(problem.FromBinding(err))
+			return
+		}
+		record("items")
+		c.JSON(http.StatusCreated, body)
+	})
+	api.GET("/text", func(c *gin.Context) { c.String(http.StatusOK, "plain") })
+	api.GET("/slow", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+	})
+
+	batch := handler.NewBatchHandler(r, handler.WithBatchTimeout(50*time.Millisecond), handler.WithMaxBatchRequests(4))
+	api.POST("/batch", batch.Batch)
+	return r
+}
+
+func postBatch(r *gin.Engine, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, "/v1/batch", strings.NewReader(body))
+	req.Header.Set(constant.HeaderContentType, "application/json")
+	req.Header.Set(constant.HeaderAuthorization, "Bearer good")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestBatch(t *testing.T) {
+	t.Run("runs sub-requests with the caller's auth", func(t *testing.T) {
+		var order []string
+		r := setupBatchRouter(&order)
+
+		w := postBatch(r, `{"requests":[
+			{"id":"me","method":"GET","path":"/v1/me"},
+			{"id":"text","method":"get","path":"/v1/text"},
+			{"id":"missing","method":"GET","path":"/v1/nope"}
+		]}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res handler.BatchResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		require.Len(t, res.Responses, 3)
+		assert.Equal(t, "me", res.Responses[0].ID)
+		assert.Equal(t, http.StatusOK, res.Responses[0].Status)
+		assert.JSONEq(t, `{"user_id":1}`, string(res.Responses[0].Body))
+		assert.JSONEq(t, `"plain"`, string(res.Responses[1].Body))
+		assert.Equal(t, http.StatusNotFound, res.Responses[2].Status)
+	})
+
+	t.Run("dependencies run in order and failures skip dependents", func(t *testing.T) {
+		var order []string
+		r := setupBatchRouter(&order)
+
+		w := postBatch(r, `{"requests":[
+			{"id":"create","method":"POST","path":"/v1/items","body":{"name":"a"},"depends_on":["me"]},
+			{"id":"me","method":"GET","path":"/v1/me"},
+			{"id":"bad","method":"POST","path":"/v1/items","body":{}},
+			{"id":"after_bad","method":"GET","path":"/v1/me","depends_on":["bad"]}
+		]}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res handler.BatchResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, http.StatusCreated, res.Responses[0].Status)
+		assert.Equal(t, []string{"me", "items"}, order)
+		assert.Equal(t, http.StatusBadRequest, res.Responses[2].Status)
+		assert.Equal(t, http.StatusFailedDependency, res.Responses[3].Status)
+		assert.Contains(t, string(res.Responses[3].Body), `"code":"dependency_failed"`)
+	})
+
+	t.Run("sub-request timeout", func(t *testing.T) {
+		var order []string
+		r := setupBatchRouter(&order)
+
+		w := postBatch(r, `{"requests":[{"method":"GET","path":"/v1/slow"},{"method":"GET","path":"/v1/text"}]}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res handler.BatchResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, http.StatusGatewayTimeout, res.Responses[0].Status)
+		assert.Contains(t, string(res.Responses[0].Body), `"code":"timeout"`)
+		assert.Equal(t, http.StatusOK, res.Responses[1].Status)
+	})
+
+	t.Run("nested batch is rejected per item", func(t *testing.T) {
+		var order []string
+		r := setupBatchRouter(&order)
+
+		for _, path := range []string{"/v1/batch", "/v1/%62atch"} {
+			w := postBatch(r, `{"requests":[{"method":"POST","path":"`+path+`","body":{"requests":[{"method":"GET","path":"/v1/me"}]}}]}`)
+			require.Equal(t, http.StatusOK, w.Code, path)
+
+			var res handler.BatchResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res), path)
+			assert.Equal(t, http.StatusBadRequest, res.Responses[0].Status, path)
+			assert.Contains(t, string(res.Responses[0].Body), "batch requests cannot be nested", path)
+		}
+		assert.Empty(t, order, "no nested sub-request ran")
+	})
+
+	t.Run("invalid batches", func(t *testing.T) {
+		var order []string
+		r := setupBatchRouter(&order)
+
+		cases := map[string]string{
+			"too many":   `{"requests":[{"method":"GET","path":"/v1/me"},{"method":"GET","path":"/v1/me"},{"method":"GET","path":"/v1/me"},{"method":"GET","path":"/v1/me"},{"method":"GET","path":"/v1/me"}]}`,
+			"cycle":      `{"requests":[{"id":"a","method":"GET","path":"/v1/me","depends_on":["b"]},{"id":"b","method":"GET","path":"/v1/me","depends_on":["a"]}]}`,
+			"unknown id": `{"requests":[{"id":"a","method":"GET","path":"/v1/me","depends_on":["z"]}]}`,
+			"duplicate":  `{"requests":[{"id":"a","method":"GET","path":"/v1/me"},{"id":"a","method":"GET","path":"/v1/me"}]}`,
+		}
+		for name, body := range cases {
+			w := postBatch(r, body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, name)
+			assert.Contains(t, w.Body.String(), `"code":"invalid_batch"`, name)
+		}
+
+		w := postBatch(r, `{"requests":[]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"validation_failed"`)
+		assert.Empty(t, order)
+	})
+
+	t.Run("oversized body is rejected before parsing", func(t *testing.T) {
+		var order []string
+		r := setupBatchRouter(&order)
+
+		body := `{"requests":[{"method":"POST","path":"/v1/items","body":{"name":"` +
+			strings.Repeat("x", handler.MaxBatchBytes) + `"}}]}`
+		w := postBatch(r, body)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"payload_too_large"`)
+		assert.Empty(t, order)
+	})
+}
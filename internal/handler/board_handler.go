@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/realtime"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+const (
+	// boardReadTimeout 內沒有收到任何訊息（含 ping）就視為斷線。
+	boardReadTimeout     = 60 * time.Second
+	boardWriteTimeout    = 10 * time.Second
+	boardMaxMessageBytes = 4 << 10
+)
+
+// 看板通道的訊息類型；伺服器另外會轉送 task.* 事件與 presence 訊息。
+const (
+	boardSubscribe    = "subscribe"
+	boardSubscribed   = "subscribed"
+	boardUnsubscribe  = "unsubscribe"
+	boardUnsubscribed = "unsubscribed"
+	boardPresence     = "presence"
+	boardPing         = "ping"
+	boardPong         = "pong"
+	boardError        = "error"
+)
+
+// boardRequest 是 client 送來的訊息；Ref 會原樣帶回對應的回應，方便 client 對應請求。
+type boardRequest struct {
+	Type      string `json:"type"`
+	Ref       string `json:"ref"`
+	ProjectID uint   `json:"project_id"`
+	TaskID    *uint  `json:"task_id"`
+	State     string `json:"state"`
+}
+
+type BoardHandler struct {
+	boardService   service.BoardService
+	readTimeout    time.Duration
+	allowedOrigins []string
+}
+
+// BoardHandlerOption 調整看板連線的設定。
+type BoardHandlerOption func(*BoardHandler)
+
+// WithAllowedOrigins 設定同源以外可以開啟看板連線的網頁來源，格式為 scheme://host[:port]。
+func WithAllowedOrigins(origins ...string) BoardHandlerOption {
+	return func(h *BoardHandler) { h.allowedOrigins = append(h.allowedOrigins, origins...) }
+}
+
+func NewBoardHandler(boardService service.BoardService, opts ...BoardHandlerOption) *BoardHandler {
+	h := &BoardHandler{boardService: boardService, readTimeout: boardReadTimeout}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Connect 把請求升級為 WebSocket，作為專案看板的雙向協作通道。
+// 瀏覽器無法在 WebSocket 請求帶 Authorization header，JWT 改以 Sec-WebSocket-Protocol 帶入（見 constant.WebSocketProtocolBoard）。
+func (h *BoardHandler) Connect(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	srv := websocket.Server{
+		Handshake: func(config *websocket.Config, r *http.Request) error {
+			origin, err := websocket.Origin(config, r)
+			if err != nil || !h.originAllowed(origin, r) {
+				return errBoardOrigin
+			}
+			config.Protocol = boardProtocol(config.Protocol)
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			h.serve(ws, userID.(uint))
+		},
+	}
+	srv.ServeHTTP(c.Writer, c.Request)
+}
+
+var errBoardOrigin = errors.New("origin not allowed")
+
+// originAllowed 只接受同源與設定的網頁來源，避免其他網站的頁面以使用者的憑證開啟看板。
+// 瀏覽器一律會帶 Origin；沒有 Origin 的是非瀏覽器 client，不受影響。
+func (h *BoardHandler) originAllowed(origin *url.URL, r *http.Request) bool {
+	if origin == nil {
+		return true
+	}
+	if strings.EqualFold(origin.Host, r.Host) {
+		return true
+	}
+	return slices.Contains(h.allowedOrigins, origin.Scheme+"://"+origin.Host)
+}
+
+// boardProtocol 選出要回應的 subprotocol：只回應看板協定，帶 JWT 的那一項不會回傳給 client。
+func boardProtocol(offered []string) []string {
+	if slices.Contains(offered, constant.WebSocketProtocolBoard) {
+		return []string{constant.WebSocketProtocolBoard}
+	}
+	return nil
+}
+
+func (h *BoardHandler) serve(ws *websocket.Conn, userID uint) {
+	ws.MaxPayloadBytes = boardMaxMessageBytes
+	client := h.boardService.Connect(userID)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.writeLoop(ws, client)
+	}()
+
+	h.readLoop(ws, client)
+	h.boardService.Disconnect(client)
+	<-done
+}
+
+// readLoop 在 client 斷線、逾時或送來無法解析的訊息時結束。
+func (h *BoardHandler) readLoop(ws *websocket.Conn, client *realtime.BoardClient) {
+	ctx := ws.Request().Context()
+	for {
+		ws.SetReadDeadline(time.Now().Add(h.readTimeout))
+		var req boardRequest
+		if err := websocket.JSON.Receive(ws, &req); err != nil {
+			return
+		}
+		if reply := h.handle(ctx, client, req); reply != nil {
+			reply.Ref = req.Ref
+			h.boardService.Send(client, *reply)
+		}
+	}
+}
+
+// writeLoop 是唯一寫入連線的 goroutine；Send 被關閉（斷線或處理太慢）或寫入失敗時關閉連線，讓 readLoop 結束。
+func (h *BoardHandler) writeLoop(ws *websocket.Conn, client *realtime.BoardClient) {
+	defer ws.Close()
+	for msg := range client.Send {
+		ws.SetWriteDeadline(time.Now().Add(boardWriteTimeout))
+		if err := websocket.JSON.Send(ws, msg); err != nil {
+			h.boardService.Disconnect(client)
+			return
+		}
+	}
+}
+
+// handle 處理一則 client 訊息並回傳要回覆的內容；nil 表示不需回覆。
+func (h *BoardHandler) handle(ctx context.Context, client *realtime.BoardClient, req boardRequest) *realtime.BoardMessage {
+	switch req.Type {
+	case boardSubscribe:
+		members, err := h.boardService.Subscribe(ctx, client, req.ProjectID)
+		if err != nil {
+			return boardErrorReply(req.ProjectID, err)
+		}
+		return &realtime.BoardMessage{Type: boardSubscribed, ProjectID: req.ProjectID, Members: members}
+	case boardUnsubscribe:
+		h.boardService.Unsubscribe(client, req.ProjectID)
+		return &realtime.BoardMessage{Type: boardUnsubscribed, ProjectID: req.ProjectID}
+	case boardPresence:
+		if err := h.boardService.UpdatePresence(ctx, client, req.ProjectID, req.TaskID, req.State); err != nil {
+			return boardErrorReply(req.ProjectID, err)
+		}
+		return nil
+	case boardPing:
+		return &realtime.BoardMessage{Type: boardPong}
+	default:
+		return &realtime.BoardMessage{Type: boardError, Error: "unknown message type"}
+	}
+}
+
+func boardErrorReply(projectID uint, err error) *realtime.BoardMessage {
+	var msg string
+	switch {
+	case errors.Is(err, service.ErrProjectNotFound):
+		msg = "project not found"
+	case errors.Is(err, service.ErrPermissionDenied):
+		msg = "permission denied"
+	case errors.Is(err, service.ErrBoardNotSubscribed):
+		msg = "not subscribed to project"
+	case errors.Is(err, service.ErrTooManyBoards):
+		msg = "too many subscriptions"
+	case errors.Is(err, service.ErrInvalidPresence):
+		msg = "invalid presence state"
+	default:
+		msg = "internal error"
+	}
+	return &realtime.BoardMessage{Type: boardError, ProjectID: projectID, Error: msg}
+}
@@ -0,0 +1,161 @@
+package handler_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/realtime"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func dialBoard(t *testing.T, url string) *websocket.Conn {
+	t.Helper()
+	ws, err := websocket.Dial(url, "", "http"+strings.TrimPrefix(url, "ws"))
+	require.NoError(t, err)
+	t.Cleanup(func() { ws.Close() })
+	return ws
+}
+
+func readBoard(t *testing.T, ws *websocket.Conn) realtime.BoardMessage {
+	t.Helper()
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var msg realtime.BoardMessage
+	require.NoError(t, websocket.JSON.Receive(ws, &msg))
+	return msg
+}
+
+func TestBoardConnect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	projects := mock_repository.NewMockProjectRepository(ctrl)
+	projects.EXPECT().FindByID(gomock.Any(), uint(10)).Return(&model.Project{ID: 10, UserID: 1}, nil).AnyTimes()
+	projects.EXPECT().FindByID(gomock.Any(), uint(20)).Return(&model.Project{ID: 20, UserID: 2}, nil).AnyTimes()
+	projects.EXPECT().IsMember(gomock.Any(), uint(20), uint(1)).Return(false, nil).AnyTimes()
+
+	broker := realtime.NewMemoryBroker()
+	stream := service.NewStreamService(broker)
+	h := handler.NewBoardHandler(service.NewBoardService(realtime.NewBoardHub(), broker, projects))
+
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	router.GET("/ws", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.Connect(c)
+	})
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+
+	alice := dialBoard(t, url)
+	bob := dialBoard(t, url)
+
+	// 每次訂閱都檢查權限
+	require.NoError(t, websocket.JSON.Send(alice, map[string]any{"type": "subscribe", "ref": "r1", "project_id": 20}))
+	msg := readBoard(t, alice)
+	assert.Equal(t, "error", msg.Type)
+	assert.Equal(t, "r1", msg.Ref)
+	assert.Equal(t, "permission denied", msg.Error)
+
+	require.NoError(t, websocket.JSON.Send(alice, map[string]any{"type": "subscribe", "ref": "r2", "project_id": 10}))
+	msg = readBoard(t, alice)
+	assert.Equal(t, "subscribed", msg.Type)
+	assert.Equal(t, "r2", msg.Ref)
+	assert.Empty(t, msg.Members)
+
+	require.NoError(t, websocket.JSON.Send(bob, map[string]any{"type": "subscribe", "project_id": 10}))
+	msg = readBoard(t, bob)
+	assert.Equal(t, "subscribed", msg.Type)
+	require.Len(t, msg.Members, 1)
+	aliceID := msg.Members[0].ClientID
+
+	msg = readBoard(t, alice)
+	assert.Equal(t, "presence", msg.Type)
+	assert.Equal(t, realtime.PresenceViewing, msg.Presence.State)
+	bobID := msg.Presence.ClientID
+
+	require.NoError(t, websocket.JSON.Send(alice, map[string]any{"type": "presence", "project_id": 10, "task_id": 3, "state": "editing"}))
+	msg = readBoard(t, bob)
+	assert.Equal(t, aliceID, msg.Presence.ClientID)
+	assert.Equal(t, realtime.PresenceEditing, msg.Presence.State)
+	require.NotNil(t, msg.Presence.TaskID)
+	assert.Equal(t, uint(3), *msg.Presence.TaskID)
+
+	// 任務異動廣播給看板上的所有連線
+	projectID := uint(10)
+	stream.PublishTaskEvent(context.Background(), service.EventTaskUpdated, &model.Task{ID: 3, UserID: 1, ProjectID: &projectID, Title: "card"})
+	for _, ws := range []*websocket.Conn{alice, bob} {
+		msg = readBoard(t, ws)
+		assert.Equal(t, service.EventTaskUpdated, msg.Type)
+		assert.Contains(t, string(msg.Data), `"title":"card"`)
+	}
+
+	require.NoError(t, websocket.JSON.Send(alice, map[string]any{"type": "ping"}))
+	assert.Equal(t, "pong", readBoard(t, alice).Type)
+
+	// 斷線後其他成員收到 left
+	require.NoError(t, bob.Close())
+	msg = readBoard(t, alice)
+	assert.Equal(t, "presence", msg.Type)
+	assert.Equal(t, bobID, msg.Presence.ClientID)
+	assert.Equal(t, realtime.PresenceLeft, msg.Presence.State)
+}
+
+func TestBoardConnect_Subprotocol(t *testing.T) {
+	broker := realtime.NewMemoryBroker()
+	h := handler.NewBoardHandler(service.NewBoardService(realtime.NewBoardHub(), broker, nil))
+
+	router := gin.New()
+	router.GET("/ws", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.Connect(c)
+	})
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	// 帶 JWT 的 subprotocol 不會出現在回應中
+	config, err := websocket.NewConfig("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", srv.URL)
+	require.NoError(t, err)
+	config.Protocol = []string{constant.WebSocketProtocolBoard, constant.WebSocketTokenProtocolPrefix + "jwt"}
+	ws, err := websocket.DialConfig(config)
+	require.NoError(t, err)
+	defer ws.Close()
+	assert.Equal(t, []string{constant.WebSocketProtocolBoard}, ws.Config().Protocol)
+}
+
+func TestBoardConnect_Origin(t *testing.T) {
+	broker := realtime.NewMemoryBroker()
+	h := handler.NewBoardHandler(service.NewBoardService(realtime.NewBoardHub(), broker, nil),
+		handler.WithAllowedOrigins("https://app.example.com"))
+
+	router := gin.New()
+	router.GET("/ws", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.Connect(c)
+	})
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+
+	// 其他網站的頁面不能開啟看板
+	_, err := websocket.Dial(url, "", "https://evil.example.com")
+	assert.Error(t, err)
+
+	for _, origin := range []string{srv.URL, "https://app.example.com"} {
+		ws, err := websocket.Dial(url, "", origin)
+		require.NoError(t, err, origin)
+		ws.Close()
+	}
+}
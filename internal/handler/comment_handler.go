@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/util"
+)
+
+type CommentHandler struct {
+	commentService service.CommentService
+}
+
+func NewCommentHandler(commentService service.CommentService) *CommentHandler {
+	return &CommentHandler{commentService: commentService}
+}
+
+type CreateCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+type CommentResponse struct {
+	ID        uint      `json:"id"`
+	TaskID    uint      `json:"task_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newCommentResponse(cm *model.Comment) CommentResponse {
+	return CommentResponse{ID: cm.ID, TaskID: cm.TaskID, Body: cm.Body, CreatedAt: cm.CreatedAt}
+}
+
+func (h *CommentHandler) AddComment(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var taskID uint
+	if err := util.ParseUintParam(c, "id", &taskID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid task ID"))
+		return
+	}
+
+	var req CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(problem.FromBinding(err))
+		return
+	}
+
+	comment, err := h.commentService.AddComment(c.Request.Context(), userID.(uint), taskID, req.Body)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newCommentResponse(comment))
+}
+
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var taskID uint
+	if err := util.ParseUintParam(c, "id", &taskID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid task ID"))
+		return
+	}
+
+	comments, err := h.commentService.ListComments(c.Request.Context(), userID.(uint), taskID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res := make([]CommentResponse, 0, len(comments))
+	for _, cm := range comments {
+		res = append(res, newCommentResponse(cm))
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
+)
+
+func TestAddComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockCommentService(ctrl)
+	h := handler.NewCommentHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.POST("/tasks/:id/comments", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.AddComment(c)
+	})
+
+	t.Run("created", func(t *testing.T) {
+		mockSvc.EXPECT().AddComment(gomock.Any(), uint(1), uint(5), "nice").
+			Return(&model.Comment{ID: 9, TaskID: 5, UserID: 1, Body: "nice"}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/tasks/5/comments", strings.NewReader(`{"body":"nice"}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"body":"nice"`)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		mockSvc.EXPECT().AddComment(gomock.Any(), uint(1), uint(6), "x").Return(nil, service.ErrPermissionDenied)
+
+		req, _ := http.NewRequest(http.MethodPost, "/tasks/6/comments", strings.NewReader(`{"body":"x"}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("missing body", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/tasks/5/comments", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestListComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockCommentService(ctrl)
+	h := handler.NewCommentHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.GET("/tasks/:id/comments", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.ListComments(c)
+	})
+
+	mockSvc.EXPECT().ListComments(gomock.Any(), uint(1), uint(7)).Return(nil, service.ErrTaskNotFound)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/7/comments", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
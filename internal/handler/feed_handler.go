@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/export"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+const (
+	feedCalendarName = "GoTasker"
+	// feedCacheControl 讓 proxy 不快取（網址本身就是憑證），client 在短時間內可直接重用。
+	feedCacheControl = "private, max-age=300"
+)
+
+type FeedHandler struct {
+	feedService service.FeedService
+}
+
+func NewFeedHandler(feedService service.FeedService) *FeedHandler {
+	return &FeedHandler{feedService: feedService}
+}
+
+type FeedTokenResponse struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+// RotateFeedToken 產生新的訂閱網址，舊網址立即失效。
+func (h *FeedHandler) RotateFeedToken(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	token, err := h.feedService.RotateToken(c.Request.Context(), userID.(uint))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, FeedTokenResponse{Token: token, URL: feedURL(c, token)})
+}
+
+func (h *FeedHandler) RevokeFeedToken(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	if err := h.feedService.RevokeToken(c.Request.Context(), userID.(uint)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetFeed 回傳 /feeds/:token.ics 的日曆內容，不需要 JWT。
+// 內容以 ETag 提供條件式請求，日曆輪詢在沒有變更時只會拿到 304。
+// 不送 Last-Modified：刪除任務或清除 due_at 不會讓剩餘任務的 UpdatedAt 前進，
+// 只帶 If-Modified-Since 的 client 會一直拿到 304 而保留已移除的事件。
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	token, ok := strings.CutSuffix(c.Param("file"), ".ics")
+	if !ok {
+		_ = c.Error(service.ErrFeedNotFound)
+		return
+	}
+
+	tasks, err := h.feedService.FeedTasks(c.Request.Context(), token)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	enc := export.NewFeedEncoder(&buf, feedCalendarName)
+	err = enc.Begin()
+	for _, t := range tasks {
+		if err != nil {
+			break
+		}
+		err = enc.Encode(t)
+	}
+	if err == nil {
+		err = enc.End()
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	c.Header("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	c.Header("Cache-Control", feedCacheControl)
+	c.Header(constant.HeaderContentType, export.ContentType(export.FormatICS))
+	http.ServeContent(c.Writer, c.Request, "tasks.ics", time.Time{}, bytes.NewReader(buf.Bytes()))
+}
+
+// feedURL 組出日曆 client 使用的完整網址；在反向代理後方時依 X-Forwarded-Proto 判斷 scheme。
+func feedURL(c *gin.Context, token string) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host + "/feeds/" + token + ".ics"
+}
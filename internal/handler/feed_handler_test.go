@@ -0,0 +1,106 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
+)
+
+func TestRotateFeedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockFeedService(ctrl)
+	h := handler.NewFeedHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.POST("/feed/token", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.RotateFeedToken(c)
+	})
+
+	mockSvc.EXPECT().RotateToken(gomock.Any(), uint(1)).Return("abc", nil)
+
+	req, _ := http.NewRequest(http.MethodPost, "/feed/token", nil)
+	req.Host = "tasks.example.com"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"token":"abc","url":"https://tasks.example.com/feeds/abc.ics"}`, w.Body.String())
+}
+
+func TestGetFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockFeedService(ctrl)
+	h := handler.NewFeedHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.GET("/feeds/:file", h.GetFeed)
+
+	updated := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	due := updated.Add(48 * time.Hour)
+	tasks := []*model.Task{{ID: 1, Title: "Pay rent", DueAt: &due, CreatedAt: updated, UpdatedAt: updated}}
+
+	mockSvc.EXPECT().FeedTasks(gomock.Any(), "tok").Return(tasks, nil).Times(3)
+
+	req, _ := http.NewRequest(http.MethodGet, "/feeds/tok.ics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get(constant.HeaderContentType))
+	assert.Equal(t, "private, max-age=300", w.Header().Get("Cache-Control"))
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+	assert.Contains(t, w.Body.String(), "BEGIN:VEVENT")
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	// 沒有變更時回 304
+	req, _ = http.NewRequest(http.MethodGet, "/feeds/tok.ics", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	// 只帶 If-Modified-Since 時一律回完整內容，避免刪除的事件被留在 client
+	req, _ = http.NewRequest(http.MethodGet, "/feeds/tok.ics", nil)
+	req.Header.Set("If-Modified-Since", updated.Add(time.Hour).Format(http.TimeFormat))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	t.Run("revoked", func(t *testing.T) {
+		mockSvc.EXPECT().FeedTasks(gomock.Any(), "old").Return(nil, service.ErrFeedNotFound)
+
+		req, _ := http.NewRequest(http.MethodGet, "/feeds/old.ics", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("missing extension", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/feeds/tok", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
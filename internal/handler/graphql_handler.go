@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/graph"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+)
+
+type GraphQLHandler struct {
+	server http.Handler
+}
+
+func NewGraphQLHandler(server http.Handler) *GraphQLHandler {
+	return &GraphQLHandler{server: server}
+}
+
+// Serve 執行 GraphQL 查詢；使用者由前面的 JWT middleware 驗證後帶入。
+func (h *GraphQLHandler) Serve(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	ctx := graph.WithUserID(c.Request.Context(), userID.(uint))
+	h.server.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+}
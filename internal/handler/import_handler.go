@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/importer"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/util"
+)
+
+// maxImportBytes 是上傳檔案（含 multipart 包裝）的大小上限。
+const maxImportBytes = 20 << 20
+
+type ImportHandler struct {
+	importService service.ImportService
+}
+
+func NewImportHandler(importService service.ImportService) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+type ImportJobResponse struct {
+	ID        uint            `json:"id"`
+	Format    string          `json:"format"`
+	Status    string          `json:"status"`
+	Total     int             `json:"total"`
+	Processed int             `json:"processed"`
+	Error     string          `json:"error,omitempty"`
+	Errors    json.RawMessage `json:"errors,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+func newImportJobResponse(job *model.ImportJob) ImportJobResponse {
+	res := ImportJobResponse{
+		ID:        job.ID,
+		Format:    job.Format,
+		Status:    job.Status,
+		Total:     job.Total,
+		Processed: job.Processed,
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	}
+	if job.Errors != "" {
+		res.Errors = json.RawMessage(job.Errors)
+	}
+	return res
+}
+
+// ImportTasks 接受 multipart 欄位 file，format=csv|json|todoist|trello（未指定時依副檔名判斷）。
+// dry_run=true 只檢查並回傳逐列錯誤；超過 service.AsyncImportThreshold 列時改為背景工作並回傳 202。
+func (h *ImportHandler) ImportTasks(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			_ = c.Error(problem.New(http.StatusRequestEntityTooLarge, problem.CodePayloadTooLarge, "file too large"))
+			return
+		}
+		_ = c.Error(problem.BadRequest("file is required"))
+		return
+	}
+
+	format := c.DefaultPostForm("format", c.Query("format"))
+	if format == "" {
+		format = importer.DetectFormat(fh.Filename)
+	}
+	dryRun, _ := strconv.ParseBool(c.DefaultPostForm("dry_run", c.Query("dry_run")))
+
+	f, err := fh.Open()
+	if err != nil {
+		_ = c.Error(problem.BadRequest("invalid file"))
+		return
+	}
+	defer f.Close()
+
+	rows, err := importer.Parse(format, f)
+	if err != nil {
+		switch {
+		case errors.Is(err, importer.ErrUnknownFormat):
+			_ = c.Error(problem.New(http.StatusBadRequest, problem.CodeUnknownFormat, "format must be one of csv, json, todoist, trello"))
+		case errors.Is(err, importer.ErrMissingTitle):
+			_ = c.Error(err)
+		default:
+			_ = c.Error(problem.BadRequest("invalid " + format + " file"))
+		}
+		return
+	}
+
+	ctx := c.Request.Context()
+	if !dryRun && len(rows) > service.AsyncImportThreshold {
+		job, err := h.importService.StartImport(ctx, userID.(uint), format, rows)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		// 與請求使用相同的版本前綴（/v1/tasks/import → /v1/tasks/import/jobs/:id）
+		c.Header(constant.HeaderLocation, c.Request.URL.Path+"/jobs/"+strconv.FormatUint(uint64(job.ID), 10))
+		c.JSON(http.StatusAccepted, newImportJobResponse(job))
+		return
+	}
+
+	report, err := h.importService.Import(ctx, userID.(uint), rows, dryRun)
+	switch {
+	case err == nil && dryRun:
+		c.JSON(http.StatusOK, report)
+	case err == nil:
+		c.JSON(http.StatusCreated, report)
+	case errors.Is(err, service.ErrImportInvalid):
+		c.JSON(http.StatusUnprocessableEntity, report)
+	default:
+		_ = c.Error(err)
+	}
+}
+
+func (h *ImportHandler) GetImportJob(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var jobID uint
+	if err := util.ParseUintParam(c, "job_id", &jobID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid job ID"))
+		return
+	}
+
+	job, err := h.importService.GetJob(c.Request.Context(), userID.(uint), jobID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newImportJobResponse(job))
+}
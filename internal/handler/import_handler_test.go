@@ -0,0 +1,154 @@
+package handler_test
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/importer"
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
+)
+
+// newImportRequest 建立帶有 file 欄位的 multipart 請求。
+func newImportRequest(t *testing.T, url, filename, content string) *http.Request {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = fw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	req, err := http.NewRequest(http.MethodPost, url, &body)
+	require.NoError(t, err)
+	req.Header.Set(constant.HeaderContentType, mw.FormDataContentType())
+	return req
+}
+
+func TestImportTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockImportService(ctrl)
+	h := handler.NewImportHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.POST("/tasks/import", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.ImportTasks(c)
+	})
+
+	t.Run("csv detected from extension", func(t *testing.T) {
+		mockSvc.EXPECT().Import(gomock.Any(), uint(1), gomock.Len(2), false).
+			Return(&service.ImportReport{Total: 2, Valid: 2, Imported: 2, Errors: []service.RowError{}}, nil)
+
+		req := newImportRequest(t, "/tasks/import", "tasks.csv", "title,priority\na,1\nb,2\n")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"imported":2`)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		mockSvc.EXPECT().Import(gomock.Any(), uint(1), gomock.Len(1), true).
+			Return(&service.ImportReport{Total: 1, Errors: []service.RowError{{Line: 2, Error: "title is required"}}}, nil)
+
+		req := newImportRequest(t, "/tasks/import?dry_run=true", "tasks.csv", "title\n\"\"\n")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `{"line":2,"error":"title is required"}`)
+	})
+
+	t.Run("invalid rows", func(t *testing.T) {
+		mockSvc.EXPECT().Import(gomock.Any(), uint(1), gomock.Any(), false).
+			Return(&service.ImportReport{Total: 1, Errors: []service.RowError{{Line: 1, Error: "invalid status"}}}, service.ErrImportInvalid)
+
+		req := newImportRequest(t, "/tasks/import?format=trello", "board.json", `{"cards":[{"name":"a"}]}`)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("large file runs as a job", func(t *testing.T) {
+		var csv strings.Builder
+		csv.WriteString("title\n")
+		for i := 0; i <= service.AsyncImportThreshold; i++ {
+			fmt.Fprintf(&csv, "task %d\n", i)
+		}
+		mockSvc.EXPECT().StartImport(gomock.Any(), uint(1), importer.FormatCSV, gomock.Len(service.AsyncImportThreshold+1)).
+			Return(&model.ImportJob{ID: 4, UserID: 1, Status: model.ImportJobPending, Total: service.AsyncImportThreshold + 1}, nil)
+
+		req := newImportRequest(t, "/tasks/import", "tasks.csv", csv.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, "/tasks/import/jobs/4", w.Header().Get("Location"))
+		assert.Contains(t, w.Body.String(), `"status":"pending"`)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		req := newImportRequest(t, "/tasks/import", "tasks.txt", "title\na\n")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/tasks/import", strings.NewReader(""))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetImportJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockImportService(ctrl)
+	h := handler.NewImportHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.GET("/tasks/import/jobs/:job_id", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.GetImportJob(c)
+	})
+
+	mockSvc.EXPECT().GetJob(gomock.Any(), uint(1), uint(4)).
+		Return(&model.ImportJob{ID: 4, Status: model.ImportJobRunning, Total: 2000, Processed: 500}, nil)
+	mockSvc.EXPECT().GetJob(gomock.Any(), uint(1), uint(5)).Return(nil, service.ErrImportJobNotFound)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/import/jobs/4", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"processed":500`)
+
+	req, _ = http.NewRequest(http.MethodGet, "/tasks/import/jobs/5", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
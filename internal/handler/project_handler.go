@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/util"
+)
+
+type ProjectHandler struct {
+	projectService service.ProjectService
+}
+
+func NewProjectHandler(projectService service.ProjectService) *ProjectHandler {
+	return &ProjectHandler{projectService: projectService}
+}
+
+type CreateProjectRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type ProjectResponse struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	OwnerID uint   `json:"owner_id"`
+}
+
+func newProjectResponse(p *model.Project) ProjectResponse {
+	return ProjectResponse{ID: p.ID, Name: p.Name, OwnerID: p.UserID}
+}
+
+type ProjectMemberResponse struct {
+	UserID  uint      `json:"user_id"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req CreateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(problem.FromBinding(err))
+		return
+	}
+
+	project, err := h.projectService.CreateProject(c.Request.Context(), userID.(uint), req.Name)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newProjectResponse(project))
+}
+
+func (h *ProjectHandler) ListProjects(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	projects, err := h.projectService.ListProjects(c.Request.Context(), userID.(uint))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res := make([]ProjectResponse, 0, len(projects))
+	for _, p := range projects {
+		res = append(res, newProjectResponse(p))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *ProjectHandler) ListMembers(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var projectID uint
+	if err := util.ParseUintParam(c, "id", &projectID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid project ID"))
+		return
+	}
+
+	members, err := h.projectService.ListMembers(c.Request.Context(), userID.(uint), projectID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res := make([]ProjectMemberResponse, 0, len(members))
+	for _, m := range members {
+		res = append(res, ProjectMemberResponse{UserID: m.UserID, AddedAt: m.CreatedAt})
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *ProjectHandler) AddMember(c *gin.Context) {
+	h.changeMember(c, h.projectService.AddMember)
+}
+
+func (h *ProjectHandler) RemoveMember(c *gin.Context) {
+	h.changeMember(c, h.projectService.RemoveMember)
+}
+
+// changeMember 解析 /projects/:id/members/:user_id 並套用 change，成功時回傳 204。
+func (h *ProjectHandler) changeMember(c *gin.Context, change func(ctx context.Context, userID, projectID, memberID uint) error) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var projectID, memberID uint
+	if err := util.ParseUintParam(c, "id", &projectID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid project ID"))
+		return
+	}
+	if err := util.ParseUintParam(c, "user_id", &memberID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid user ID"))
+		return
+	}
+
+	if err := change(c.Request.Context(), userID.(uint), projectID, memberID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/realtime"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+const (
+	// streamHeartbeat 定期送出註解行，避免 proxy 因閒置而關閉連線。
+	streamHeartbeat = 15 * time.Second
+	// streamRetryMillis 建議 client 斷線後重連的等待時間。
+	streamRetryMillis = 3000
+)
+
+type StreamHandler struct {
+	streamService service.StreamService
+	heartbeat     time.Duration
+}
+
+func NewStreamHandler(streamService service.StreamService) *StreamHandler {
+	return &StreamHandler{streamService: streamService, heartbeat: streamHeartbeat}
+}
+
+// Stream 以 Server-Sent Events 推送目前使用者的任務異動。
+// 支援 Last-Event-ID 補送；緩衝區已不足以補送時先送出 reset 事件，client 應重新載入清單。
+// 連線處理太慢被 broker 中斷時直接結束回應，client 重連即可從 Last-Event-ID 接續。
+func (h *StreamHandler) Stream(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	st, err := h.streamService.Open(ctx, userID.(uint), c.GetHeader("Last-Event-ID"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer st.Close()
+
+	c.Header(constant.HeaderContentType, "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetryMillis)
+	if st.Reset {
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+	var lastID uint64
+	for _, ev := range st.Replay {
+		writeStreamEvent(w, ev)
+		lastID = ev.ID
+	}
+	w.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-st.Events:
+			if !ok {
+				log.Printf("stream: user %d dropped (slow consumer)", userID.(uint))
+				return
+			}
+			// 補送與訂閱之間可能重複
+			if ev.ID <= lastID {
+				continue
+			}
+			writeStreamEvent(w, ev)
+			lastID = ev.ID
+		case <-ticker.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		w.Flush()
+	}
+}
+
+func writeStreamEvent(w gin.ResponseWriter, ev realtime.Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, ev.Data)
+}
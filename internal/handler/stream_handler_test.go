@@ -0,0 +1,74 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/realtime"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+// readUntil 讀取 SSE 回應直到出現 want，回傳讀到的內容。
+func readUntil(t *testing.T, r *bufio.Reader, want string) string {
+	t.Helper()
+	var sb strings.Builder
+	for !strings.Contains(sb.String(), want) {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err, "read so far: %q", sb.String())
+		sb.WriteString(line)
+	}
+	return sb.String()
+}
+
+func TestStream(t *testing.T) {
+	svc := service.NewStreamService(realtime.NewMemoryBroker())
+	h := handler.NewStreamHandler(svc)
+
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	router.GET("/stream", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.Stream(c)
+	})
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	ctx := context.Background()
+	svc.PublishTaskEvent(ctx, service.EventTaskCreated, &model.Task{ID: 5, UserID: 1, Title: "first"})
+
+	reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(reqCtx, http.MethodGet, srv.URL+"/stream", nil)
+	req.Header.Set("Last-Event-ID", "0")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get(constant.HeaderContentType))
+
+	r := bufio.NewReader(resp.Body)
+	out := readUntil(t, r, `"title":"first"`)
+	assert.Contains(t, out, "retry: 3000\n")
+	assert.Contains(t, out, "id: 1\nevent: task.created\ndata: {")
+
+	// 其他使用者的事件不會收到，流水號也是各自獨立
+	svc.PublishTaskEvent(ctx, service.EventTaskCreated, &model.Task{ID: 6, UserID: 2, Title: "other"})
+	svc.PublishTaskEvent(ctx, service.EventTaskDeleted, &model.Task{ID: 5, UserID: 1, Title: "first"})
+	out = readUntil(t, r, "event: task.deleted")
+	assert.Contains(t, out, "id: 2\n")
+	assert.NotContains(t, out, "other")
+}
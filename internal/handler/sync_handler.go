@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+type SyncHandler struct {
+	taskService service.TaskService
+}
+
+func NewSyncHandler(taskService service.TaskService) *SyncHandler {
+	return &SyncHandler{taskService: taskService}
+}
+
+// SyncTaskResponse 在任務欄位之外帶上 change_seq，client 送出修改時以它作為 base_seq。
+type SyncTaskResponse struct {
+	taskFields
+	ChangeSeq int64 `json:"change_seq"`
+}
+
+func newSyncTaskResponse(t *model.Task) *SyncTaskResponse {
+	return &SyncTaskResponse{taskFields: taskFields(newTaskResponse(t)), ChangeSeq: t.ChangeSeq}
+}
+
+type DeletedTaskResponse struct {
+	ID        uint      `json:"id"`
+	ChangeSeq int64     `json:"change_seq"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// SyncChangesResponse 的 sync_token 要保存起來作為下一次的 since；has_more 為 true 時應立即再取一次。
+type SyncChangesResponse struct {
+	Changes   []*SyncTaskResponse   `json:"changes"`
+	Deleted   []DeletedTaskResponse `json:"deleted"`
+	SyncToken string                `json:"sync_token"`
+	HasMore   bool                  `json:"has_more"`
+}
+
+// SyncMutationRequest 的 op 為 create / update / delete；update 與 delete 需帶 id 與 base_seq，
+// 其餘欄位只在 create / update 時使用，未提供的欄位保持不變；clear_due_at 為 true 時清除截止時間。
+type SyncMutationRequest struct {
+	Op         string     `json:"op" binding:"required"`
+	ClientID   string     `json:"client_id"`
+	ID         uint       `json:"id"`
+	BaseSeq    int64      `json:"base_seq"`
+	Title      *string    `json:"title"`
+	Content    *string    `json:"content"`
+	Status     *string    `json:"status"`
+	Priority   *int       `json:"priority"`
+	DueAt      *time.Time `json:"due_at"`
+	ClearDueAt bool       `json:"clear_due_at"`
+}
+
+type SyncMutationsRequest struct {
+	Mutations []SyncMutationRequest `json:"mutations" binding:"required,min=1,dive"`
+}
+
+// SyncResultResponse 的 task 在 applied 時為寫入後的任務，conflict 時為伺服器目前的版本（null 表示已被刪除）。
+type SyncResultResponse struct {
+	ClientID string            `json:"client_id,omitempty"`
+	ID       uint              `json:"id,omitempty"`
+	Status   string            `json:"status"`
+	Error    string            `json:"error,omitempty"`
+	Task     *SyncTaskResponse `json:"task"`
+}
+
+type SyncMutationsResponse struct {
+	Results []SyncResultResponse `json:"results"`
+}
+
+// GetChanges 回傳 since 之後新增、修改或刪除的任務；未帶 since 時回傳所有任務。
+func (h *SyncHandler) GetChanges(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	limit := service.DefaultSyncLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > service.MaxSyncLimit {
+			_ = c.Error(problem.BadRequest("invalid limit"))
+			return
+		}
+		limit = n
+	}
+
+	page, err := h.taskService.SyncChanges(c.Request.Context(), userID.(uint), c.Query("since"), limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res := SyncChangesResponse{
+		Changes:   []*SyncTaskResponse{},
+		Deleted:   []DeletedTaskResponse{},
+		SyncToken: page.Token,
+		HasMore:   page.HasMore,
+	}
+	for _, ch := range page.Changes {
+		if ch.Task == nil {
+			res.Deleted = append(res.Deleted, DeletedTaskResponse{ID: ch.Key.ID, ChangeSeq: ch.Key.Seq, DeletedAt: *ch.DeletedAt})
+			continue
+		}
+		res.Changes = append(res.Changes, newSyncTaskResponse(ch.Task))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// ApplyMutations 依序套用 client 離線時的修改，逐筆回報 applied / conflict / failed。
+func (h *SyncHandler) ApplyMutations(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req SyncMutationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(problem.FromBinding(err))
+		return
+	}
+
+	mutations := make([]service.SyncMutation, 0, len(req.Mutations))
+	for _, m := range req.Mutations {
+		mutations = append(mutations, service.SyncMutation{
+			Op:       m.Op,
+			ClientID: m.ClientID,
+			TaskID:   m.ID,
+			BaseSeq:  m.BaseSeq,
+			Patch: service.TaskPatch{
+				Title:      m.Title,
+				Content:    m.Content,
+				Status:     m.Status,
+				Priority:   m.Priority,
+				DueAt:      m.DueAt,
+				ClearDueAt: m.ClearDueAt,
+			},
+		})
+	}
+
+	results, err := h.taskService.ApplySync(c.Request.Context(), userID.(uint), mutations)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res := SyncMutationsResponse{Results: make([]SyncResultResponse, 0, len(results))}
+	for _, r := range results {
+		item := SyncResultResponse{ClientID: r.ClientID, ID: r.TaskID, Status: r.Status, Error: syncItemError(r.Err)}
+		if r.Task != nil {
+			item.Task = newSyncTaskResponse(r.Task)
+		}
+		res.Results = append(res.Results, item)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// syncItemError 只回傳已知的錯誤訊息，避免把資料庫錯誤細節暴露給 client。
+func syncItemError(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, known := range []error{
+		service.ErrInvalidMutation,
+		service.ErrPermissionDenied,
+		service.ErrInvalidStatus,
+		service.ErrInvalidTransition,
+		service.ErrInvalidPriority,
+	} {
+		if errors.Is(err, known) {
+			return known.Error()
+		}
+	}
+	return "internal error"
+}
@@ -0,0 +1,138 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
+)
+
+func setupSyncRouter(t *testing.T) (*mock_service.MockTaskService, *gin.Engine) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock_service.NewMockTaskService(ctrl)
+	h := handler.NewSyncHandler(mockSvc)
+
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	withUser := func(fn gin.HandlerFunc) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Set(constant.ContextUserIDKey, uint(1))
+			fn(c)
+		}
+	}
+	router.GET("/sync", withUser(h.GetChanges))
+	router.POST("/sync", withUser(h.ApplyMutations))
+	return mockSvc, router
+}
+
+func TestSyncGetChanges(t *testing.T) {
+	mockSvc, router := setupSyncRouter(t)
+
+	t.Run("changes and tombstones", func(t *testing.T) {
+		deletedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		mockSvc.EXPECT().SyncChanges(gomock.Any(), uint(1), "tok", 50).Return(&service.SyncPage{
+			Changes: []repository.TaskChange{
+				{Key: repository.ChangeKey{Seq: 3, ID: 5}, Task: &model.Task{ID: 5, Title: "a", ChangeSeq: 3}},
+				{Key: repository.ChangeKey{Seq: 4, ID: 6}, DeletedAt: &deletedAt},
+			},
+			Token:   "next",
+			HasMore: true,
+		}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sync?since=tok&limit=50", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var res handler.SyncChangesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		require.Len(t, res.Changes, 1)
+		assert.Equal(t, int64(3), res.Changes[0].ChangeSeq)
+		assert.Equal(t, "a", res.Changes[0].Title)
+		require.Len(t, res.Deleted, 1)
+		assert.Equal(t, uint(6), res.Deleted[0].ID)
+		assert.Equal(t, "next", res.SyncToken)
+		assert.True(t, res.HasMore)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mockSvc.EXPECT().SyncChanges(gomock.Any(), uint(1), "bad", service.DefaultSyncLimit).Return(nil, service.ErrInvalidSyncToken)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sync?since=bad", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sync?limit=0", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSyncApplyMutations(t *testing.T) {
+	mockSvc, router := setupSyncRouter(t)
+
+	t.Run("per-item results", func(t *testing.T) {
+		mockSvc.EXPECT().ApplySync(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uint, muts []service.SyncMutation) ([]service.SyncResult, error) {
+				require.Len(t, muts, 3)
+				assert.Equal(t, service.SyncOpCreate, muts[0].Op)
+				assert.Equal(t, "tmp-1", muts[0].ClientID)
+				assert.Equal(t, "new", *muts[0].Patch.Title)
+				assert.Equal(t, uint(5), muts[1].TaskID)
+				assert.Equal(t, int64(3), muts[1].BaseSeq)
+				assert.Nil(t, muts[1].Patch.Title)
+				return []service.SyncResult{
+					{ClientID: "tmp-1", TaskID: 9, Status: service.SyncResultApplied, Task: &model.Task{ID: 9, Title: "new", ChangeSeq: 10}},
+					{TaskID: 5, Status: service.SyncResultConflict, Task: &model.Task{ID: 5, Title: "server", ChangeSeq: 8}},
+					{TaskID: 6, Status: service.SyncResultFailed, Err: service.ErrPermissionDenied},
+				}, nil
+			})
+
+		body := `{"mutations":[
+			{"op":"create","client_id":"tmp-1","title":"new"},
+			{"op":"update","id":5,"base_seq":3,"status":"done"},
+			{"op":"delete","id":6,"base_seq":1}
+		]}`
+		req := httptest.NewRequest(http.MethodPost, "/sync", strings.NewReader(body))
+		req.Header.Set(constant.HeaderContentType, constant.ContentTypeJSON)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var res handler.SyncMutationsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		require.Len(t, res.Results, 3)
+		assert.Equal(t, "applied", res.Results[0].Status)
+		assert.Equal(t, int64(10), res.Results[0].Task.ChangeSeq)
+		assert.Equal(t, "conflict", res.Results[1].Status)
+		assert.Equal(t, "server", res.Results[1].Task.Title)
+		assert.Equal(t, "failed", res.Results[2].Status)
+		assert.Equal(t, "permission denied", res.Results[2].Error)
+		assert.Nil(t, res.Results[2].Task)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/sync", strings.NewReader(`{"mutations":[]}`))
+		req.Header.Set(constant.HeaderContentType, constant.ContentTypeJSON)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
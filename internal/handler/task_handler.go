@@ -1,227 +1,676 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
+	"log"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 
 	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/export"
+	"github.com/SoliMark/gotasker-pro/internal/jsonpatch"
 	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
 	"github.com/SoliMark/gotasker-pro/internal/service"
 	"github.com/SoliMark/gotasker-pro/internal/util"
 )
 
 type TaskHandler struct {
-	taskService service.TaskService
+	taskService    service.TaskService
+	commentService service.CommentService
+	projectService service.ProjectService
 }
 
-func NewTaskHandler(taskService service.TaskService) *TaskHandler {
-	return &TaskHandler{taskService: taskService}
+// TaskHandlerOption 設定 TaskHandler 的選用相依。
+type TaskHandlerOption func(*TaskHandler)
+
+// WithCommentService 讓任務回應可以 ?include=comments。
+func WithCommentService(s service.CommentService) TaskHandlerOption {
+	return func(h *TaskHandler) { h.commentService = s }
+}
+
+// WithProjectService 讓任務回應可以 ?include=project。
+func WithProjectService(s service.ProjectService) TaskHandlerOption {
+	return func(h *TaskHandler) { h.projectService = s }
+}
+
+func NewTaskHandler(taskService service.TaskService, opts ...TaskHandlerOption) *TaskHandler {
+	h := &TaskHandler{taskService: taskService}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 type CreateTaskRequest struct {
-	Title   string `json:"title" binding:"required"`
-	Content string `json:"content"`
+	Title    string     `json:"title" binding:"required"`
+	Content  string     `json:"content"`
+	Priority int        `json:"priority"` // 0-9, 0 表示未設定
+	DueAt    *time.Time `json:"due_at"`
+	ParentID *uint      `json:"parent_id"` // 建立為該任務的子任務；只支援一層
 }
 
 type TaskResponse struct {
-	ID      uint   `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	Status  string `json:"status"`
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Content     string     `json:"content"`
+	Status      string     `json:"status"`
+	Position    string     `json:"position"`
+	ProjectID   *uint      `json:"project_id,omitempty"`
+	ParentID    *uint      `json:"parent_id,omitempty"`
+	Priority    int        `json:"priority"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	// 以下關聯只在 ?include= 指定時出現
+	Tags     []TagResponse     `json:"tags,omitempty"`
+	Comments []CommentResponse `json:"comments,omitempty"`
+	Project  *ProjectResponse  `json:"project,omitempty"`
+
+	view *taskView
 }
 
+func newTaskResponse(t *model.Task) TaskResponse {
+	return TaskResponse{
+		ID:          t.ID,
+		Title:       t.Title,
+		Content:     t.Content,
+		Status:      t.Status,
+		Position:    t.Position,
+		ProjectID:   t.ProjectID,
+		ParentID:    t.ParentID,
+		Priority:    t.Priority,
+		DueAt:       t.DueAt,
+		CompletedAt: t.CompletedAt,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+// UpdateTaskRequest 是任務可修改的完整內容：PUT 以它整份取代任務，未提供的欄位會被清空；
+// PATCH 的 patch 也作用在這份文件上。
 type UpdateTaskRequest struct {
-	Title   *string `json:"title"`
-	Content *string `json:"content"`
-	Status  *string `json:"status"` // must be "pending" or "done" if provided
+	Title    string     `json:"title" binding:"required"`
+	Content  string     `json:"content"`
+	Status   string     `json:"status" binding:"required"` // must be a state of the user's workflow
+	Priority int        `json:"priority"`
+	DueAt    *time.Time `json:"due_at"`
+}
+
+func newUpdateTaskRequest(t *model.Task) UpdateTaskRequest {
+	return UpdateTaskRequest{
+		Title:    t.Title,
+		Content:  t.Content,
+		Status:   t.Status,
+		Priority: t.Priority,
+		DueAt:    t.DueAt,
+	}
+}
+
+func (r UpdateTaskRequest) applyTo(t *model.Task) {
+	t.Title = r.Title
+	t.Content = r.Content
+	t.Status = r.Status
+	t.Priority = r.Priority
+	t.DueAt = r.DueAt
+}
+
+// TaskListResponse 是分頁列表的回應；next_cursor 為空表示沒有下一頁，總數放在 X-Total-Count header。
+type TaskListResponse struct {
+	Items      []TaskResponse `json:"items"`
+	Limit      int            `json:"limit"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// SearchHitResponse 是搜尋結果的一筆；snippet 為已 escape 的 HTML，命中字詞以 <mark> 標示。
+type SearchHitResponse struct {
+	Task    TaskResponse `json:"task"`
+	Rank    float64      `json:"rank"`
+	Snippet string       `json:"snippet"`
+}
+
+// BulkTaskRequest 一次送出多筆操作；mode 預設為 atomic。
+type BulkTaskRequest struct {
+	Mode       string                 `json:"mode"`
+	Operations []BulkOperationRequest `json:"operations" binding:"required,min=1,dive"`
+}
+
+// BulkOperationRequest 的 op 為 set_status / delete / move_project / add_tag。
+// move_project 的 project_id 為 null 時表示移出專案。
+type BulkOperationRequest struct {
+	Op        string `json:"op" binding:"required"`
+	TaskID    uint   `json:"task_id" binding:"required"`
+	Status    string `json:"status"`
+	ProjectID *uint  `json:"project_id"`
+	Tag       string `json:"tag"`
+}
+
+type BulkItemResponse struct {
+	TaskID uint   `json:"task_id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkTaskResponse struct {
+	Mode    string             `json:"mode"`
+	Results []BulkItemResponse `json:"results"`
+}
+
+// MoveTaskRequest 指定要放在哪個任務之前（before）或之後（after），兩者擇一。
+type MoveTaskRequest struct {
+	Before *uint `json:"before"`
+	After  *uint `json:"after"`
 }
 
 func (h *TaskHandler) CreateTask(c *gin.Context) {
 	var req CreateTaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		_ = c.Error(problem.FromBinding(err))
 		return
 	}
 
 	userID, exists := c.Get(constant.ContextUserIDKey)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		_ = c.Error(problem.Unauthorized("unauthorized"))
 		return
 	}
 
 	task := &model.Task{
-		UserID:  userID.(uint),
-		Title:   req.Title,
-		Content: req.Content,
-		Status:  model.TaskStatusPending,
+		UserID:   userID.(uint),
+		Title:    req.Title,
+		Content:  req.Content,
+		Priority: req.Priority,
+		DueAt:    req.DueAt,
+		ParentID: req.ParentID,
 	}
 
 	if err := h.taskService.CreateTask(c.Request.Context(), task); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create task"})
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, TaskResponse{
-		ID:      task.ID,
-		Title:   task.Title,
-		Content: task.Content,
-		Status:  task.Status,
-	})
+	c.JSON(http.StatusCreated, newTaskResponse(task))
 }
 
 func (h *TaskHandler) GetTask(c *gin.Context) {
 	userID, exists := c.Get(constant.ContextUserIDKey)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		_ = c.Error(problem.Unauthorized("unauthorized"))
 		return
 	}
 
 	var taskID uint
 	if err := util.ParseUintParam(c, "id", &taskID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		_ = c.Error(problem.BadRequest("invalid task ID"))
+		return
+	}
+
+	view, err := h.parseTaskView(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
 
 	task, err := h.taskService.GetTask(c.Request.Context(), taskID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get task"})
+		_ = c.Error(err)
 		return
 	}
 
 	if task == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		_ = c.Error(service.ErrTaskNotFound)
 		return
 	}
 
 	if task.UserID != userID.(uint) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "permission denied"})
+		_ = c.Error(service.ErrPermissionDenied)
 		return
 	}
 
-	c.JSON(http.StatusOK, TaskResponse{
-		ID:      task.ID,
-		Title:   task.Title,
-		Content: task.Content,
-		Status:  task.Status,
-	})
+	res, err := h.taskResponses(c.Request.Context(), userID.(uint), []*model.Task{task}, view)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, res[0])
 }
 
 func (h *TaskHandler) ListTasks(c *gin.Context) {
 	userID, exists := c.Get(constant.ContextUserIDKey)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		_ = c.Error(problem.Unauthorized("unauthorized"))
 		return
 	}
 
-	tasks, err := h.taskService.ListTasks(c, userID.(uint))
+	limit := service.DefaultPageLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > service.MaxPageLimit {
+			_ = c.Error(problem.BadRequest("invalid limit"))
+			return
+		}
+		limit = n
+	}
+
+	filter, err := parseTaskFilter(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list tasks"})
+		_ = c.Error(problem.BadRequest(err.Error()))
 		return
 	}
-	var res []TaskResponse
-	for _, t := range tasks {
-		res = append(res, TaskResponse{
-			ID:      t.ID,
-			Title:   t.Title,
-			Content: t.Content,
-			Status:  t.Status,
-		})
+
+	view, err := h.parseTaskView(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
 
+	page, err := h.taskService.ListTaskPage(c.Request.Context(), userID.(uint), service.TaskPageRequest{
+		Filter: filter,
+		Sort:   c.Query("sort"),
+		Limit:  limit,
+		Cursor: c.Query("cursor"),
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items, err := h.taskResponses(c.Request.Context(), userID.(uint), page.Tasks, view)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	res := TaskListResponse{
+		Items:      items,
+		Limit:      limit,
+		NextCursor: page.NextCursor,
+	}
+
+	c.Header(constant.HeaderTotalCount, strconv.FormatInt(page.Total, 10))
 	c.JSON(http.StatusOK, res)
 }
 
 func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	userID, exists := c.Get(constant.ContextUserIDKey)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		_ = c.Error(problem.Unauthorized("unauthorized"))
 		return
 	}
 
 	var taskID uint
 	if err := util.ParseUintParam(c, "id", &taskID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		_ = c.Error(problem.BadRequest("invalid task ID"))
 		return
 	}
 
 	var req UpdateTaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		_ = c.Error(problem.FromBinding(err))
 		return
 	}
 
-	if req.Status != nil &&
-		*req.Status != model.TaskStatusPending &&
-		*req.Status != model.TaskStatusDone {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+	task, err := h.ownedTask(c, userID.(uint), taskID)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
+	h.saveTask(c, task, req)
+}
 
-	task, err := h.taskService.GetTask(c.Request.Context(), taskID)
+// PatchTask 以 JSON Merge Patch（RFC 7396）或 JSON Patch（RFC 6902）修改任務，依 Content-Type 判斷格式。
+// patch 作用在 UpdateTaskRequest 這份文件上，套用後與 PUT 的內容一樣經過驗證才寫入。
+func (h *TaskHandler) PatchTask(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var taskID uint
+	if err := util.ParseUintParam(c, "id", &taskID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid task ID"))
+		return
+	}
+
+	var applyPatch func(doc any, patch []byte) (any, error)
+	switch c.ContentType() {
+	case jsonpatch.MergePatchContentType:
+		applyPatch = jsonpatch.MergePatch
+	case jsonpatch.JSONPatchContentType:
+		applyPatch = jsonpatch.Apply
+	default:
+		_ = c.Error(problem.New(http.StatusUnsupportedMediaType, problem.CodeUnsupportedMedia,
+			"Content-Type must be "+jsonpatch.MergePatchContentType+" or "+jsonpatch.JSONPatchContentType))
+		return
+	}
+
+	patch, err := c.GetRawData()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get task"})
+		_ = c.Error(problem.BadRequest("malformed request body"))
 		return
 	}
 
-	if task == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+	task, err := h.ownedTask(c, userID.(uint), taskID)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	if task.UserID != userID.(uint) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "permission denied"})
+	doc, err := toDocument(newUpdateTaskRequest(task))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	patched, err := applyPatch(doc, patch)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
+	req, err := decodePatchedTask(doc, patched)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.saveTask(c, task, req)
+}
 
-	if req.Title != nil {
-		task.Title = *req.Title
+// ownedTask 取得任務並確認屬於 userID。
+func (h *TaskHandler) ownedTask(c *gin.Context, userID, taskID uint) (*model.Task, error) {
+	task, err := h.taskService.GetTask(c.Request.Context(), taskID)
+	if err != nil {
+		return nil, err
 	}
-	if req.Content != nil {
-		task.Content = *req.Content
+	if task == nil {
+		return nil, service.ErrTaskNotFound
 	}
-	if req.Status != nil {
-		task.Status = *req.Status
+	if task.UserID != userID {
+		return nil, service.ErrPermissionDenied
 	}
+	return task, nil
+}
 
+func (h *TaskHandler) saveTask(c *gin.Context, task *model.Task, req UpdateTaskRequest) {
+	req.applyTo(task)
 	if err := h.taskService.UpdateTask(c.Request.Context(), task); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update task"})
+		_ = c.Error(err)
 		return
 	}
+	c.JSON(http.StatusOK, newTaskResponse(task))
+}
 
-	c.JSON(http.StatusOK, TaskResponse{
-		ID:      task.ID,
-		Title:   task.Title,
-		Content: task.Content,
-		Status:  task.Status,
-	})
+// toDocument 把 v 轉成 JSON 解碼後的一般結構，作為 patch 的對象。
+func toDocument(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	err = json.Unmarshal(b, &doc)
+	return doc, err
+}
+
+// decodePatchedTask 把套用 patch 後的文件轉回 UpdateTaskRequest 並驗證；
+// 原文件沒有的欄位（例如 id）不可由 patch 加入。
+func decodePatchedTask(original, patched any) (UpdateTaskRequest, error) {
+	var req UpdateTaskRequest
+	fields, ok := patched.(map[string]any)
+	if !ok {
+		return req, problem.New(http.StatusBadRequest, problem.CodeValidationFailed, "patched task must be a JSON object")
+	}
+	known := original.(map[string]any)
+	var unknown []problem.FieldError
+	for name := range fields {
+		if _, ok := known[name]; !ok {
+			unknown = append(unknown, problem.FieldError{Field: name, Rule: "unknown", Message: "is not a task field"})
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Slice(unknown, func(i, j int) bool { return unknown[i].Field < unknown[j].Field })
+		p := problem.New(http.StatusBadRequest, problem.CodeValidationFailed, "request validation failed")
+		p.Errors = unknown
+		return req, p
+	}
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return req, err
+	}
+	if err := json.Unmarshal(b, &req); err != nil {
+		return req, problem.FromBinding(err)
+	}
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return req, problem.FromBinding(err)
+	}
+	return req, nil
 }
 
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	userIDVal, exists := c.Get(constant.ContextUserIDKey)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		_ = c.Error(problem.Unauthorized("unauthorized"))
 		return
 	}
 	uid := userIDVal.(uint)
 
 	var taskID uint
 	if err := util.ParseUintParam(c, "id", &taskID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		_ = c.Error(problem.BadRequest("invalid task ID"))
 		return
 	}
 
 	if err := h.taskService.DeleteTask(c.Request.Context(), uid, taskID); err != nil {
-		switch {
-		case errors.Is(err, service.ErrTaskNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
-		case errors.Is(err, service.ErrPermissionDenied):
-			c.JSON(http.StatusForbidden, gin.H{"error": "permission denied"})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete task"})
-		}
+		_ = c.Error(err)
 		return
 	}
 
 	c.AbortWithStatus(http.StatusNoContent)
 }
+
+func (h *TaskHandler) MoveTask(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var taskID uint
+	if err := util.ParseUintParam(c, "id", &taskID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid task ID"))
+		return
+	}
+
+	var req MoveTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(problem.FromBinding(err))
+		return
+	}
+
+	var opts service.MoveOptions
+	if req.Before != nil {
+		opts.Before = *req.Before
+	}
+	if req.After != nil {
+		opts.After = *req.After
+	}
+
+	task, err := h.taskService.MoveTask(c.Request.Context(), userID.(uint), taskID, opts)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTaskResponse(task))
+}
+
+func (h *TaskHandler) SearchTasks(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	limit := service.DefaultSearchLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > service.MaxSearchLimit {
+			_ = c.Error(problem.BadRequest("invalid limit"))
+			return
+		}
+		limit = n
+	}
+
+	hits, err := h.taskService.SearchTasks(c.Request.Context(), userID.(uint), c.Query("q"), limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res := make([]SearchHitResponse, 0, len(hits))
+	for _, hit := range hits {
+		res = append(res, SearchHitResponse{
+			Task:    newTaskResponse(hit.Task),
+			Rank:    hit.Rank,
+			Snippet: hit.Snippet,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"items": res})
+}
+
+// BulkTasks 執行批次操作：全部成功（或 best_effort）回 200，atomic 模式有任一項失敗時回 422 並附上每項結果。
+func (h *TaskHandler) BulkTasks(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req BulkTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(problem.FromBinding(err))
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = string(service.BulkAtomic)
+	}
+
+	ops := make([]service.BulkOperation, 0, len(req.Operations))
+	for _, op := range req.Operations {
+		ops = append(ops, service.BulkOperation{
+			Op:        op.Op,
+			TaskID:    op.TaskID,
+			Status:    op.Status,
+			ProjectID: op.ProjectID,
+			Tag:       op.Tag,
+		})
+	}
+
+	results, err := h.taskService.BulkApply(c.Request.Context(), userID.(uint), service.BulkMode(req.Mode), ops)
+	status := http.StatusOK
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrBulkAborted):
+		status = http.StatusUnprocessableEntity
+	default:
+		_ = c.Error(err)
+		return
+	}
+
+	res := BulkTaskResponse{Mode: req.Mode, Results: make([]BulkItemResponse, 0, len(results))}
+	for _, r := range results {
+		res.Results = append(res.Results, BulkItemResponse{TaskID: r.TaskID, Status: r.Status, Error: bulkItemError(r.Err)})
+	}
+	c.JSON(status, res)
+}
+
+// bulkItemError 只回傳已知的錯誤訊息，避免把資料庫錯誤細節暴露給 client。
+func bulkItemError(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, known := range []error{
+		service.ErrTaskNotFound,
+		service.ErrPermissionDenied,
+		service.ErrInvalidStatus,
+		service.ErrInvalidTransition,
+		service.ErrInvalidBulkOp,
+		service.ErrProjectNotFound,
+	} {
+		if errors.Is(err, known) {
+			return known.Error()
+		}
+	}
+	return "internal error"
+}
+
+// ExportTasks 以 format=csv|json|ics 串流匯出使用者的所有任務。
+// 開始寫出後就無法再改變狀態碼，此時的錯誤只能記錄並中斷連線。
+func (h *TaskHandler) ExportTasks(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	format := c.DefaultQuery("format", export.FormatJSON)
+	enc, err := export.NewEncoder(format, c.Writer)
+	if err != nil {
+		_ = c.Error(problem.New(http.StatusBadRequest, problem.CodeUnknownFormat, "format must be one of csv, json, ics"))
+		return
+	}
+
+	c.Header(constant.HeaderContentType, export.ContentType(format))
+	c.Header("Content-Disposition", `attachment; filename="tasks.`+format+`"`)
+	c.Status(http.StatusOK)
+
+	err = enc.Begin()
+	if err == nil {
+		err = h.taskService.ExportTasks(c.Request.Context(), userID.(uint), enc.Encode)
+	}
+	if err == nil {
+		err = enc.End()
+	}
+	if err != nil {
+		log.Printf("export: user %d: %v", userID.(uint), err)
+		c.Abort()
+	}
+}
+
+// parseTaskFilter 解析列表的篩選參數：
+// status=a,b、created_after / created_before / updated_after / updated_before（RFC3339）、title_prefix。
+func parseTaskFilter(c *gin.Context) (service.TaskFilter, error) {
+	var f service.TaskFilter
+	if v := c.Query("status"); v != "" {
+		f.Statuses = strings.Split(v, ",")
+	}
+	f.TitlePrefix = c.Query("title_prefix")
+
+	times := []struct {
+		param string
+		dst   **time.Time
+	}{
+		{"created_after", &f.CreatedAfter},
+		{"created_before", &f.CreatedBefore},
+		{"updated_after", &f.UpdatedAfter},
+		{"updated_before", &f.UpdatedBefore},
+	}
+	for _, t := range times {
+		v := c.Query(t.param)
+		if v == "" {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return f, errors.New("invalid " + t.param)
+		}
+		*t.dst = &ts
+	}
+	return f, nil
+}
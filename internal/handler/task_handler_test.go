@@ -1,11 +1,13 @@
 package handler_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -13,7 +15,9 @@ import (
 
 	"github.com/SoliMark/gotasker-pro/internal/constant"
 	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
 	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
 	"github.com/SoliMark/gotasker-pro/internal/service"
 	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
 )
@@ -26,6 +30,7 @@ func TestCreateTask(t *testing.T) {
 	h := handler.NewTaskHandler(mockSvc)
 
 	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
 	router.POST("/tasks", func(c *gin.Context) {
 		c.Set(constant.ContextUserIDKey, uint(1))
 		h.CreateTask(c)
@@ -64,6 +69,7 @@ func TestGetTask(t *testing.T) {
 	h := handler.NewTaskHandler(mockSvc)
 
 	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
 	router.GET("/tasks/:id", func(c *gin.Context) {
 		c.Set(constant.ContextUserIDKey, uint(1))
 		h.GetTask(c)
@@ -117,22 +123,389 @@ func TestListTaskss(t *testing.T) {
 	h := handler.NewTaskHandler(mockSvc)
 
 	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
 	router.GET("/tasks", func(c *gin.Context) {
 		c.Set(constant.ContextUserIDKey, uint(1))
 		h.ListTasks(c)
 	})
 
 	t.Run("success", func(t *testing.T) {
-		mockSvc.EXPECT().ListTasks(gomock.Any(), uint(1)).Return([]*model.Task{
-			{ID: 1, Title: "T1", Status: model.TaskStatusPending},
-			{ID: 2, Title: "T2", Status: model.TaskStatusDone},
-		}, nil)
+		mockSvc.EXPECT().ListTaskPage(gomock.Any(), uint(1), service.TaskPageRequest{Limit: service.DefaultPageLimit}).
+			Return(&service.TaskPage{
+				Tasks: []*model.Task{
+					{ID: 1, Title: "T1", Status: model.TaskStatusPending},
+					{ID: 2, Title: "T2", Status: model.TaskStatusDone},
+				},
+				Total: 2,
+			}, nil)
 
 		req, _ := http.NewRequest(http.MethodGet, "/tasks", nil)
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get(constant.HeaderTotalCount))
+		assert.NotContains(t, w.Body.String(), "next_cursor")
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		mockSvc.EXPECT().ListTaskPage(gomock.Any(), uint(1), service.TaskPageRequest{Sort: "position", Limit: 2, Cursor: "abc"}).
+			Return(&service.TaskPage{
+				Tasks:      []*model.Task{{ID: 3, Title: "T3", Position: "a"}, {ID: 4, Title: "T4", Position: "b"}},
+				NextCursor: "def",
+				Total:      9,
+			}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/tasks?sort=position&limit=2&cursor=abc", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "9", w.Header().Get(constant.HeaderTotalCount))
+		assert.Contains(t, w.Body.String(), `"next_cursor":"def"`)
+		assert.Contains(t, w.Body.String(), `"position":"a"`)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockSvc.EXPECT().ListTaskPage(gomock.Any(), uint(1), service.TaskPageRequest{
+			Filter: service.TaskFilter{
+				Statuses:     []string{"todo", "review"},
+				CreatedAfter: &after,
+				TitlePrefix:  "rep",
+			},
+			Sort:  "-status,created_at",
+			Limit: service.DefaultPageLimit,
+		}).Return(&service.TaskPage{}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet,
+			"/tasks?status=todo,review&created_after=2025-01-01T00:00:00Z&title_prefix=rep&sort=-status,created_at", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"items":[]`)
+	})
+
+	t.Run("invalid time filter", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/tasks?updated_before=yesterday", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/tasks?limit=0", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		mockSvc.EXPECT().ListTaskPage(gomock.Any(), uint(1), gomock.Any()).Return(nil, service.ErrInvalidCursor)
+
+		req, _ := http.NewRequest(http.MethodGet, "/tasks?cursor=forged", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown sort", func(t *testing.T) {
+		mockSvc.EXPECT().ListTaskPage(gomock.Any(), uint(1), gomock.Any()).Return(nil, service.ErrInvalidSort)
+
+		req, _ := http.NewRequest(http.MethodGet, "/tasks?sort=random", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTaskFieldsAndIncludes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockTaskService(ctrl)
+	mockComments := mock_service.NewMockCommentService(ctrl)
+	mockProjects := mock_service.NewMockProjectService(ctrl)
+	h := handler.NewTaskHandler(mockSvc,
+		handler.WithCommentService(mockComments),
+		handler.WithProjectService(mockProjects),
+	)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.GET("/tasks", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.ListTasks(c)
+	})
+	router.GET("/tasks/:id", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.GetTask(c)
+	})
+
+	projectID := uint(7)
+	created := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	tasks := []*model.Task{
+		{ID: 1, UserID: 1, Title: "T1", Status: model.TaskStatusPending, ProjectID: &projectID, CreatedAt: created, UpdatedAt: created},
+		{ID: 2, UserID: 1, Title: "T2", Status: model.TaskStatusDone, ProjectID: &projectID, CreatedAt: created, UpdatedAt: created},
+	}
+
+	t.Run("sparse fields", func(t *testing.T) {
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(1)).Return(tasks[0], nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/tasks/1?fields=id,title,updated_at", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id":1,"title":"T1","updated_at":"2026-10-01T08:00:00Z"}`, w.Body.String())
+	})
+
+	t.Run("full response has timestamps", func(t *testing.T) {
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(1)).Return(tasks[0], nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/tasks/1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"created_at":"2026-10-01T08:00:00Z"`)
+		assert.NotContains(t, w.Body.String(), `"tags"`)
+	})
+
+	t.Run("includes are loaded once per page", func(t *testing.T) {
+		mockSvc.EXPECT().ListTaskPage(gomock.Any(), uint(1), gomock.Any()).
+			Return(&service.TaskPage{Tasks: tasks, Total: 2}, nil)
+		mockSvc.EXPECT().ListTaskTags(gomock.Any(), uint(1), []uint{1, 2}).
+			Return(map[uint][]*model.Tag{1: {{ID: 3, Name: "work"}}}, nil)
+		mockComments.EXPECT().ListCommentsByTasks(gomock.Any(), uint(1), []uint{1, 2}).
+			Return(map[uint][]*model.Comment{2: {{ID: 9, TaskID: 2, Body: "hi", CreatedAt: created}}}, nil)
+		mockProjects.EXPECT().GetProjects(gomock.Any(), uint(1), []uint{7}).
+			Return([]*model.Project{{ID: 7, UserID: 1, Name: "Home"}}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/tasks?fields=id&include=tags,comments,project", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"items":[
+			{"id":1,"tags":[{"id":3,"name":"work"}],"comments":[],"project":{"id":7,"name":"Home","owner_id":1}},
+			{"id":2,"tags":[],"comments":[{"id":9,"task_id":2,"body":"hi","created_at":"2026-10-01T08:00:00Z"}],"project":{"id":7,"name":"Home","owner_id":1}}
+		],"limit":50}`, w.Body.String())
+	})
+
+	t.Run("unknown field or include", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/tasks?fields=id,secret&include=owner", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `{"field":"fields","rule":"unknown","message":"\"secret\" is not a task field"}`)
+		assert.Contains(t, w.Body.String(), `{"field":"include","rule":"unknown","message":"\"owner\" cannot be included"}`)
+
+		req, _ = http.NewRequest(http.MethodGet, "/tasks/1?fields=tags", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestMoveTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockTaskService(ctrl)
+	h := handler.NewTaskHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.POST("/tasks/:id/move", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.MoveTask(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockSvc.EXPECT().MoveTask(gomock.Any(), uint(1), uint(10), service.MoveOptions{Before: 11}).
+			Return(&model.Task{ID: 10, UserID: 1, Title: "T", Position: "h"}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/tasks/10/move", strings.NewReader(`{"before":11}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"position":"h"`)
+	})
+
+	t.Run("invalid move", func(t *testing.T) {
+		mockSvc.EXPECT().MoveTask(gomock.Any(), uint(1), uint(10), service.MoveOptions{}).
+			Return(nil, service.ErrInvalidMove)
+
+		req, _ := http.NewRequest(http.MethodPost, "/tasks/10/move", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("anchor not found", func(t *testing.T) {
+		mockSvc.EXPECT().MoveTask(gomock.Any(), uint(1), uint(10), service.MoveOptions{After: 99}).
+			Return(nil, service.ErrTaskNotFound)
+
+		req, _ := http.NewRequest(http.MethodPost, "/tasks/10/move", strings.NewReader(`{"after":99}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestSearchTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockTaskService(ctrl)
+	h := handler.NewTaskHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.GET("/tasks/search", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.SearchTasks(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockSvc.EXPECT().SearchTasks(gomock.Any(), uint(1), "rep", 5).Return([]repository.SearchHit{
+			{Task: &model.Task{ID: 3, UserID: 1, Title: "Report"}, Rank: 0.5, Snippet: "<mark>Rep</mark>ort"},
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/tasks/search?q=rep&limit=5", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"title":"Report"`)
+		assert.Contains(t, w.Body.String(), `"rank":0.5`)
+		assert.Contains(t, w.Body.String(), `"snippet":"\u003cmark\u003eRep\u003c/mark\u003eort"`)
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		mockSvc.EXPECT().SearchTasks(gomock.Any(), uint(1), "", service.DefaultSearchLimit).Return(nil, service.ErrEmptyQuery)
+
+		req, _ := http.NewRequest(http.MethodGet, "/tasks/search", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/tasks/search?q=a&limit=500", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestBulkTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockTaskService(ctrl)
+	h := handler.NewTaskHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.POST("/tasks/bulk", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.BulkTasks(c)
+	})
+
+	t.Run("best effort", func(t *testing.T) {
+		mockSvc.EXPECT().BulkApply(gomock.Any(), uint(1), service.BulkBestEffort, []service.BulkOperation{
+			{Op: "set_status", TaskID: 1, Status: "done"},
+			{Op: "delete", TaskID: 2},
+		}).Return([]service.BulkResult{
+			{TaskID: 1, Status: service.BulkResultOK},
+			{TaskID: 2, Status: service.BulkResultFailed, Err: errors.New("pq: connection reset")},
+		}, nil)
+
+		body := `{"mode":"best_effort","operations":[{"op":"set_status","task_id":1,"status":"done"},{"op":"delete","task_id":2}]}`
+		req, _ := http.NewRequest(http.MethodPost, "/tasks/bulk", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `{"task_id":1,"status":"ok"}`)
+		assert.Contains(t, w.Body.String(), `{"task_id":2,"status":"failed","error":"internal error"}`)
+	})
+
+	t.Run("atomic aborted -> 422", func(t *testing.T) {
+		mockSvc.EXPECT().BulkApply(gomock.Any(), uint(1), service.BulkAtomic, gomock.Any()).Return([]service.BulkResult{
+			{TaskID: 3, Status: service.BulkResultFailed, Err: service.ErrPermissionDenied},
+		}, service.ErrBulkAborted)
+
+		req, _ := http.NewRequest(http.MethodPost, "/tasks/bulk", strings.NewReader(`{"operations":[{"op":"delete","task_id":3}]}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), `"error":"permission denied"`)
+	})
+
+	t.Run("empty operations", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/tasks/bulk", strings.NewReader(`{"operations":[]}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestExportTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockTaskService(ctrl)
+	h := handler.NewTaskHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.GET("/tasks/export", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.ExportTasks(c)
+	})
+
+	t.Run("ics", func(t *testing.T) {
+		mockSvc.EXPECT().ExportTasks(gomock.Any(), uint(1), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, fn func(*model.Task) error) error {
+				return fn(&model.Task{ID: 4, Title: "Pay rent", Priority: 2})
+			})
+
+		req, _ := http.NewRequest(http.MethodGet, "/tasks/export?format=ics", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "tasks.ics")
+		assert.Contains(t, w.Body.String(), "SUMMARY:Pay rent\r\n")
+		assert.Contains(t, w.Body.String(), "PRIORITY:2")
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/tasks/export?format=xml", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 }
 
@@ -146,6 +519,7 @@ func TestUpdateTask(t *testing.T) {
 	h := handler.NewTaskHandler(mockSvc)
 
 	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
 	router.PUT("/tasks/:id", func(c *gin.Context) {
 		c.Set(constant.ContextUserIDKey, uint(1))
 		h.UpdateTask(c)
@@ -171,8 +545,64 @@ func TestUpdateTask(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	t.Run("replaces omitted fields", func(t *testing.T) {
+		due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(10)).Return(&model.Task{
+			ID: 10, UserID: 1, Title: "Old", Content: "notes", Status: model.TaskStatusPending, Priority: 2, DueAt: &due,
+		}, nil)
+		mockSvc.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, task *model.Task) error {
+				assert.Equal(t, "New", task.Title)
+				assert.Empty(t, task.Content)
+				assert.Zero(t, task.Priority)
+				assert.Nil(t, task.DueAt)
+				return nil
+			})
+
+		req, _ := http.NewRequest(http.MethodPut, "/tasks/10", strings.NewReader(`{"title":"New","status":"pending"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("missing required fields", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPut, "/tasks/10", strings.NewReader(`{"content":"x"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `{"field":"title","rule":"required","message":"is required"}`)
+		assert.Contains(t, w.Body.String(), `{"field":"status","rule":"required","message":"is required"}`)
+	})
+
+	t.Run("blank title", func(t *testing.T) {
+		// binding 的 required 接受空白，由 service 擋下並回應與 binding 相同的欄位錯誤
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(10)).Return(&model.Task{ID: 10, UserID: 1, Title: "Old"}, nil)
+		mockSvc.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(service.ErrTitleRequired)
+
+		req, _ := http.NewRequest(http.MethodPut, "/tasks/10", strings.NewReader(`{"title":"   ","status":"pending"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"validation_failed"`)
+		assert.Contains(t, w.Body.String(), `{"field":"title","rule":"required","message":"is required"}`)
+	})
+
 	t.Run("invalid status", func(t *testing.T) {
-		body := `{"status":"weird"}`
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(10)).Return(&model.Task{
+			ID:     10,
+			UserID: 1,
+			Title:  "Old",
+			Status: model.TaskStatusPending,
+		}, nil)
+		mockSvc.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(service.ErrInvalidStatus)
+
+		body := `{"title":"Old","status":"weird"}`
 		req, _ := http.NewRequest(http.MethodPut, "/tasks/10", strings.NewReader(body))
 		req.Header.Set("Content-Type", "application/json")
 
@@ -181,10 +611,28 @@ func TestUpdateTask(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
+	t.Run("transition not allowed", func(t *testing.T) {
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(10)).Return(&model.Task{
+			ID:     10,
+			UserID: 1,
+			Title:  "Old",
+			Status: "todo",
+		}, nil)
+		mockSvc.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(service.ErrInvalidTransition)
+
+		body := `{"title":"Old","status":"done"}`
+		req, _ := http.NewRequest(http.MethodPut, "/tasks/10", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
 	t.Run("not found", func(t *testing.T) {
 		mockSvc.EXPECT().GetTask(gomock.Any(), uint(999)).Return(nil, nil)
 
-		req, _ := http.NewRequest(http.MethodPut, "/tasks/999", strings.NewReader(`{}`))
+		req, _ := http.NewRequest(http.MethodPut, "/tasks/999", strings.NewReader(`{"title":"abc","status":"pending"}`))
 		req.Header.Set("Content-Type", "application/json")
 
 		w := httptest.NewRecorder()
@@ -199,7 +647,7 @@ func TestUpdateTask(t *testing.T) {
 			Title:  "X",
 		}, nil)
 
-		req, _ := http.NewRequest(http.MethodPut, "/tasks/456", strings.NewReader(`{"title":"abc"}`))
+		req, _ := http.NewRequest(http.MethodPut, "/tasks/456", strings.NewReader(`{"title":"abc","status":"pending"}`))
 		req.Header.Set("Content-Type", "application/json")
 
 		w := httptest.NewRecorder()
@@ -208,6 +656,119 @@ func TestUpdateTask(t *testing.T) {
 	})
 }
 
+func TestPatchTask(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockTaskService(ctrl)
+	h := handler.NewTaskHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.PATCH("/tasks/:id", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.PatchTask(c)
+	})
+
+	due := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+	current := func() *model.Task {
+		return &model.Task{ID: 10, UserID: 1, Title: "Old", Content: "notes", Status: model.TaskStatusPending, Priority: 3, DueAt: &due}
+	}
+	patch := func(contentType, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPatch, "/tasks/10", strings.NewReader(body))
+		req.Header.Set(constant.HeaderContentType, contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("merge patch clears a field", func(t *testing.T) {
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(10)).Return(current(), nil)
+		mockSvc.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, task *model.Task) error {
+				assert.Equal(t, "New", task.Title)
+				assert.Equal(t, "notes", task.Content)
+				assert.Equal(t, 3, task.Priority)
+				assert.Nil(t, task.DueAt)
+				return nil
+			})
+
+		w := patch("application/merge-patch+json", `{"title":"New","due_at":null}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "due_at")
+	})
+
+	t.Run("json patch", func(t *testing.T) {
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(10)).Return(current(), nil)
+		mockSvc.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, task *model.Task) error {
+				assert.Equal(t, model.TaskStatusDone, task.Status)
+				assert.Empty(t, task.Content)
+				assert.Equal(t, "Old", task.Title)
+				return nil
+			})
+
+		w := patch("application/json-patch+json", `[
+			{"op":"test","path":"/status","value":"pending"},
+			{"op":"replace","path":"/status","value":"done"},
+			{"op":"remove","path":"/content"}
+		]`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("failed test op", func(t *testing.T) {
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(10)).Return(current(), nil)
+
+		w := patch("application/json-patch+json", `[{"op":"test","path":"/status","value":"done"}]`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"patch_conflict"`)
+	})
+
+	t.Run("malformed patch", func(t *testing.T) {
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(10)).Return(current(), nil)
+
+		w := patch("application/json-patch+json", `{"op":"replace"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"invalid_patch"`)
+	})
+
+	t.Run("patched task is validated", func(t *testing.T) {
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(10)).Return(current(), nil)
+		w := patch("application/merge-patch+json", `{"title":null}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `{"field":"title","rule":"required","message":"is required"}`)
+
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(10)).Return(current(), nil)
+		w = patch("application/json-patch+json", `[{"op":"add","path":"/id","value":99}]`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `{"field":"id","rule":"unknown","message":"is not a task field"}`)
+
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(10)).Return(current(), nil)
+		w = patch("application/merge-patch+json", `{"priority":"high"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"priority","rule":"type"`)
+
+		mockSvc.EXPECT().GetTask(gomock.Any(), uint(10)).Return(current(), nil)
+		mockSvc.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(service.ErrTitleRequired)
+		w = patch("application/merge-patch+json", `{"title":" "}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `{"field":"title","rule":"required","message":"is required"}`)
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		w := patch("application/json", `{"title":"New"}`)
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+}
+
+// deleteTask 直接呼叫 handler，再由 ErrorHandler 寫出以 c.Error 回報的錯誤。
+func deleteTask(h *handler.TaskHandler, c *gin.Context) {
+	h.DeleteTask(c)
+	middleware.ErrorHandler()(c)
+}
+
 func TestDeleteTask(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -224,7 +785,7 @@ func TestDeleteTask(t *testing.T) {
 		c.Set(constant.ContextUserIDKey, uint(1))
 
 		h := handler.NewTaskHandler(mockSvc)
-		h.DeleteTask(c)
+		deleteTask(h, c)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
@@ -241,7 +802,7 @@ func TestDeleteTask(t *testing.T) {
 		c.Params = gin.Params{{Key: "id", Value: "1"}}
 
 		h := handler.NewTaskHandler(mockSvc)
-		h.DeleteTask(c)
+		deleteTask(h, c)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
@@ -263,7 +824,7 @@ func TestDeleteTask(t *testing.T) {
 			Return(service.ErrTaskNotFound)
 
 		h := handler.NewTaskHandler(mockSvc)
-		h.DeleteTask(c)
+		deleteTask(h, c)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
@@ -285,7 +846,7 @@ func TestDeleteTask(t *testing.T) {
 			Return(service.ErrPermissionDenied)
 
 		h := handler.NewTaskHandler(mockSvc)
-		h.DeleteTask(c)
+		deleteTask(h, c)
 
 		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
@@ -307,7 +868,7 @@ func TestDeleteTask(t *testing.T) {
 			Return(errors.New("DB error"))
 
 		h := handler.NewTaskHandler(mockSvc)
-		h.DeleteTask(c)
+		deleteTask(h, c)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
@@ -329,7 +890,7 @@ func TestDeleteTask(t *testing.T) {
 			Return(nil)
 
 		h := handler.NewTaskHandler(mockSvc)
-		h.DeleteTask(c)
+		deleteTask(h, c)
 
 		assert.Equal(t, http.StatusNoContent, w.Code)
 		assert.Equal(t, "", w.Body.String())
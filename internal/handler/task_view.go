@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+)
+
+// 可以用 ?include= 嵌入任務回應的關聯。
+const (
+	includeTags     = "tags"
+	includeComments = "comments"
+	includeProject  = "project"
+)
+
+type TagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// taskFieldNames 是 ?fields= 可選的欄位，即 TaskResponse 除了關聯以外的 JSON 欄位。
+var taskFieldNames = func() map[string]bool {
+	names := map[string]bool{}
+	t := reflect.TypeOf(TaskResponse{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		names[name] = true
+	}
+	for _, rel := range []string{includeTags, includeComments, includeProject} {
+		delete(names, rel)
+	}
+	return names
+}()
+
+// taskFields 是不會依 view 裁剪的 TaskResponse，供其他回應嵌入；
+// 直接嵌入 TaskResponse 會連同 MarshalJSON 一起提升，蓋掉外層的欄位。
+type taskFields TaskResponse
+
+// taskView 是請求指定的回應形狀：fields 為 nil 表示全部欄位，includes 為要嵌入的關聯。
+type taskView struct {
+	fields   map[string]bool
+	includes map[string]bool
+}
+
+// MarshalJSON 依 view 裁剪欄位；有指定 include 的關聯即使為空也會輸出（[] 或 null）。
+func (r TaskResponse) MarshalJSON() ([]byte, error) {
+	if r.view == nil {
+		return json.Marshal(taskFields(r))
+	}
+
+	b, err := json.Marshal(taskFields(r))
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, err
+	}
+	for rel := range r.view.includes {
+		if _, ok := obj[rel]; !ok {
+			if rel == includeProject {
+				obj[rel] = json.RawMessage("null")
+			} else {
+				obj[rel] = json.RawMessage("[]")
+			}
+		}
+	}
+	if r.view.fields != nil {
+		for name := range obj {
+			if !r.view.fields[name] && !r.view.includes[name] {
+				delete(obj, name)
+			}
+		}
+	}
+	return json.Marshal(obj)
+}
+
+// parseTaskView 解析 ?fields=id,title 與 ?include=tags,comments；未知的欄位或關聯回傳 400。
+// 兩者都沒指定時回傳 nil，回應維持完整的 TaskResponse。
+func (h *TaskHandler) parseTaskView(c *gin.Context) (*taskView, error) {
+	fields, include := c.Query("fields"), c.Query("include")
+	if fields == "" && include == "" {
+		return nil, nil
+	}
+
+	v := &taskView{includes: map[string]bool{}}
+	var errs []problem.FieldError
+	if fields != "" {
+		v.fields = map[string]bool{}
+		for _, name := range strings.Split(fields, ",") {
+			name = strings.TrimSpace(name)
+			if !taskFieldNames[name] {
+				errs = append(errs, problem.FieldError{Field: "fields", Rule: "unknown", Message: fmt.Sprintf("%q is not a task field", name)})
+				continue
+			}
+			v.fields[name] = true
+		}
+	}
+	if include != "" {
+		for _, rel := range strings.Split(include, ",") {
+			rel = strings.TrimSpace(rel)
+			if !h.canInclude(rel) {
+				errs = append(errs, problem.FieldError{Field: "include", Rule: "unknown", Message: fmt.Sprintf("%q cannot be included", rel)})
+				continue
+			}
+			v.includes[rel] = true
+		}
+	}
+	if len(errs) > 0 {
+		p := problem.New(http.StatusBadRequest, problem.CodeValidationFailed, "request validation failed")
+		p.Errors = errs
+		return nil, p
+	}
+	return v, nil
+}
+
+func (h *TaskHandler) canInclude(rel string) bool {
+	switch rel {
+	case includeTags:
+		return true
+	case includeComments:
+		return h.commentService != nil
+	case includeProject:
+		return h.projectService != nil
+	}
+	return false
+}
+
+// taskResponses 轉換任務並依 view 嵌入關聯；每種關聯只查一次，不會對每筆任務各查一次。
+func (h *TaskHandler) taskResponses(ctx context.Context, userID uint, tasks []*model.Task, v *taskView) ([]TaskResponse, error) {
+	out := make([]TaskResponse, 0, len(tasks))
+	for _, t := range tasks {
+		r := newTaskResponse(t)
+		r.view = v
+		out = append(out, r)
+	}
+	if v == nil || len(v.includes) == 0 || len(tasks) == 0 {
+		return out, nil
+	}
+
+	ids := make([]uint, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+
+	if v.includes[includeTags] {
+		tags, err := h.taskService.ListTaskTags(ctx, userID, ids)
+		if err != nil {
+			return nil, err
+		}
+		for i := range out {
+			for _, tag := range tags[out[i].ID] {
+				out[i].Tags = append(out[i].Tags, TagResponse{ID: tag.ID, Name: tag.Name})
+			}
+		}
+	}
+
+	if v.includes[includeComments] {
+		comments, err := h.commentService.ListCommentsByTasks(ctx, userID, ids)
+		if err != nil {
+			return nil, err
+		}
+		for i := range out {
+			for _, cm := range comments[out[i].ID] {
+				out[i].Comments = append(out[i].Comments, newCommentResponse(cm))
+			}
+		}
+	}
+
+	if v.includes[includeProject] {
+		var projectIDs []uint
+		seen := map[uint]bool{}
+		for _, t := range tasks {
+			if t.ProjectID != nil && !seen[*t.ProjectID] {
+				seen[*t.ProjectID] = true
+				projectIDs = append(projectIDs, *t.ProjectID)
+			}
+		}
+		if len(projectIDs) > 0 {
+			projects, err := h.projectService.GetProjects(ctx, userID, projectIDs)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uint]ProjectResponse, len(projects))
+			for _, p := range projects {
+				byID[p.ID] = newProjectResponse(p)
+			}
+			for i := range out {
+				if out[i].ProjectID == nil {
+					continue
+				}
+				if p, ok := byID[*out[i].ProjectID]; ok {
+					out[i].Project = &p
+				}
+			}
+		}
+	}
+	return out, nil
+}
@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/SoliMark/gotasker-pro/internal/constant"
 	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
 	"github.com/SoliMark/gotasker-pro/internal/service"
 )
 
@@ -37,10 +39,6 @@ type LoginResponse struct {
 	Token string `json:"token"`
 }
 
-type ErrorResponse struct {
-	Error string `json:"error"`
-}
-
 func NewUserHandler(userService service.UserService) *UserHandler {
 	return &UserHandler{
 		UserService: userService,
@@ -50,9 +48,7 @@ func NewUserHandler(userService service.UserService) *UserHandler {
 func (h *UserHandler) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request:" + err.Error(),
-		})
+		_ = c.Error(problem.FromBinding(err))
 		return
 	}
 
@@ -63,17 +59,14 @@ func (h *UserHandler) Register(c *gin.Context) {
 	}
 
 	if err := h.UserService.CreateUser(c.Request.Context(), user); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: err.Error(),
-		})
+		_ = c.Error(err)
 		return
 	}
 
 	token, err := h.UserService.AuthenticateUser(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "Failed to generate token" + err.Error(),
-		})
+		_ = c.Error(err)
+		return
 	}
 
 	c.JSON(http.StatusOK, LoginResponse{
@@ -84,17 +77,18 @@ func (h *UserHandler) Register(c *gin.Context) {
 func (h *UserHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request: " + err.Error(),
-		})
+		_ = c.Error(problem.FromBinding(err))
 		return
 	}
 
 	token, err := h.UserService.AuthenticateUser(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error: err.Error(),
-		})
+		// 帳號不存在與密碼錯誤回應相同，避免洩漏哪些 email 已註冊
+		if errors.Is(err, service.ErrUserNotFound) {
+			err = service.ErrInvalidCredential
+		}
+		_ = c.Error(err)
+		return
 	}
 
 	c.JSON(http.StatusOK, LoginResponse{
@@ -105,9 +99,7 @@ func (h *UserHandler) Login(c *gin.Context) {
 func (h *UserHandler) Profile(c *gin.Context) {
 	userID, exists := c.Get(constant.ContextUserIDKey)
 	if !exists {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "user_id not found in context",
-		})
+		_ = c.Error(problem.Unauthorized("unauthorized"))
 		return
 	}
 
@@ -50,6 +50,7 @@ func TestUserHandler_Register_Success(t *testing.T) {
 		Return("mocked.jwt.token", nil)
 
 	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
 	router.POST("/register", userHandler.Register)
 
 	req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonBody))
@@ -73,6 +74,7 @@ func TestUserHandler_Login(t *testing.T) {
 	}
 
 	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
 	router.POST("/login", userHandler.Login)
 
 	// --- success ---
@@ -116,6 +118,32 @@ func TestUserHandler_Login(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, resp.Code)
 	assert.Contains(t, resp.Body.String(), service.ErrInvalidCredential.Error())
 
+	// --- unknown email looks the same as a wrong password ---
+	mockSvc.EXPECT().
+		AuthenticateUser(gomock.Any(), "nobody@example.com", "password123").
+		Return("", service.ErrUserNotFound)
+
+	body, _ = json.Marshal(handler.LoginRequest{Email: "nobody@example.com", Password: "password123"})
+	req, _ = http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(body))
+	req.Header.Set(constant.HeaderContentType, constant.ContentTypeJSON)
+	resp = httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"code":"invalid_credentials"`)
+
+	// --- validation failure lists fields ---
+	req, _ = http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer([]byte(`{"email":"not-an-email"}`)))
+	req.Header.Set(constant.HeaderContentType, constant.ContentTypeJSON)
+	resp = httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), `{"field":"email","rule":"email","message":"must be a valid email address"}`)
+	assert.Contains(t, resp.Body.String(), `{"field":"password","rule":"required","message":"is required"}`)
+
 	// --- invalid payload ---
 	req, _ = http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer([]byte(`invalid`)))
 	req.Header.Set("Content-Type", "application/json")
@@ -139,6 +167,7 @@ func TestUserHandler_Profile(t *testing.T) {
 	userHandler := &handler.UserHandler{}
 
 	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
 	router.GET("/profile",
 		middleware.JWTAuthMiddleware(jwtMaker),
 		userHandler.Profile,
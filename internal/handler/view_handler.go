@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/util"
+)
+
+type ViewHandler struct {
+	viewService service.ViewService
+}
+
+func NewViewHandler(viewService service.ViewService) *ViewHandler {
+	return &ViewHandler{viewService: viewService}
+}
+
+// ViewFilterDTO 對應列表的篩選參數（status、created_after ... title_prefix）。
+type ViewFilterDTO struct {
+	Status        []string   `json:"status,omitempty"`
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+	UpdatedAfter  *time.Time `json:"updated_after,omitempty"`
+	UpdatedBefore *time.Time `json:"updated_before,omitempty"`
+	TitlePrefix   string     `json:"title_prefix,omitempty"`
+}
+
+type ViewRequest struct {
+	Name   string        `json:"name" binding:"required"`
+	Filter ViewFilterDTO `json:"filter"`
+	Sort   string        `json:"sort"`
+}
+
+type ViewResponse struct {
+	ID     uint          `json:"id"`
+	Name   string        `json:"name"`
+	Filter ViewFilterDTO `json:"filter"`
+	Sort   string        `json:"sort"`
+}
+
+func (r ViewRequest) input() service.ViewInput {
+	return service.ViewInput{
+		Name: r.Name,
+		Filter: service.TaskFilter{
+			Statuses:      r.Filter.Status,
+			CreatedAfter:  r.Filter.CreatedAfter,
+			CreatedBefore: r.Filter.CreatedBefore,
+			UpdatedAfter:  r.Filter.UpdatedAfter,
+			UpdatedBefore: r.Filter.UpdatedBefore,
+			TitlePrefix:   r.Filter.TitlePrefix,
+		},
+		Sort: r.Sort,
+	}
+}
+
+func newViewResponse(v *model.SavedView) (ViewResponse, error) {
+	f, err := service.ViewFilter(v)
+	if err != nil {
+		return ViewResponse{}, err
+	}
+	return ViewResponse{
+		ID:   v.ID,
+		Name: v.Name,
+		Filter: ViewFilterDTO{
+			Status:        f.Statuses,
+			CreatedAfter:  f.CreatedAfter,
+			CreatedBefore: f.CreatedBefore,
+			UpdatedAfter:  f.UpdatedAfter,
+			UpdatedBefore: f.UpdatedBefore,
+			TitlePrefix:   f.TitlePrefix,
+		},
+		Sort: v.Sort,
+	}, nil
+}
+
+func (h *ViewHandler) CreateView(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req ViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(problem.FromBinding(err))
+		return
+	}
+
+	view, err := h.viewService.CreateView(c.Request.Context(), userID.(uint), req.input())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.respondView(c, http.StatusCreated, view)
+}
+
+func (h *ViewHandler) ListViews(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	views, err := h.viewService.ListViews(c.Request.Context(), userID.(uint))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res := make([]ViewResponse, 0, len(views))
+	for _, v := range views {
+		item, err := newViewResponse(v)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		res = append(res, item)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *ViewHandler) GetView(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var viewID uint
+	if err := util.ParseUintParam(c, "id", &viewID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid view ID"))
+		return
+	}
+
+	view, err := h.viewService.GetView(c.Request.Context(), userID.(uint), viewID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.respondView(c, http.StatusOK, view)
+}
+
+func (h *ViewHandler) UpdateView(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var viewID uint
+	if err := util.ParseUintParam(c, "id", &viewID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid view ID"))
+		return
+	}
+
+	var req ViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(problem.FromBinding(err))
+		return
+	}
+
+	view, err := h.viewService.UpdateView(c.Request.Context(), userID.(uint), viewID, req.input())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.respondView(c, http.StatusOK, view)
+}
+
+func (h *ViewHandler) DeleteView(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var viewID uint
+	if err := util.ParseUintParam(c, "id", &viewID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid view ID"))
+		return
+	}
+
+	if err := h.viewService.DeleteView(c.Request.Context(), userID.(uint), viewID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+// ListViewTasks 執行 view，回應格式與 GET /v1/tasks 相同。
+func (h *ViewHandler) ListViewTasks(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var viewID uint
+	if err := util.ParseUintParam(c, "id", &viewID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid view ID"))
+		return
+	}
+
+	limit := service.DefaultPageLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > service.MaxPageLimit {
+			_ = c.Error(problem.BadRequest("invalid limit"))
+			return
+		}
+		limit = n
+	}
+
+	page, err := h.viewService.RunView(c.Request.Context(), userID.(uint), viewID, limit, c.Query("cursor"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res := TaskListResponse{
+		Items:      make([]TaskResponse, 0, len(page.Tasks)),
+		Limit:      limit,
+		NextCursor: page.NextCursor,
+	}
+	for _, t := range page.Tasks {
+		res.Items = append(res.Items, newTaskResponse(t))
+	}
+
+	c.Header(constant.HeaderTotalCount, strconv.FormatInt(page.Total, 10))
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *ViewHandler) respondView(c *gin.Context, status int, view *model.SavedView) {
+	res, err := newViewResponse(view)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(status, res)
+}
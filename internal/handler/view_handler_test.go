@@ -0,0 +1,102 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
+)
+
+func TestCreateView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockViewService(ctrl)
+	h := handler.NewViewHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.POST("/views", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.CreateView(c)
+	})
+
+	t.Run("created", func(t *testing.T) {
+		mockSvc.EXPECT().CreateView(gomock.Any(), uint(1), service.ViewInput{
+			Name:   "Done",
+			Filter: service.TaskFilter{Statuses: []string{"done"}},
+			Sort:   "-updated_at",
+		}).Return(&model.SavedView{ID: 3, UserID: 1, Name: "Done", Filter: `{"Statuses":["done"]}`, Sort: "-updated_at,-id"}, nil)
+
+		body := `{"name":"Done","filter":{"status":["done"]},"sort":"-updated_at"}`
+		req, _ := http.NewRequest(http.MethodPost, "/views", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"filter":{"status":["done"]}`)
+		assert.Contains(t, w.Body.String(), `"sort":"-updated_at,-id"`)
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		mockSvc.EXPECT().CreateView(gomock.Any(), uint(1), gomock.Any()).Return(nil, service.ErrInvalidSort)
+
+		req, _ := http.NewRequest(http.MethodPost, "/views", strings.NewReader(`{"name":"x","sort":"password"}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestListViewTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockViewService(ctrl)
+	h := handler.NewViewHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.GET("/views/:id/tasks", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.ListViewTasks(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockSvc.EXPECT().RunView(gomock.Any(), uint(1), uint(3), 20, "abc").Return(&service.TaskPage{
+			Tasks:      []*model.Task{{ID: 5, UserID: 1, Title: "T"}},
+			NextCursor: "def",
+			Total:      9,
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/views/3/tasks?limit=20&cursor=abc", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "9", w.Header().Get(constant.HeaderTotalCount))
+		assert.Contains(t, w.Body.String(), `"next_cursor":"def"`)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockSvc.EXPECT().RunView(gomock.Any(), uint(1), uint(4), service.DefaultPageLimit, "").Return(nil, service.ErrViewNotFound)
+
+		req, _ := http.NewRequest(http.MethodGet, "/views/4/tasks", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
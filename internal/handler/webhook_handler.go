@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/util"
+)
+
+type WebhookHandler struct {
+	webhookService service.WebhookService
+}
+
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events" binding:"required"`
+	Secret string   `json:"secret"`
+}
+
+// WebhookResponse 只在建立時帶出 secret，之後的查詢不再回傳。
+type WebhookResponse struct {
+	ID        uint      `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newWebhookResponse(h *model.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:        h.ID,
+		URL:       h.URL,
+		Events:    strings.Split(h.Events, ","),
+		CreatedAt: h.CreatedAt,
+	}
+}
+
+type DeliveryResponse struct {
+	ID            uint            `json:"id"`
+	WebhookID     uint            `json:"webhook_id"`
+	Event         string          `json:"event"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	ResponseCode  int             `json:"response_code,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+func newDeliveryResponse(d *model.WebhookDelivery) DeliveryResponse {
+	res := DeliveryResponse{
+		ID:           d.ID,
+		WebhookID:    d.WebhookID,
+		Event:        d.Event,
+		Status:       d.Status,
+		Attempts:     d.Attempts,
+		ResponseCode: d.ResponseCode,
+		Error:        d.Error,
+		Payload:      json.RawMessage(d.Payload),
+		CreatedAt:    d.CreatedAt,
+		UpdatedAt:    d.UpdatedAt,
+	}
+	if d.Status == model.DeliveryPending {
+		next := d.NextAttemptAt
+		res.NextAttemptAt = &next
+	}
+	return res
+}
+
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(problem.FromBinding(err))
+		return
+	}
+
+	hook, err := h.webhookService.CreateWebhook(c.Request.Context(), userID.(uint), service.WebhookInput{
+		URL:    req.URL,
+		Events: req.Events,
+		Secret: req.Secret,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res := newWebhookResponse(hook)
+	res.Secret = hook.Secret
+	c.JSON(http.StatusCreated, res)
+}
+
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	hooks, err := h.webhookService.ListWebhooks(c.Request.Context(), userID.(uint))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res := make([]WebhookResponse, 0, len(hooks))
+	for _, hook := range hooks {
+		res = append(res, newWebhookResponse(hook))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var webhookID uint
+	if err := util.ParseUintParam(c, "id", &webhookID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid webhook ID"))
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), userID.(uint), webhookID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var webhookID uint
+	if err := util.ParseUintParam(c, "id", &webhookID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid webhook ID"))
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), userID.(uint), webhookID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res := make([]DeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		res = append(res, newDeliveryResponse(d))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// Redeliver 以原本的 payload 重新排入投遞，回傳 202 與新的投遞紀錄。
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var webhookID, deliveryID uint
+	if err := util.ParseUintParam(c, "id", &webhookID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid webhook ID"))
+		return
+	}
+	if err := util.ParseUintParam(c, "delivery_id", &deliveryID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid delivery ID"))
+		return
+	}
+
+	d, err := h.webhookService.Redeliver(c.Request.Context(), userID.(uint), webhookID, deliveryID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, newDeliveryResponse(d))
+}
+
+// Ping 排入一個 ping 事件，回傳 202；結果可在投遞紀錄中查看。
+func (h *WebhookHandler) Ping(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var webhookID uint
+	if err := util.ParseUintParam(c, "id", &webhookID); err != nil {
+		_ = c.Error(problem.BadRequest("invalid webhook ID"))
+		return
+	}
+
+	d, err := h.webhookService.Ping(c.Request.Context(), userID.(uint), webhookID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, newDeliveryResponse(d))
+}
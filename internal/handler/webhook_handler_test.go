@@ -0,0 +1,110 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
+)
+
+func setupWebhookRouter(h *handler.WebhookHandler) *gin.Engine {
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.Use(func(c *gin.Context) { c.Set(constant.ContextUserIDKey, uint(1)) })
+	router.POST("/webhooks", h.CreateWebhook)
+	router.GET("/webhooks", h.ListWebhooks)
+	router.POST("/webhooks/:id/ping", h.Ping)
+	router.GET("/webhooks/:id/deliveries", h.ListDeliveries)
+	router.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", h.Redeliver)
+	return router
+}
+
+func TestCreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockWebhookService(ctrl)
+	router := setupWebhookRouter(handler.NewWebhookHandler(mockSvc))
+
+	t.Run("created returns the secret once", func(t *testing.T) {
+		mockSvc.EXPECT().CreateWebhook(gomock.Any(), uint(1), service.WebhookInput{
+			URL:    "https://example.com/hook",
+			Events: []string{"task.created"},
+		}).Return(&model.Webhook{ID: 3, URL: "https://example.com/hook", Events: "task.created", Secret: "whsec_x"}, nil)
+		mockSvc.EXPECT().ListWebhooks(gomock.Any(), uint(1)).
+			Return([]*model.Webhook{{ID: 3, URL: "https://example.com/hook", Events: "task.created", Secret: "whsec_x"}}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(`{"url":"https://example.com/hook","events":["task.created"]}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"secret":"whsec_x"`)
+
+		req, _ = http.NewRequest(http.MethodGet, "/webhooks", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "whsec_x")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		mockSvc.EXPECT().CreateWebhook(gomock.Any(), uint(1), gomock.Any()).Return(nil, service.ErrInvalidWebhook)
+
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(`{"url":"ftp://x","events":["task.created"]}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockWebhookService(ctrl)
+	router := setupWebhookRouter(handler.NewWebhookHandler(mockSvc))
+
+	t.Run("ping", func(t *testing.T) {
+		mockSvc.EXPECT().Ping(gomock.Any(), uint(1), uint(3)).
+			Return(&model.WebhookDelivery{ID: 8, WebhookID: 3, Event: service.EventPing, Payload: `{"event":"ping"}`, Status: model.DeliveryPending}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks/3/ping", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), `"payload":{"event":"ping"}`)
+	})
+
+	t.Run("log", func(t *testing.T) {
+		mockSvc.EXPECT().ListDeliveries(gomock.Any(), uint(1), uint(3)).Return([]*model.WebhookDelivery{
+			{ID: 8, WebhookID: 3, Event: "task.created", Payload: `{}`, Status: model.DeliveryFailed, Attempts: 8, ResponseCode: 500, Error: "unexpected status 500"},
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/webhooks/3/deliveries", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"response_code":500`)
+		assert.NotContains(t, w.Body.String(), "next_attempt_at")
+	})
+
+	t.Run("redeliver unknown delivery", func(t *testing.T) {
+		mockSvc.EXPECT().Redeliver(gomock.Any(), uint(1), uint(3), uint(9)).Return(nil, service.ErrDeliveryNotFound)
+
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks/3/deliveries/9/redeliver", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+type WorkflowHandler struct {
+	workflowService service.WorkflowService
+}
+
+func NewWorkflowHandler(workflowService service.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{workflowService: workflowService}
+}
+
+type WorkflowStateDTO struct {
+	Name      string `json:"name" binding:"required"`
+	Completes bool   `json:"completes"`
+}
+
+type WorkflowTransitionDTO struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required"`
+}
+
+type WorkflowRequest struct {
+	Name          string                  `json:"name" binding:"required"`
+	InitialStatus string                  `json:"initial_status" binding:"required"`
+	States        []WorkflowStateDTO      `json:"states" binding:"required,min=1,dive"`
+	Transitions   []WorkflowTransitionDTO `json:"transitions" binding:"dive"`
+}
+
+type WorkflowResponse struct {
+	Name          string                  `json:"name"`
+	InitialStatus string                  `json:"initial_status"`
+	States        []WorkflowStateDTO      `json:"states"`
+	Transitions   []WorkflowTransitionDTO `json:"transitions"`
+}
+
+func newWorkflowResponse(wf *model.Workflow) WorkflowResponse {
+	res := WorkflowResponse{
+		Name:          wf.Name,
+		InitialStatus: wf.InitialStatus,
+		States:        make([]WorkflowStateDTO, 0, len(wf.States)),
+		Transitions:   make([]WorkflowTransitionDTO, 0, len(wf.Transitions)),
+	}
+	for _, st := range wf.States {
+		res.States = append(res.States, WorkflowStateDTO{Name: st.Name, Completes: st.Completes})
+	}
+	for _, t := range wf.Transitions {
+		res.Transitions = append(res.Transitions, WorkflowTransitionDTO{From: t.FromStatus, To: t.ToStatus})
+	}
+	return res
+}
+
+func (h *WorkflowHandler) GetWorkflow(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	wf, err := h.workflowService.GetWorkflow(c.Request.Context(), userID.(uint))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newWorkflowResponse(wf))
+}
+
+func (h *WorkflowHandler) SaveWorkflow(c *gin.Context) {
+	userID, exists := c.Get(constant.ContextUserIDKey)
+	if !exists {
+		_ = c.Error(problem.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req WorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(problem.FromBinding(err))
+		return
+	}
+
+	wf := &model.Workflow{
+		UserID:        userID.(uint),
+		Name:          req.Name,
+		InitialStatus: req.InitialStatus,
+	}
+	for _, st := range req.States {
+		wf.States = append(wf.States, model.WorkflowState{Name: st.Name, Completes: st.Completes})
+	}
+	for _, t := range req.Transitions {
+		wf.Transitions = append(wf.Transitions, model.WorkflowTransition{FromStatus: t.From, ToStatus: t.To})
+	}
+
+	if err := h.workflowService.SaveWorkflow(c.Request.Context(), wf); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newWorkflowResponse(wf))
+}
@@ -0,0 +1,104 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
+)
+
+func TestGetWorkflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockWorkflowService(ctrl)
+	h := handler.NewWorkflowHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.GET("/workflow", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.GetWorkflow(c)
+	})
+
+	mockSvc.EXPECT().GetWorkflow(gomock.Any(), uint(1)).Return(model.DefaultWorkflow(), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/workflow", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"initial_status":"pending"`)
+}
+
+func TestSaveWorkflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mock_service.NewMockWorkflowService(ctrl)
+	h := handler.NewWorkflowHandler(mockSvc)
+
+	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
+	router.PUT("/workflow", func(c *gin.Context) {
+		c.Set(constant.ContextUserIDKey, uint(1))
+		h.SaveWorkflow(c)
+	})
+
+	body := `{
+		"name": "kanban",
+		"initial_status": "todo",
+		"states": [{"name":"todo"},{"name":"done","completes":true}],
+		"transitions": [{"from":"todo","to":"done"}]
+	}`
+
+	t.Run("success", func(t *testing.T) {
+		mockSvc.EXPECT().SaveWorkflow(gomock.Any(), gomock.AssignableToTypeOf(&model.Workflow{})).
+			DoAndReturn(func(_ interface{}, wf *model.Workflow) error {
+				assert.Equal(t, uint(1), wf.UserID)
+				assert.Len(t, wf.States, 2)
+				assert.Equal(t, "todo", wf.Transitions[0].FromStatus)
+				return nil
+			})
+
+		req, _ := http.NewRequest(http.MethodPut, "/workflow", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid workflow", func(t *testing.T) {
+		mockSvc.EXPECT().SaveWorkflow(gomock.Any(), gomock.Any()).
+			Return(service.ErrInvalidWorkflow)
+
+		req, _ := http.NewRequest(http.MethodPut, "/workflow", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("missing states", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPut, "/workflow",
+			strings.NewReader(`{"name":"x","initial_status":"todo","states":[]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,94 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// csvColumns 把常見的欄位名稱對應到任務欄位（不分大小寫）。
+// id、created_at、project_id 等欄位會被忽略，匯入一律建立新任務。
+var csvColumns = map[string]string{
+	"title":        "title",
+	"name":         "title",
+	"summary":      "title",
+	"task":         "title",
+	"content":      "content",
+	"description":  "content",
+	"desc":         "content",
+	"notes":        "content",
+	"status":       "status",
+	"priority":     "priority",
+	"due_at":       "due_at",
+	"due":          "due_at",
+	"due_date":     "due_at",
+	"deadline":     "due_at",
+	"completed":    "completed",
+	"completed_at": "completed",
+}
+
+func parseCSV(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		if field, ok := csvColumns[name]; ok {
+			if _, dup := cols[field]; !dup {
+				cols[field] = i
+			}
+		}
+	}
+	if _, ok := cols["title"]; !ok {
+		return nil, ErrMissingTitle
+	}
+
+	var rows []Row
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		row := Row{Line: line}
+		if err != nil {
+			var perr *csv.ParseError
+			if !errors.As(err, &perr) {
+				return nil, err
+			}
+			row.Err = err
+			rows = append(rows, row)
+			continue
+		}
+		get := func(field string) string {
+			i, ok := cols[field]
+			if !ok || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+
+		row.Task.Title = get("title")
+		row.Task.Content = get("content")
+		row.Task.Status = get("status")
+		if v := get("priority"); v != "" {
+			if row.Task.Priority, err = strconv.Atoi(v); err != nil {
+				row.Err = errors.New("invalid priority: " + v)
+			}
+		}
+		if row.Task.DueAt, err = parseTime(get("due_at")); err != nil && row.Err == nil {
+			row.Err = err
+		}
+		if v := strings.ToLower(get("completed")); v != "" && v != "false" && v != "0" && v != "no" {
+			row.Completed = true
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
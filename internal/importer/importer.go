@@ -0,0 +1,84 @@
+// Package importer 將 CSV、JSON 以及第三方（Todoist、Trello）匯出檔解析成任務列。
+// 這裡只做格式層面的解析；標題、優先度、狀態等商業規則由 service 檢查。
+package importer
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown import format")
+	ErrMissingTitle  = errors.New("missing title column")
+)
+
+const (
+	FormatCSV     = "csv"
+	FormatJSON    = "json"
+	FormatTodoist = "todoist"
+	FormatTrello  = "trello"
+)
+
+// Row 是解析後的一列；Line 為來源中的列號（CSV 含標題列，JSON 為陣列中的第幾個元素，從 1 起算）。
+// Completed 表示來源標記為已完成但沒有對應的狀態名稱，由 service 對應到流程中的完成狀態。
+// Err 不為 nil 時表示該列無法解析。
+type Row struct {
+	Line      int
+	Task      model.Task
+	Completed bool
+	Err       error
+}
+
+// Parse 依格式解析整份檔案。
+func Parse(format string, r io.Reader) ([]Row, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r)
+	case FormatJSON:
+		return parseJSON(r)
+	case FormatTodoist:
+		return parseTodoist(r)
+	case FormatTrello:
+		return parseTrello(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// DetectFormat 依副檔名猜測格式，無法判斷時回傳空字串。
+func DetectFormat(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV
+	case ".json":
+		return FormatJSON
+	}
+	return ""
+}
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseTime 接受 RFC3339 與常見的日期格式；沒有時區的值視為 UTC。
+func parseTime(s string) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, errors.New("invalid date: " + s)
+}
@@ -0,0 +1,103 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	in := "\ufeffID,Name,Description,Priority,Due,Status\n" +
+		"1,Write report,Q3 numbers,2,2024-05-01,done\n" +
+		"2,,no title,,,\n" +
+		"3,Bad priority,,high,,\n" +
+		"4,Bad date,,,tomorrow,\n"
+
+	rows, err := Parse(FormatCSV, strings.NewReader(in))
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, 2, rows[0].Line)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, "Write report", rows[0].Task.Title)
+	assert.Equal(t, "Q3 numbers", rows[0].Task.Content)
+	assert.Equal(t, 2, rows[0].Task.Priority)
+	assert.Equal(t, "done", rows[0].Task.Status)
+	require.NotNil(t, rows[0].Task.DueAt)
+	assert.Equal(t, "2024-05-01", rows[0].Task.DueAt.Format("2006-01-02"))
+
+	// 空標題留給 service 檢查
+	assert.NoError(t, rows[1].Err)
+	assert.Empty(t, rows[1].Task.Title)
+	assert.EqualError(t, rows[2].Err, "invalid priority: high")
+	assert.EqualError(t, rows[3].Err, "invalid date: tomorrow")
+
+	_, err = Parse(FormatCSV, strings.NewReader("foo,bar\n1,2\n"))
+	assert.ErrorIs(t, err, ErrMissingTitle)
+}
+
+func TestParseJSON(t *testing.T) {
+	in := `[
+		{"id":1,"title":"a","status":"pending","priority":3,"due_at":"2024-05-01T09:00:00Z"},
+		{"title":"b","completed_at":"2024-05-02T00:00:00Z"},
+		{"title":5}
+	]`
+	rows, err := Parse(FormatJSON, strings.NewReader(in))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, "a", rows[0].Task.Title)
+	assert.Equal(t, 3, rows[0].Task.Priority)
+	require.NotNil(t, rows[0].Task.DueAt)
+	assert.True(t, rows[1].Completed)
+	assert.Equal(t, 3, rows[2].Line)
+	assert.Error(t, rows[2].Err)
+
+	_, err = Parse(FormatJSON, strings.NewReader(`{"title":"a"}`))
+	assert.Error(t, err)
+}
+
+func TestParseTodoist(t *testing.T) {
+	in := "TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE\n" +
+		"section,Inbox,,,,,,,,\n" +
+		"task,Pay rent,monthly,4,1,me,,2024-06-01,en,UTC\n" +
+		"task,Stretch,,1,1,me,,every day,en,UTC\n"
+
+	rows, err := Parse(FormatTodoist, strings.NewReader(in))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, 3, rows[0].Line)
+	assert.Equal(t, "Pay rent", rows[0].Task.Title)
+	assert.Equal(t, 1, rows[0].Task.Priority)
+	require.NotNil(t, rows[0].Task.DueAt)
+	// 自然語言的日期略過
+	assert.Nil(t, rows[1].Task.DueAt)
+	assert.Equal(t, 0, rows[1].Task.Priority)
+}
+
+func TestParseTrello(t *testing.T) {
+	in := `{"name":"Board","cards":[
+		{"name":"Ship it","desc":"v1","due":"2024-06-01T12:00:00.000Z","dueComplete":true},
+		{"name":"Archived","closed":true},
+		{"name":"Later","due":null}
+	]}`
+	rows, err := Parse(FormatTrello, strings.NewReader(in))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, "Ship it", rows[0].Task.Title)
+	assert.True(t, rows[0].Completed)
+	require.NotNil(t, rows[0].Task.DueAt)
+	assert.Equal(t, 3, rows[1].Line)
+	assert.False(t, rows[1].Completed)
+}
+
+func TestParse_UnknownFormat(t *testing.T) {
+	_, err := Parse("xlsx", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+	assert.Equal(t, FormatCSV, DetectFormat("Tasks.CSV"))
+	assert.Equal(t, "", DetectFormat("tasks.txt"))
+}
@@ -0,0 +1,58 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// jsonTask 對應 GET /v1/tasks/export?format=json 的欄位，匯出的檔案可以直接匯入。
+type jsonTask struct {
+	Title       string  `json:"title"`
+	Content     string  `json:"content"`
+	Status      string  `json:"status"`
+	Priority    int     `json:"priority"`
+	DueAt       *string `json:"due_at"`
+	CompletedAt *string `json:"completed_at"`
+}
+
+// parseJSON 逐一解碼陣列中的元素；單一元素格式錯誤只影響該列。
+func parseJSON(r io.Reader) ([]Row, error) {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return nil, errors.New("expected a JSON array")
+	}
+
+	var rows []Row
+	for line := 1; dec.More(); line++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		row := Row{Line: line}
+		var jt jsonTask
+		if err := json.Unmarshal(raw, &jt); err != nil {
+			row.Err = errors.New("invalid task object")
+			rows = append(rows, row)
+			continue
+		}
+		row.Task.Title = strings.TrimSpace(jt.Title)
+		row.Task.Content = jt.Content
+		row.Task.Status = strings.TrimSpace(jt.Status)
+		row.Task.Priority = jt.Priority
+		if jt.DueAt != nil {
+			row.Task.DueAt, row.Err = parseTime(*jt.DueAt)
+		}
+		row.Completed = jt.CompletedAt != nil && *jt.CompletedAt != ""
+		rows = append(rows, row)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
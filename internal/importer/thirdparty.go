@@ -0,0 +1,104 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// todoistPriority 把 Todoist 的 PRIORITY（4 = p1 最高 … 1 = 無）對應到 1-9 的優先度。
+var todoistPriority = map[string]int{"4": 1, "3": 3, "2": 5, "1": 0}
+
+// parseTodoist 解析 Todoist 專案匯出的 CSV（TYPE, CONTENT, DESCRIPTION, PRIORITY, DATE, ...）。
+// 只匯入 TYPE 為 task 的列；DATE 可能是自然語言（例如 "every day"），無法解析時略過不視為錯誤。
+func parseTodoist(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	if _, ok := cols["CONTENT"]; !ok {
+		return nil, ErrMissingTitle
+	}
+
+	var rows []Row
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var perr *csv.ParseError
+			if !errors.As(err, &perr) {
+				return nil, err
+			}
+			rows = append(rows, Row{Line: line, Err: err})
+			continue
+		}
+		get := func(col string) string {
+			i, ok := cols[col]
+			if !ok || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+		if t := strings.ToLower(get("TYPE")); t != "" && t != "task" {
+			continue
+		}
+
+		row := Row{Line: line}
+		row.Task.Title = get("CONTENT")
+		row.Task.Content = get("DESCRIPTION")
+		row.Task.Priority = todoistPriority[get("PRIORITY")]
+		if due, err := parseTime(get("DATE")); err == nil {
+			row.Task.DueAt = due
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+type trelloBoard struct {
+	Cards []struct {
+		Name        string  `json:"name"`
+		Desc        string  `json:"desc"`
+		Due         *string `json:"due"`
+		DueComplete bool    `json:"dueComplete"`
+		Closed      bool    `json:"closed"`
+	} `json:"cards"`
+}
+
+// parseTrello 解析 Trello 看板匯出的 JSON；封存（closed）的卡片不匯入，dueComplete 視為已完成。
+func parseTrello(r io.Reader) ([]Row, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, err
+	}
+
+	rows := make([]Row, 0, len(board.Cards))
+	for i, card := range board.Cards {
+		if card.Closed {
+			continue
+		}
+		row := Row{Line: i + 1, Completed: card.DueComplete}
+		row.Task.Title = strings.TrimSpace(card.Name)
+		row.Task.Content = card.Desc
+		if card.Due != nil {
+			var err error
+			if row.Task.DueAt, err = parseTime(*card.Due); err != nil {
+				row.Err = errors.New("invalid due date on card " + strconv.Itoa(i+1))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,319 @@
+// Package jsonpatch 實作 JSON Merge Patch（RFC 7396）與 JSON Patch（RFC 6902），
+// 作用在以 encoding/json 解碼成 any 的文件上；傳入的文件不會被修改。
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+var (
+	// ErrInvalidPatch 表示 patch 文件本身格式錯誤。
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrConflict 表示 patch 無法套用到目前的文件，例如路徑不存在或 test 不符合。
+	ErrConflict = errors.New("patch cannot be applied")
+)
+
+// Operation 是 JSON Patch 的一個操作；Value 為 nil 表示沒有提供 value 成員。
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// MergePatch 依 RFC 7396 把 patch 合併到 doc：null 表示刪除該成員，物件遞迴合併，其他值直接取代。
+func MergePatch(doc any, patch []byte) (any, error) {
+	var p any
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return merge(doc, p), nil
+}
+
+func merge(target, patch any) any {
+	pm, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	out := map[string]any{}
+	if tm, ok := target.(map[string]any); ok {
+		for k, v := range tm {
+			out[k] = v
+		}
+	}
+	for k, v := range pm {
+		if v == nil {
+			delete(out, k)
+			continue
+		}
+		out[k] = merge(out[k], v)
+	}
+	return out
+}
+
+// Apply 依 RFC 6902 依序套用 patch 中的操作；任一操作失敗時整份 patch 都不生效。
+func Apply(doc any, patch []byte) (any, error) {
+	var ops []Operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	doc = deepCopy(doc)
+	for i, op := range ops {
+		var err error
+		if doc, err = apply(doc, op); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+func apply(doc any, op Operation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: value is required", ErrInvalidPatch)
+		}
+		var val any
+		if err := json.Unmarshal(op.Value, &val); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		switch op.Op {
+		case "add":
+			return add(doc, path, val)
+		case "replace":
+			return replace(doc, path, val)
+		}
+		cur, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(cur, val) {
+			return nil, fmt.Errorf("%w: test failed", ErrConflict)
+		}
+		return doc, nil
+
+	case "remove":
+		if len(path) == 0 {
+			return nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+		}
+		return remove(doc, path)
+
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		val, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			return add(doc, path, deepCopy(val))
+		}
+		if op.From == op.Path {
+			return doc, nil
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("%w: cannot move a value into itself", ErrInvalidPatch)
+		}
+		if doc, err = remove(doc, from); err != nil {
+			return nil, err
+		}
+		return add(doc, path, val)
+
+	default:
+		return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.Op)
+	}
+}
+
+// parsePointer 解析 JSON Pointer（RFC 6901）；空字串指向整份文件。
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("%w: path %q must start with /", ErrInvalidPatch, p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(doc any, path []string) (any, error) {
+	for _, tok := range path {
+		switch n := doc.(type) {
+		case map[string]any:
+			v, ok := n[tok]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q not found", ErrConflict, tok)
+			}
+			doc = v
+		case []any:
+			i, err := arrayIndex(tok, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = n[i]
+		default:
+			return nil, fmt.Errorf("%w: %q not found", ErrConflict, tok)
+		}
+	}
+	return doc, nil
+}
+
+// modify 找到 path 的父節點後以 fn 修改，並把修改後的節點（陣列插入或刪除後 slice 會改變）接回上層。
+func modify(node any, path []string, fn func(parent any, key string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+	tok := path[0]
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[tok]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q not found", ErrConflict, tok)
+		}
+		c, err := modify(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[tok] = c
+		return n, nil
+	case []any:
+		i, err := arrayIndex(tok, len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		c, err := modify(n[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = c
+		return n, nil
+	default:
+		return nil, fmt.Errorf("%w: %q not found", ErrConflict, tok)
+	}
+}
+
+func add(doc any, path []string, val any) (any, error) {
+	if len(path) == 0 {
+		return val, nil
+	}
+	return modify(doc, path, func(parent any, key string) (any, error) {
+		switch n := parent.(type) {
+		case map[string]any:
+			n[key] = val
+			return n, nil
+		case []any:
+			i := len(n)
+			if key != "-" {
+				var err error
+				if i, err = arrayIndex(key, len(n)); err != nil {
+					return nil, err
+				}
+			}
+			return slices.Insert(n, i, val), nil
+		default:
+			return nil, fmt.Errorf("%w: parent of %q is not a container", ErrConflict, key)
+		}
+	})
+}
+
+func replace(doc any, path []string, val any) (any, error) {
+	if len(path) == 0 {
+		return val, nil
+	}
+	return modify(doc, path, func(parent any, key string) (any, error) {
+		switch n := parent.(type) {
+		case map[string]any:
+			if _, ok := n[key]; !ok {
+				return nil, fmt.Errorf("%w: %q not found", ErrConflict, key)
+			}
+			n[key] = val
+			return n, nil
+		case []any:
+			i, err := arrayIndex(key, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			n[i] = val
+			return n, nil
+		default:
+			return nil, fmt.Errorf("%w: %q not found", ErrConflict, key)
+		}
+	})
+}
+
+func remove(doc any, path []string) (any, error) {
+	return modify(doc, path, func(parent any, key string) (any, error) {
+		switch n := parent.(type) {
+		case map[string]any:
+			if _, ok := n[key]; !ok {
+				return nil, fmt.Errorf("%w: %q not found", ErrConflict, key)
+			}
+			delete(n, key)
+			return n, nil
+		case []any:
+			i, err := arrayIndex(key, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			return slices.Delete(n, i, i+1), nil
+		default:
+			return nil, fmt.Errorf("%w: %q not found", ErrConflict, key)
+		}
+	})
+}
+
+// arrayIndex 解析陣列索引，不允許前導 0；超出 0..max 視為衝突。
+func arrayIndex(tok string, max int) (int, error) {
+	if tok == "" || (len(tok) > 1 && tok[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, tok)
+	}
+	i, err := strconv.Atoi(tok)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, tok)
+	}
+	if i > max {
+		return 0, fmt.Errorf("%w: index %d out of range", ErrConflict, i)
+	}
+	return i, nil
+}
+
+func deepCopy(v any) any {
+	switch n := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(n))
+		for k, e := range n {
+			out[k] = deepCopy(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(n))
+		for i, e := range n {
+			out[i] = deepCopy(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
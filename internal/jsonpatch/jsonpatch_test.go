@@ -0,0 +1,105 @@
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/jsonpatch"
+)
+
+func decode(t *testing.T, s string) any {
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(s), &v))
+	return v
+}
+
+func encode(t *testing.T, v any) string {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(b)
+}
+
+// 範例取自 RFC 7396 附錄 A
+func TestMergePatch(t *testing.T) {
+	cases := []struct{ doc, patch, want string }{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+	}
+	for _, tc := range cases {
+		doc := decode(t, tc.doc)
+		got, err := jsonpatch.MergePatch(doc, []byte(tc.patch))
+		require.NoError(t, err, tc.patch)
+		assert.JSONEq(t, tc.want, encode(t, got), tc.patch)
+		assert.JSONEq(t, tc.doc, encode(t, doc), "document must not be modified")
+	}
+
+	_, err := jsonpatch.MergePatch(map[string]any{}, []byte(`{`))
+	assert.ErrorIs(t, err, jsonpatch.ErrInvalidPatch)
+}
+
+// 範例取自 RFC 6902 附錄 A
+func TestApply(t *testing.T) {
+	cases := []struct{ doc, patch, want string }{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{`{"foo":{"bar":"baz"}}`, `[{"op":"copy","from":"/foo","path":"/copy"}]`, `{"foo":{"bar":"baz"},"copy":{"bar":"baz"}}`},
+		{`{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			`{"baz":"qux","foo":["a",2,"c"]}`},
+		{`{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10},{"op":"replace","path":"/~1","value":null}]`, `{"/":null,"~1":10}`},
+		{`{"foo":"bar"}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+	}
+	for _, tc := range cases {
+		doc := decode(t, tc.doc)
+		got, err := jsonpatch.Apply(doc, []byte(tc.patch))
+		require.NoError(t, err, tc.patch)
+		assert.JSONEq(t, tc.want, encode(t, got), tc.patch)
+		assert.JSONEq(t, tc.doc, encode(t, doc), "document must not be modified")
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	doc := decode(t, `{"foo":"bar","list":[1,2]}`)
+	cases := []struct {
+		patch string
+		want  error
+	}{
+		{`{"op":"add"}`, jsonpatch.ErrInvalidPatch},
+		{`[{"op":"frobnicate","path":"/foo"}]`, jsonpatch.ErrInvalidPatch},
+		{`[{"op":"add","path":"/baz"}]`, jsonpatch.ErrInvalidPatch},
+		{`[{"op":"add","path":"foo","value":1}]`, jsonpatch.ErrInvalidPatch},
+		{`[{"op":"add","path":"/list/01","value":1}]`, jsonpatch.ErrInvalidPatch},
+		{`[{"op":"move","from":"/list","path":"/list/0"}]`, jsonpatch.ErrInvalidPatch},
+		{`[{"op":"remove","path":"/missing"}]`, jsonpatch.ErrConflict},
+		{`[{"op":"replace","path":"/missing","value":1}]`, jsonpatch.ErrConflict},
+		{`[{"op":"add","path":"/missing/child","value":1}]`, jsonpatch.ErrConflict},
+		{`[{"op":"add","path":"/list/5","value":1}]`, jsonpatch.ErrConflict},
+		{`[{"op":"test","path":"/foo","value":"baz"}]`, jsonpatch.ErrConflict},
+	}
+	for _, tc := range cases {
+		_, err := jsonpatch.Apply(doc, []byte(tc.patch))
+		assert.ErrorIs(t, err, tc.want, tc.patch)
+	}
+
+	// 中途失敗時前面的操作也不生效
+	_, err := jsonpatch.Apply(doc, []byte(`[{"op":"replace","path":"/foo","value":"x"},{"op":"remove","path":"/missing"}]`))
+	require.Error(t, err)
+	assert.JSONEq(t, `{"foo":"bar","list":[1,2]}`, encode(t, doc))
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+)
+
+type DeprecationMiddleware = gin.HandlerFunc
+
+// Deprecated 標示已棄用的路由：Deprecation（RFC 9745）為棄用日期，Sunset（RFC 8594）為預計移除的日期，
+// 並以 Link rel="successor-version" 指向新版本的路徑（把路徑前綴 from 換成 to）。
+func Deprecated(deprecatedAt, sunset time.Time, from, to string) DeprecationMiddleware {
+	deprecation := "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+	sunsetDate := sunset.UTC().Format(http.TimeFormat)
+	return func(c *gin.Context) {
+		c.Header(constant.HeaderDeprecation, deprecation)
+		c.Header(constant.HeaderSunset, sunsetDate)
+		if rest, ok := strings.CutPrefix(c.Request.URL.Path, from); ok {
+			c.Header(constant.HeaderLink, "<"+to+rest+`>; rel="successor-version"`)
+		}
+		c.Next()
+	}
+}
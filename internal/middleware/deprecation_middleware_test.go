@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+)
+
+func TestDeprecated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	deprecatedAt := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+
+	router := gin.New()
+	router.GET("/login", Deprecated(deprecatedAt, sunset, "", "/v1"), func(c *gin.Context) { c.Status(http.StatusOK) })
+	api := router.Group("/api", Deprecated(deprecatedAt, sunset, "/api", "/v1"))
+	api.GET("/tasks/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		path      string
+		successor string
+	}{
+		{"/login", "</v1/login>"},
+		{"/api/tasks/7", "</v1/tasks/7>"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "@1792368000", w.Header().Get(constant.HeaderDeprecation))
+		assert.Equal(t, "Fri, 30 Apr 2027 00:00:00 GMT", w.Header().Get(constant.HeaderSunset))
+		assert.Equal(t, tt.successor+`; rel="successor-version"`, w.Header().Get(constant.HeaderLink))
+	}
+}
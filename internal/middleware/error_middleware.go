@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/importer"
+	"github.com/SoliMark/gotasker-pro/internal/jsonpatch"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+type ErrorMiddleware = gin.HandlerFunc
+
+// knownErrors 把 service 的 sentinel error 對應到 HTTP 狀態碼與穩定的 code；
+// 未列出的錯誤一律視為 500，訊息不回傳給 client。
+var knownErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{service.ErrInvalidCredential, http.StatusUnauthorized, problem.CodeInvalidCredentials},
+	{service.ErrEmailTaken, http.StatusConflict, problem.CodeEmailTaken},
+	{service.ErrPermissionDenied, http.StatusForbidden, problem.CodeForbidden},
+
+	{service.ErrTaskNotFound, http.StatusNotFound, problem.CodeTaskNotFound},
+	{service.ErrProjectNotFound, http.StatusNotFound, problem.CodeProjectNotFound},
+	{service.ErrViewNotFound, http.StatusNotFound, problem.CodeViewNotFound},
+	{service.ErrWebhookNotFound, http.StatusNotFound, problem.CodeWebhookNotFound},
+	{service.ErrDeliveryNotFound, http.StatusNotFound, problem.CodeDeliveryNotFound},
+	{service.ErrImportJobNotFound, http.StatusNotFound, problem.CodeImportJobNotFound},
+	{service.ErrFeedNotFound, http.StatusNotFound, problem.CodeFeedNotFound},
+
+	{service.ErrInvalidStatus, http.StatusBadRequest, problem.CodeInvalidStatus},
+	{service.ErrInvalidTransition, http.StatusConflict, problem.CodeInvalidTransition},
+	{service.ErrInvalidPriority, http.StatusBadRequest, problem.CodeInvalidPriority},
+	{service.ErrInvalidMove, http.StatusBadRequest, problem.CodeInvalidMove},
+	{service.ErrInvalidParent, http.StatusBadRequest, problem.CodeInvalidParent},
+	{service.ErrInvalidSort, http.StatusBadRequest, problem.CodeInvalidSort},
+	{service.ErrInvalidCursor, http.StatusBadRequest, problem.CodeInvalidCursor},
+	{service.ErrInvalidSyncToken, http.StatusBadRequest, problem.CodeInvalidSyncToken},
+	{service.ErrInvalidSync, http.StatusBadRequest, problem.CodeInvalidRequest},
+	{service.ErrInvalidWorkflow, http.StatusBadRequest, problem.CodeInvalidWorkflow},
+	{service.ErrInvalidProject, http.StatusBadRequest, problem.CodeInvalidProject},
+	{service.ErrInvalidMember, http.StatusBadRequest, problem.CodeInvalidMember},
+	{service.ErrInvalidView, http.StatusBadRequest, problem.CodeInvalidView},
+	{service.ErrInvalidWebhook, http.StatusBadRequest, problem.CodeInvalidWebhook},
+	{service.ErrInvalidBulk, http.StatusBadRequest, problem.CodeInvalidBulk},
+	{service.ErrEmptyQuery, http.StatusBadRequest, problem.CodeEmptyQuery},
+	{service.ErrEmptyComment, http.StatusBadRequest, problem.CodeEmptyComment},
+	{importer.ErrUnknownFormat, http.StatusBadRequest, problem.CodeUnknownFormat},
+	{importer.ErrMissingTitle, http.StatusBadRequest, problem.CodeImportInvalid},
+	{jsonpatch.ErrInvalidPatch, http.StatusBadRequest, problem.CodeInvalidPatch},
+	{jsonpatch.ErrConflict, http.StatusConflict, problem.CodePatchConflict},
+
+	{service.ErrIdempotencyMismatch, http.StatusUnprocessableEntity, problem.CodeIdempotencyMismatch},
+	{service.ErrIdempotencyInProgress, http.StatusConflict, problem.CodeIdempotencyInProgress},
+}
+
+// knownFieldErrors 是單一欄位驗證失敗的 sentinel error，與 binding 驗證失敗一樣回應 validation_failed。
+var knownFieldErrors = []struct {
+	err   error
+	field problem.FieldError
+}{
+	{service.ErrTitleRequired, problem.FieldError{Field: "title", Rule: "required", Message: "is required"}},
+}
+
+// ErrorHandler 把 handler 以 c.Error 回報的錯誤寫成 application/problem+json。
+// handler 回報錯誤後直接 return，不自行寫出錯誤回應。
+func ErrorHandler() ErrorMiddleware {
+	return func(c *gin.Context) {
+		c.Next()
+		writeErrors(c)
+	}
+}
+
+// writeErrors 在還沒有寫出回應時，寫出最後一個以 c.Error 回報的錯誤。
+func writeErrors(c *gin.Context) {
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+	abortWithError(c, c.Errors.Last().Err)
+}
+
+func abortWithError(c *gin.Context, err error) {
+	p := ToProblem(err)
+	if p.Status >= http.StatusInternalServerError {
+		log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	}
+	problem.Write(c, p)
+}
+
+// ToProblem 把錯誤轉成回應給 client 的 Problem；GraphQL 等其他介面也以此決定錯誤代碼。
+func ToProblem(err error) *problem.Problem {
+	var p *problem.Problem
+	if errors.As(err, &p) {
+		return p
+	}
+	for _, known := range knownFieldErrors {
+		if errors.Is(err, known.err) {
+			p := problem.New(http.StatusBadRequest, problem.CodeValidationFailed, "request validation failed")
+			p.Errors = []problem.FieldError{known.field}
+			return p
+		}
+	}
+	for _, known := range knownErrors {
+		if errors.Is(err, known.err) {
+			// sentinel 外層可能以 %w 補充了給 client 的說明（例如哪一條流程規則不合法）
+			return problem.New(known.status, known.code, err.Error())
+		}
+	}
+	return problem.New(http.StatusInternalServerError, problem.CodeInternal, "internal server error")
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func TestErrorHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"sentinel", service.ErrTaskNotFound, http.StatusNotFound, problem.CodeTaskNotFound, "task not found"},
+		{"wrapped sentinel keeps detail", fmt.Errorf("%w: unknown status %q", service.ErrInvalidWorkflow, "x"),
+			http.StatusBadRequest, problem.CodeInvalidWorkflow, `invalid workflow: unknown status "x"`},
+		{"problem", problem.BadRequest("invalid limit"), http.StatusBadRequest, problem.CodeInvalidRequest, "invalid limit"},
+		{"unknown error is not leaked", errors.New("pq: connection refused"),
+			http.StatusInternalServerError, problem.CodeInternal, "internal server error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(ErrorHandler())
+			router.GET("/x", func(c *gin.Context) { _ = c.Error(tt.err) })
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/x", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, problem.ContentType, w.Header().Get(constant.HeaderContentType))
+			var got problem.Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, tt.wantCode, got.Code)
+			assert.Equal(t, tt.wantDetail, got.Detail)
+			assert.Equal(t, "/x", got.Instance)
+		})
+	}
+
+	t.Run("response already written", func(t *testing.T) {
+		router := gin.New()
+		router.Use(ErrorHandler())
+		router.GET("/x", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"ok": true})
+			_ = c.Error(errors.New("late"))
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/x", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"ok":true}`, w.Body.String())
+	})
+}
+
+// ErrorHandler 在 Idempotency 之外時，錯誤回應仍要被保存並在重送時回放。
+func TestIdempotencyStoresErrorResponses(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	calls := 0
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.POST("/tasks",
+		func(c *gin.Context) { c.Set(constant.ContextUserIDKey, uint(1)) },
+		Idempotency(service.NewIdempotencyService(nil, rdb, time.Hour)),
+		func(c *gin.Context) {
+			calls++
+			_ = c.Error(service.ErrInvalidStatus)
+		},
+	)
+
+	do := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{}`))
+		req.Header.Set(constant.HeaderIdempotencyKey, "k1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := do()
+	assert.Equal(t, http.StatusBadRequest, first.Code)
+	second := do()
+	assert.Equal(t, http.StatusBadRequest, second.Code)
+	assert.Equal(t, "true", second.Header().Get(constant.HeaderIdempotentReplayed))
+	assert.Equal(t, problem.ContentType, second.Header().Get(constant.HeaderContentType))
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+type IdempotencyMiddleware = gin.HandlerFunc
+
+const maxIdempotencyKeyLength = 255
+
+// idempotencyRefreshInterval 是處理中延長佔用的間隔，短於 service.IdempotencyLockTTL，
+// 執行超過佔用時間的請求（例如大型匯入）不會讓同一個 key 被重複執行。
+var idempotencyRefreshInterval = service.IdempotencyLockTTL / 3
+
+// maxIdempotentBodyBytes 是帶 Idempotency-Key 的請求可被暫存的 body 上限，與匯入檔案的上限相同。
+const maxIdempotentBodyBytes = 20 << 20
+
+// Idempotency 讓帶有 Idempotency-Key 的變更請求（POST/PUT/PATCH/DELETE）只執行一次：
+// 重送相同請求時回放第一次的回應，同一個 key 搭配不同內容時回 422。
+// 需放在 JWT middleware 之後，key 以使用者為範圍；5xx 回應不保存，client 可以用同一個 key 重試。
+func Idempotency(svc service.IdempotencyService) IdempotencyMiddleware {
+	return func(c *gin.Context) {
+		key := c.GetHeader(constant.HeaderIdempotencyKey)
+		if key == "" || !isMutating(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			problem.Write(c, problem.BadRequest("idempotency key is too long"))
+			return
+		}
+		userIDVal, exists := c.Get(constant.ContextUserIDKey)
+		if !exists {
+			c.Next()
+			return
+		}
+		userID := userIDVal.(uint)
+
+		// 計算指紋需要完整 body，先限制大小再暫存
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				problem.Write(c, problem.New(http.StatusRequestEntityTooLarge, problem.CodePayloadTooLarge, "request body too large"))
+				return
+			}
+			problem.Write(c, problem.BadRequest("invalid request body"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := requestFingerprint(c.Request, body)
+
+		stored, err := svc.Begin(c.Request.Context(), userID, key, fingerprint)
+		switch {
+		case err != nil:
+			abortWithError(c, err)
+			return
+		case stored != nil:
+			c.Header(constant.HeaderIdempotentReplayed, "true")
+			c.Data(stored.StatusCode, stored.ContentType, stored.Body)
+			c.Abort()
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = rec
+		stopRefresh := refreshIdempotencyLock(svc, userID, key)
+		completed := false
+		defer func() {
+			stopRefresh()
+			// 5xx 或 panic：放棄這個 key，讓 client 可以重試
+			if !completed {
+				if err := svc.Release(context.Background(), userID, key); err != nil {
+					log.Printf("idempotency: release: %v", err)
+				}
+			}
+		}()
+
+		c.Next()
+		// 錯誤回應也要保存，因此在這裡先寫出 handler 回報的錯誤
+		writeErrors(c)
+		stopRefresh()
+
+		if rec.Status() >= http.StatusInternalServerError {
+			return
+		}
+		completed = true
+		resp := service.StoredResponse{
+			StatusCode:  rec.Status(),
+			ContentType: rec.Header().Get(constant.HeaderContentType),
+			Body:        rec.body.Bytes(),
+		}
+		if err := svc.Complete(c.Request.Context(), userID, key, fingerprint, resp); err != nil {
+			log.Printf("idempotency: complete: %v", err)
+		}
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestFingerprint 涵蓋方法、路徑、查詢字串、Content-Type 與 body；
+// 查詢參數經過排序，參數順序不同的同一個請求會得到相同的指紋。
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write([]byte(r.URL.Query().Encode() + "\n"))
+	h.Write([]byte(strings.TrimSpace(r.Header.Get(constant.HeaderContentType)) + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// refreshIdempotencyLock 在請求處理期間定期延長 key 的佔用，回傳的 stop 可以重複呼叫，
+// 返回時保證不會再延長，之後的 Complete 或 Release 不會被覆蓋。
+func refreshIdempotencyLock(svc service.IdempotencyService, userID uint, key string) (stop func()) {
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(idempotencyRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := svc.Extend(context.Background(), userID, key); err != nil {
+					log.Printf("idempotency: extend: %v", err)
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+		<-exited
+	}
+}
+
+// responseRecorder 在寫出回應的同時保留一份 body。
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/cache"
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	svc := service.NewIdempotencyService(nil, rdb, time.Hour)
+
+	created := 0
+	failNext := false
+	router := gin.New()
+	router.POST("/tasks",
+		func(c *gin.Context) { c.Set(constant.ContextUserIDKey, uint(1)) },
+		Idempotency(svc),
+		func(c *gin.Context) {
+			if failNext {
+				failNext = false
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "boom"})
+				return
+			}
+			created++
+			c.JSON(http.StatusCreated, gin.H{"id": created})
+		},
+	)
+
+	do := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(constant.HeaderIdempotencyKey, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// 第一次執行，重送時回放相同回應
+	w := do("k1", `{"title":"a"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = do("k1", `{"title":"a"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `{"id":1}`, w.Body.String())
+	assert.Equal(t, "true", w.Header().Get(constant.HeaderIdempotentReplayed))
+	assert.Equal(t, 1, created)
+
+	// 同一個 key 不同內容
+	w = do("k1", `{"title":"b"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	// 沒帶 key 不受影響
+	do("", `{"title":"a"}`)
+	assert.Equal(t, 2, created)
+
+	// 5xx 不保存，可用同一個 key 重試
+	failNext = true
+	w = do("k2", `{}`)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	w = do("k2", `{}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, 3, created)
+
+	// 處理中的 key
+	pending := `{"fp":"` + requestFingerprint(httptest.NewRequest(http.MethodPost, "/tasks", nil), []byte(`{}`)) + `"}`
+	require.NoError(t, mr.Set(cache.KeyIdempotency(1, "k3"), pending))
+	w = do("k3", `{}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// 超過上限的 body 不會被暫存
+	w = do("k4", strings.Repeat("x", maxIdempotentBodyBytes+1))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(t, 3, created)
+}
+
+func TestIdempotencyMiddleware_FingerprintIncludesQueryAndContentType(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	router := gin.New()
+	router.POST("/tasks/import",
+		func(c *gin.Context) { c.Set(constant.ContextUserIDKey, uint(1)) },
+		Idempotency(service.NewIdempotencyService(nil, rdb, time.Hour)),
+		func(c *gin.Context) { c.JSON(http.StatusCreated, gin.H{}) },
+	)
+
+	do := func(target, contentType string) int {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader("a,b"))
+		req.Header.Set(constant.HeaderIdempotencyKey, "k")
+		req.Header.Set(constant.HeaderContentType, contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusCreated, do("/tasks/import?format=csv&dry_run=true", "text/csv"))
+	// 參數順序不同仍是同一個請求
+	assert.Equal(t, http.StatusCreated, do("/tasks/import?dry_run=true&format=csv", "text/csv"))
+	assert.Equal(t, http.StatusUnprocessableEntity, do("/tasks/import?format=csv", "text/csv"))
+	assert.Equal(t, http.StatusUnprocessableEntity, do("/tasks/import?format=csv&dry_run=true", "application/json"))
+}
+
+func TestIdempotencyMiddleware_ExtendsLockWhileRunning(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	old := idempotencyRefreshInterval
+	idempotencyRefreshInterval = 10 * time.Millisecond
+	defer func() { idempotencyRefreshInterval = old }()
+
+	router := gin.New()
+	router.POST("/tasks",
+		func(c *gin.Context) { c.Set(constant.ContextUserIDKey, uint(1)) },
+		Idempotency(service.NewIdempotencyService(nil, rdb, time.Hour)),
+		func(c *gin.Context) {
+			// 處理時間累計超過佔用時間，期間的延長讓 key 仍被佔用
+			for i := 0; i < 3; i++ {
+				mr.FastForward(service.IdempotencyLockTTL - time.Second)
+				time.Sleep(50 * time.Millisecond)
+			}
+			assert.True(t, mr.Exists(cache.KeyIdempotency(1, "k")))
+			c.JSON(http.StatusCreated, gin.H{})
+		},
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{}`))
+	req.Header.Set(constant.HeaderIdempotencyKey, "k")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, time.Hour, mr.TTL(cache.KeyIdempotency(1, "k")))
+}
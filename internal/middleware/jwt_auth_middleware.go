@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/problem"
 	"github.com/SoliMark/gotasker-pro/internal/util"
 )
 
@@ -15,17 +16,19 @@ type JWTMiddleware = gin.HandlerFunc
 func JWTAuthMiddleware(jwtMaker *util.JWTMaker) JWTMiddleware {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader(constant.HeaderAuthorization)
+		// 瀏覽器的 WebSocket API 無法設定 header，升級請求允許以 subprotocol 帶 token
+		if authHeader == "" && isWebSocketUpgrade(c.Request) {
+			if token := webSocketProtocolToken(c.Request); token != "" {
+				authHeader = "Bearer " + token
+			}
+		}
 		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				constant.ErrorKey: "authorization header is missing",
-			})
+			problem.Write(c, problem.Unauthorized("authorization header is missing"))
 			return
 		}
 		parts := strings.SplitN(authHeader, " ", 2)
 		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				constant.ErrorKey: "invalid authorization header format",
-			})
+			problem.Write(c, problem.Unauthorized("invalid authorization header format"))
 			return
 		}
 
@@ -33,9 +36,7 @@ func JWTAuthMiddleware(jwtMaker *util.JWTMaker) JWTMiddleware {
 
 		claims, err := jwtMaker.VerifyToken(tokenStr)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				constant.ErrorKey: "invaild or expired token",
-			})
+			problem.Write(c, problem.Unauthorized("invalid or expired token"))
 			return
 		}
 
@@ -43,3 +44,19 @@ func JWTAuthMiddleware(jwtMaker *util.JWTMaker) JWTMiddleware {
 		c.Next()
 	}
 }
+
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// webSocketProtocolToken 從 Sec-WebSocket-Protocol 取出 "access_token.<JWT>" 中的 JWT。
+func webSocketProtocolToken(r *http.Request) string {
+	for _, v := range r.Header.Values(constant.HeaderWebSocketProtocol) {
+		for _, p := range strings.Split(v, ",") {
+			if token, ok := strings.CutPrefix(strings.TrimSpace(p), constant.WebSocketTokenProtocolPrefix); ok {
+				return token
+			}
+		}
+	}
+	return ""
+}
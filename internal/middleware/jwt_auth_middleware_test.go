@@ -76,4 +76,35 @@ func TestJWTAuthMiddleware(t *testing.T) {
 
 		require.Equal(t, http.StatusUnauthorized, rec.Code)
 	})
+
+	t.Run("subprotocol token on websocket upgrade", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set(constant.HeaderWebSocketProtocol, constant.WebSocketProtocolBoard+", "+constant.WebSocketTokenProtocolPrefix+token)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("subprotocol token ignored without upgrade", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set(constant.HeaderWebSocketProtocol, constant.WebSocketTokenProtocolPrefix+token)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("query token is not accepted", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/protected?access_token="+token, nil)
+		req.Header.Set("Upgrade", "websocket")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
 }
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type Comment struct {
+	ID        uint   `gorm:"primaryKey"`
+	TaskID    uint   `gorm:"not null;index"`
+	UserID    uint   `gorm:"not null;index"`
+	Body      string `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
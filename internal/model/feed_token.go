@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// FeedToken 是使用者私人日曆訂閱網址的權杖；只保存 SHA-256 雜湊，每位使用者最多一個。
+type FeedToken struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;uniqueIndex"`
+	TokenHash string `gorm:"size:64;not null;uniqueIndex"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// IdempotencyRecord 保存 Idempotency-Key 第一次請求的指紋與回應（Redis 不可用時的後備儲存）。
+// StatusCode 為 0 表示請求仍在處理中。
+type IdempotencyRecord struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;uniqueIndex:idx_idempotency_user_key,priority:1"`
+	Key         string `gorm:"column:idem_key;size:255;not null;uniqueIndex:idx_idempotency_user_key,priority:2"`
+	Fingerprint string `gorm:"size:64;not null"`
+	StatusCode  int    `gorm:"not null;default:0"`
+	ContentType string `gorm:"size:100"`
+	Body        []byte
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	CreatedAt   time.Time
+}
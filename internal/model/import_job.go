@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// ImportJob 記錄一次非同步匯入的進度；Errors 為逐列錯誤的 JSON（只在檢查失敗時有值）。
+type ImportJob struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	Format    string `gorm:"size:20"`
+	Status    string `gorm:"size:20;not null"`
+	Total     int    `gorm:"not null;default:0"`
+	Processed int    `gorm:"not null;default:0"`
+	Error     string
+	Errors    string `gorm:"type:text"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+const (
+	ImportJobPending   = "pending"
+	ImportJobRunning   = "running"
+	ImportJobSucceeded = "succeeded"
+	ImportJobFailed    = "failed"
+)
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type Project struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	Name      string `gorm:"size:100;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ProjectMember 是擁有者以外可以開啟專案看板的使用者；任務仍只屬於擁有者。
+type ProjectMember struct {
+	ProjectID uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"primaryKey;index"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// SavedView 是使用者儲存的篩選與排序條件；Filter 為正規化後的 JSON，Sort 為正規化後的排序字串。
+type SavedView struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	Name      string `gorm:"size:100;not null"`
+	Filter    string `gorm:"type:text;not null"`
+	Sort      string `gorm:"size:255;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
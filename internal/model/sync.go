@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// SyncCounter 是每位使用者的任務變更流水號，每次寫入任務時遞增並寫進 Task.ChangeSeq。
+type SyncCounter struct {
+	UserID uint  `gorm:"primaryKey;autoIncrement:false"`
+	Seq    int64 `gorm:"not null;default:0"`
+}
+
+// TaskTombstone 記錄被刪除的任務，讓增量同步能通知 client 移除本地資料。
+type TaskTombstone struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index:idx_task_tombstones_user_seq,priority:1"`
+	TaskID    uint      `gorm:"not null"`
+	ChangeSeq int64     `gorm:"not null;index:idx_task_tombstones_user_seq,priority:2"`
+	DeletedAt time.Time `gorm:"not null"`
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// Tag 屬於使用者，同一使用者的標籤名稱不重複；與 Task 透過 task_tags 多對多關聯。
+type Tag struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_tags_user_name,priority:1"`
+	Name      string `gorm:"size:50;not null;uniqueIndex:idx_tags_user_name,priority:2"`
+	CreatedAt time.Time
+}
@@ -3,17 +3,27 @@ package model
 import "time"
 
 type Task struct {
-	ID        uint   `gorm:"primaryKey"`
-	UserID    uint   `gorm:"not null;index"`
-	Title     string `gorm:"not null"`
-	Content   string
-	Status    string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt *time.Time `gorm:"index"`
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;index;index:idx_tasks_user_position,priority:1;index:idx_tasks_user_change,priority:1"`
+	Title       string `gorm:"not null"`
+	Content     string
+	Status      string
+	ProjectID   *uint      `gorm:"index"`
+	ParentID    *uint      `gorm:"index"`              // 子任務所屬的任務；子任務不能再有子任務
+	Priority    int        `gorm:"not null;default:0"` // 0 表示未設定，1 最高、9 最低（與 iCalendar PRIORITY 相同）
+	DueAt       *time.Time `gorm:"index"`
+	Position    string     `gorm:"size:255;index:idx_tasks_user_position,priority:2"` // 手動排序用的字典序 rank
+	CompletedAt *time.Time
+	ChangeSeq   int64 `gorm:"not null;default:0;index:idx_tasks_user_change,priority:2"` // 最後一次寫入時的使用者變更流水號
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   *time.Time `gorm:"index"`
+	Tags        []Tag      `gorm:"many2many:task_tags"`
 }
 
 const (
 	TaskStatusPending = "pending"
 	TaskStatusDone    = "done"
 )
+
+const MaxTaskPriority = 9
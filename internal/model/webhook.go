@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// Webhook 是使用者註冊的事件接收端；Events 以逗號分隔訂閱的事件類型。
+// Secret 用於 HMAC 簽章，必須保存明文才能簽出接收端可驗證的值。
+type Webhook struct {
+	ID         uint              `gorm:"primaryKey"`
+	UserID     uint              `gorm:"not null;index"`
+	URL        string            `gorm:"size:2048;not null"`
+	Secret     string            `gorm:"size:255;not null"`
+	Events     string            `gorm:"size:255;not null"`
+	Deliveries []WebhookDelivery `gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// WebhookDelivery 是一次事件投遞及其重試狀態，同時作為投遞紀錄。
+// Status 為 pending 時由背景 worker 在 NextAttemptAt 之後送出。
+type WebhookDelivery struct {
+	ID            uint   `gorm:"primaryKey"`
+	WebhookID     uint   `gorm:"not null;index"`
+	Event         string `gorm:"size:50;not null"`
+	Payload       string `gorm:"type:text;not null"`
+	Status        string `gorm:"size:20;not null;index:idx_webhook_deliveries_due,priority:1"`
+	Attempts      int    `gorm:"not null;default:0"`
+	ResponseCode  int
+	Error         string
+	NextAttemptAt time.Time `gorm:"not null;index:idx_webhook_deliveries_due,priority:2"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
@@ -0,0 +1,67 @@
+package model
+
+import "time"
+
+// Workflow 定義使用者的任務狀態流程：可用狀態、允許的轉換與代表完成的狀態。
+type Workflow struct {
+	ID            uint                 `gorm:"primaryKey"`
+	UserID        uint                 `gorm:"not null;uniqueIndex"`
+	Name          string               `gorm:"not null"`
+	InitialStatus string               `gorm:"not null"`
+	States        []WorkflowState      `gorm:"constraint:OnDelete:CASCADE"`
+	Transitions   []WorkflowTransition `gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// WorkflowState 是流程中的一個狀態。Completes 表示進入此狀態時任務視為完成並記錄 CompletedAt；
+// 它不限制離開此狀態的轉換，能否重新開啟由 Transitions 決定。
+type WorkflowState struct {
+	ID         uint   `gorm:"primaryKey"`
+	WorkflowID uint   `gorm:"not null;index"`
+	Name       string `gorm:"not null"`
+	Completes  bool   `gorm:"column:terminal"` // 沿用既有欄位名稱，不需搬移資料
+}
+
+type WorkflowTransition struct {
+	ID         uint   `gorm:"primaryKey"`
+	WorkflowID uint   `gorm:"not null;index"`
+	FromStatus string `gorm:"not null"`
+	ToStatus   string `gorm:"not null"`
+}
+
+// DefaultWorkflow 是未自訂流程時使用的 pending ⇄ done 流程。
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		Name:          "default",
+		InitialStatus: TaskStatusPending,
+		States: []WorkflowState{
+			{Name: TaskStatusPending},
+			{Name: TaskStatusDone, Completes: true},
+		},
+		Transitions: []WorkflowTransition{
+			{FromStatus: TaskStatusPending, ToStatus: TaskStatusDone},
+			{FromStatus: TaskStatusDone, ToStatus: TaskStatusPending},
+		},
+	}
+}
+
+// State 回傳名稱對應的狀態，不存在時回傳 nil。
+func (w *Workflow) State(name string) *WorkflowState {
+	for i := range w.States {
+		if w.States[i].Name == name {
+			return &w.States[i]
+		}
+	}
+	return nil
+}
+
+// CanTransition 判斷 from → to 是否為允許的轉換。
+func (w *Workflow) CanTransition(from, to string) bool {
+	for _, t := range w.Transitions {
+		if t.FromStatus == from && t.ToStatus == to {
+			return true
+		}
+	}
+	return false
+}
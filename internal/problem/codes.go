@@ -0,0 +1,52 @@
+package problem
+
+// 穩定的錯誤代碼；新增可以，既有的值不可更改。
+const (
+	CodeInvalidRequest   = "invalid_request"
+	CodeValidationFailed = "validation_failed"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "permission_denied"
+	CodeNotFound         = "not_found"
+	CodeConflict         = "conflict"
+	CodePayloadTooLarge  = "payload_too_large"
+	CodeUnsupportedMedia = "unsupported_media_type"
+	CodeInternal         = "internal_error"
+
+	CodeInvalidCredentials = "invalid_credentials"
+	CodeEmailTaken         = "email_taken"
+
+	CodeTaskNotFound      = "task_not_found"
+	CodeProjectNotFound   = "project_not_found"
+	CodeViewNotFound      = "view_not_found"
+	CodeWebhookNotFound   = "webhook_not_found"
+	CodeDeliveryNotFound  = "delivery_not_found"
+	CodeImportJobNotFound = "import_job_not_found"
+	CodeFeedNotFound      = "feed_not_found"
+
+	CodeInvalidStatus     = "invalid_status"
+	CodeInvalidTransition = "invalid_transition"
+	CodeInvalidPriority   = "invalid_priority"
+	CodeInvalidMove       = "invalid_move"
+	CodeInvalidParent     = "invalid_parent"
+	CodeInvalidSort       = "invalid_sort"
+	CodeInvalidCursor     = "invalid_cursor"
+	CodeInvalidSyncToken  = "invalid_sync_token"
+	CodeInvalidWorkflow   = "invalid_workflow"
+	CodeInvalidProject    = "invalid_project"
+	CodeInvalidMember     = "invalid_member"
+	CodeInvalidView       = "invalid_view"
+	CodeInvalidWebhook    = "invalid_webhook"
+	CodeInvalidBulk       = "invalid_bulk"
+	CodeInvalidBatch      = "invalid_batch"
+	CodeImportInvalid     = "import_invalid"
+	CodeUnknownFormat     = "unknown_format"
+	CodeEmptyQuery        = "empty_query"
+	CodeEmptyComment      = "empty_comment"
+	CodeInvalidPatch      = "invalid_patch"
+	CodePatchConflict     = "patch_conflict"
+	CodeDependencyFailed  = "dependency_failed"
+	CodeTimeout           = "timeout"
+
+	CodeIdempotencyMismatch   = "idempotency_key_mismatch"
+	CodeIdempotencyInProgress = "idempotency_key_in_progress"
+)
@@ -0,0 +1,67 @@
+// Package problem 定義 API 的錯誤格式（RFC 7807 application/problem+json）。
+// 每個錯誤都帶有穩定的 code，client 應以 code 判斷錯誤種類，detail 只供人閱讀。
+package problem
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ContentType = "application/problem+json"
+
+// Problem 同時實作 error，handler 可以直接 c.Error(problem.New(...))。
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Code     string       `json:"code"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError 是驗證失敗的單一欄位；Field 為 JSON 路徑，例如 mutations[0].op。
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// New 建立沒有專屬說明文件的 problem，type 依 RFC 7807 使用 about:blank、title 為狀態碼的標準說明。
+func New(status int, code, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+// BadRequest 是 handler 自行檢查參數失敗時使用的 400。
+func BadRequest(detail string) *Problem {
+	return New(http.StatusBadRequest, CodeInvalidRequest, detail)
+}
+
+func (p *Problem) Error() string {
+	if p.Detail == "" {
+		return p.Code
+	}
+	return p.Code + ": " + p.Detail
+}
+
+// Write 寫出 problem 並中止後續的 handler；instance 未指定時使用請求路徑。
+func Write(c *gin.Context, p *Problem) {
+	out := *p
+	if out.Instance == "" {
+		out.Instance = c.Request.URL.Path
+	}
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(out.Status, &out)
+}
+
+// Unauthorized 是缺少或無效的身分驗證。
+func Unauthorized(detail string) *Problem {
+	return New(http.StatusUnauthorized, CodeUnauthorized, detail)
+}
@@ -0,0 +1,80 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bindingItem struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type bindingRequest struct {
+	Email string        `json:"email" binding:"required,email"`
+	Count int           `json:"count"`
+	Items []bindingItem `json:"items" binding:"required,min=1,dive"`
+}
+
+func TestFromBinding(t *testing.T) {
+	t.Run("validation errors list json fields", func(t *testing.T) {
+		var req bindingRequest
+		err := binding.JSON.BindBody([]byte(`{"email":"nope","items":[{}]}`), &req)
+		require.Error(t, err)
+
+		p := FromBinding(err)
+		assert.Equal(t, http.StatusBadRequest, p.Status)
+		assert.Equal(t, CodeValidationFailed, p.Code)
+		assert.ElementsMatch(t, []FieldError{
+			{Field: "email", Rule: "email", Message: "must be a valid email address"},
+			{Field: "items[0].name", Rule: "required", Message: "is required"},
+		}, p.Errors)
+	})
+
+	t.Run("wrong json type", func(t *testing.T) {
+		var req bindingRequest
+		err := binding.JSON.BindBody([]byte(`{"count":"many"}`), &req)
+		p := FromBinding(err)
+		assert.Equal(t, CodeValidationFailed, p.Code)
+		require.Len(t, p.Errors, 1)
+		assert.Equal(t, "count", p.Errors[0].Field)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		var req bindingRequest
+		err := binding.JSON.BindBody([]byte(`{`), &req)
+		p := FromBinding(err)
+		assert.Equal(t, CodeInvalidRequest, p.Code)
+		assert.Empty(t, p.Errors)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/tasks/9", nil)
+
+	Write(c, New(http.StatusNotFound, CodeTaskNotFound, "task not found"))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+	assert.True(t, c.IsAborted())
+
+	var got Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, Problem{
+		Type:     "about:blank",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Code:     CodeTaskNotFound,
+		Detail:   "task not found",
+		Instance: "/api/tasks/9",
+	}, got)
+}
@@ -0,0 +1,82 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// 讓驗證錯誤回報 JSON 欄位名稱而不是 Go 的欄位名稱。
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+func jsonFieldName(f reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(f.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// FromBinding 把 ShouldBind 系列的錯誤轉成 400；驗證失敗時逐欄位列出原因。
+func FromBinding(err error) *Problem {
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		p := New(http.StatusBadRequest, CodeValidationFailed, "request validation failed")
+		for _, fe := range verrs {
+			p.Errors = append(p.Errors, FieldError{
+				Field:   fieldPath(fe.Namespace()),
+				Rule:    fe.Tag(),
+				Message: ruleMessage(fe),
+			})
+		}
+		return p
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		p := New(http.StatusBadRequest, CodeValidationFailed, "request validation failed")
+		p.Errors = []FieldError{{Field: typeErr.Field, Rule: "type", Message: "must be of type " + typeErr.Type.Kind().String()}}
+		return p
+	}
+	return BadRequest("malformed request body")
+}
+
+// fieldPath 去掉最前面的 struct 名稱：CreateTaskRequest.title → title。
+func fieldPath(namespace string) string {
+	if _, rest, ok := strings.Cut(namespace, "."); ok {
+		return rest
+	}
+	return namespace
+}
+
+func ruleMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "min":
+		return "must be at least " + fe.Param()
+	case "max":
+		return "must be at most " + fe.Param()
+	case "oneof":
+		return "must be one of: " + fe.Param()
+	default:
+		return "failed the " + fe.Tag() + " rule"
+	}
+}
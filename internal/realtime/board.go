@@ -0,0 +1,215 @@
+package realtime
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrClientClosed  = errors.New("board client closed")
+	ErrNotJoined     = errors.New("board not joined")
+	ErrTooManyBoards = errors.New("too many boards")
+)
+
+// MaxBoardsPerClient 是單一連線可同時訂閱的專案看板數。
+const MaxBoardsPerClient = 20
+
+// boardClientBuffer 是每條看板連線的送出佇列長度；佇列滿時連線會被中斷，由 client 重連後重新訂閱。
+const boardClientBuffer = 64
+
+// 看板上的狀態；PresenceLeft 只由伺服器在取消訂閱或斷線時送出。
+const (
+	PresenceViewing = "viewing"
+	PresenceEditing = "editing"
+	PresenceIdle    = "idle"
+	PresenceLeft    = "left"
+)
+
+// BoardMessagePresence 是 hub 自行送出的 presence 訊息類型。
+const BoardMessagePresence = "presence"
+
+type Presence struct {
+	ClientID  uint64    `json:"client_id"`
+	UserID    uint      `json:"user_id"`
+	TaskID    *uint     `json:"task_id,omitempty"`
+	State     string    `json:"state"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BoardMessage 是送給看板連線的訊息；依 Type 只會帶部分欄位。
+type BoardMessage struct {
+	Type      string          `json:"type"`
+	Ref       string          `json:"ref,omitempty"`
+	ProjectID uint            `json:"project_id,omitempty"`
+	Presence  *Presence       `json:"presence,omitempty"`
+	Members   []Presence      `json:"members,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// BoardClient 是一條看板連線。Send 被關閉表示連線已結束（Disconnect 或處理太慢被中斷）。
+type BoardClient struct {
+	ID     uint64
+	UserID uint
+	Send   <-chan BoardMessage
+
+	send   chan BoardMessage
+	done   chan struct{}
+	boards map[uint]*Presence // 由 hub.mu 保護
+	closed bool
+}
+
+// Done 在連線結束時關閉。
+func (c *BoardClient) Done() <-chan struct{} {
+	return c.done
+}
+
+// BoardHub 在單一程序內維護各專案看板的訂閱者與 presence。
+// presence 不跨程序同步，多台部署時同一看板的連線需導向同一台（sticky session）才看得到彼此。
+type BoardHub struct {
+	mu     sync.Mutex
+	nextID uint64
+	boards map[uint]map[*BoardClient]struct{}
+}
+
+func NewBoardHub() *BoardHub {
+	return &BoardHub{boards: map[uint]map[*BoardClient]struct{}{}}
+}
+
+func (h *BoardHub) Connect(userID uint) *BoardClient {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nextID++
+	send := make(chan BoardMessage, boardClientBuffer)
+	return &BoardClient{
+		ID:     h.nextID,
+		UserID: userID,
+		Send:   send,
+		send:   send,
+		done:   make(chan struct{}),
+		boards: map[uint]*Presence{},
+	}
+}
+
+// Join 把連線加入看板並以 viewing 狀態通知其他成員，回傳加入前的成員 presence。重複加入不會重送通知。
+func (h *BoardHub) Join(c *BoardClient, projectID uint) ([]Presence, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+	if _, ok := c.boards[projectID]; !ok {
+		if len(c.boards) >= MaxBoardsPerClient {
+			return nil, ErrTooManyBoards
+		}
+		p := &Presence{ClientID: c.ID, UserID: c.UserID, State: PresenceViewing, UpdatedAt: time.Now()}
+		c.boards[projectID] = p
+		if h.boards[projectID] == nil {
+			h.boards[projectID] = map[*BoardClient]struct{}{}
+		}
+		h.boards[projectID][c] = struct{}{}
+		h.broadcastPresenceLocked(projectID, c, *p)
+	}
+
+	members := []Presence{}
+	for other := range h.boards[projectID] {
+		if other != c {
+			members = append(members, *other.boards[projectID])
+		}
+	}
+	return members, nil
+}
+
+// Leave 讓連線離開看板並通知其他成員；未加入時不做任何事。
+func (h *BoardHub) Leave(c *BoardClient, projectID uint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.leaveLocked(c, projectID)
+}
+
+// SetPresence 更新連線在看板上的狀態並通知其他成員。
+func (h *BoardHub) SetPresence(c *BoardClient, projectID uint, taskID *uint, state string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if c.closed {
+		return ErrClientClosed
+	}
+	p, ok := c.boards[projectID]
+	if !ok {
+		return ErrNotJoined
+	}
+	p.TaskID, p.State, p.UpdatedAt = taskID, state, time.Now()
+	h.broadcastPresenceLocked(projectID, c, *p)
+	return nil
+}
+
+// Joined 回傳連線目前是否在看板上。
+func (h *BoardHub) Joined(c *BoardClient, projectID uint) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, ok := c.boards[projectID]
+	return ok
+}
+
+// Send 把訊息放進連線的送出佇列；佇列已滿時中斷該連線。
+func (h *BoardHub) Send(c *BoardClient, msg BoardMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sendLocked(c, msg)
+}
+
+// Disconnect 讓連線離開所有看板並關閉 Send；可重複呼叫。
+func (h *BoardHub) Disconnect(c *BoardClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.disconnectLocked(c)
+}
+
+func (h *BoardHub) sendLocked(c *BoardClient, msg BoardMessage) {
+	if c.closed {
+		return
+	}
+	select {
+	case c.send <- msg:
+	default:
+		h.disconnectLocked(c)
+	}
+}
+
+func (h *BoardHub) disconnectLocked(c *BoardClient) {
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.send)
+	close(c.done)
+	for projectID := range c.boards {
+		h.leaveLocked(c, projectID)
+	}
+}
+
+func (h *BoardHub) leaveLocked(c *BoardClient, projectID uint) {
+	p, ok := c.boards[projectID]
+	if !ok {
+		return
+	}
+	delete(c.boards, projectID)
+	delete(h.boards[projectID], c)
+	if len(h.boards[projectID]) == 0 {
+		delete(h.boards, projectID)
+	}
+	left := *p
+	left.TaskID, left.State, left.UpdatedAt = nil, PresenceLeft, time.Now()
+	h.broadcastPresenceLocked(projectID, c, left)
+}
+
+// broadcastPresenceLocked 通知看板上 from 以外的成員；過程中可能中斷佇列已滿的連線。
+func (h *BoardHub) broadcastPresenceLocked(projectID uint, from *BoardClient, p Presence) {
+	for other := range h.boards[projectID] {
+		if other != from {
+			h.sendLocked(other, BoardMessage{Type: BoardMessagePresence, ProjectID: projectID, Presence: &p})
+		}
+	}
+}
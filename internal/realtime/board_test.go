@@ -0,0 +1,86 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardHub(t *testing.T) {
+	h := NewBoardHub()
+	a := h.Connect(1)
+	b := h.Connect(1)
+
+	members, err := h.Join(a, 10)
+	require.NoError(t, err)
+	assert.Empty(t, members)
+
+	members, err = h.Join(b, 10)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, a.ID, members[0].ClientID)
+	assert.Equal(t, PresenceViewing, members[0].State)
+
+	msg := <-a.Send
+	assert.Equal(t, BoardMessagePresence, msg.Type)
+	assert.Equal(t, b.ID, msg.Presence.ClientID)
+
+	taskID := uint(5)
+	require.NoError(t, h.SetPresence(b, 10, &taskID, PresenceEditing))
+	msg = <-a.Send
+	assert.Equal(t, PresenceEditing, msg.Presence.State)
+	assert.Equal(t, &taskID, msg.Presence.TaskID)
+	assert.Empty(t, b.Send, "自己的 presence 不會回送")
+
+	assert.ErrorIs(t, h.SetPresence(b, 11, nil, PresenceIdle), ErrNotJoined)
+
+	h.Disconnect(b)
+	h.Disconnect(b)
+	msg = <-a.Send
+	assert.Equal(t, PresenceLeft, msg.Presence.State)
+	assert.Nil(t, msg.Presence.TaskID)
+	_, open := <-b.Send
+	assert.False(t, open)
+	_, err = h.Join(b, 10)
+	assert.ErrorIs(t, err, ErrClientClosed)
+}
+
+func TestBoardHubLimits(t *testing.T) {
+	h := NewBoardHub()
+	c := h.Connect(1)
+	for i := 1; i <= MaxBoardsPerClient; i++ {
+		_, err := h.Join(c, uint(i))
+		require.NoError(t, err)
+	}
+	_, err := h.Join(c, MaxBoardsPerClient+1)
+	assert.ErrorIs(t, err, ErrTooManyBoards)
+	// 已加入的看板重複加入不受限制
+	_, err = h.Join(c, 1)
+	assert.NoError(t, err)
+}
+
+func TestBoardHubDropsSlowClient(t *testing.T) {
+	h := NewBoardHub()
+	slow := h.Connect(1)
+	watcher := h.Connect(1)
+	_, err := h.Join(slow, 10)
+	require.NoError(t, err)
+	_, err = h.Join(watcher, 10)
+	require.NoError(t, err)
+	<-slow.Send // watcher 加入時的 viewing
+
+	for i := 0; i <= boardClientBuffer; i++ {
+		h.Send(slow, BoardMessage{Type: "task.updated"})
+	}
+
+	select {
+	case <-slow.Done():
+	default:
+		t.Fatal("slow client should be disconnected")
+	}
+	assert.False(t, h.Joined(slow, 10))
+	msg := <-watcher.Send
+	assert.Equal(t, PresenceLeft, msg.Presence.State)
+	assert.Equal(t, slow.ID, msg.Presence.ClientID)
+}
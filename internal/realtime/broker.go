@@ -0,0 +1,117 @@
+// Package realtime 把使用者的任務異動事件推送給已連線的 client（SSE 等）。
+// 每位使用者的事件有遞增的流水號，並保留最近一段作為重連時補送的緩衝。
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// BufferSize 是每位使用者保留、可供補送的事件數。
+const BufferSize = 200
+
+// subscriberBuffer 是每個訂閱者的佇列長度；佇列滿時該訂閱者會被中斷，由 client 以 Last-Event-ID 重連補送。
+const subscriberBuffer = 64
+
+type Event struct {
+	ID     uint64          `json:"id"`
+	UserID uint            `json:"-"`
+	Type   string          `json:"type"`
+	Data   json.RawMessage `json:"data"`
+}
+
+type Broker interface {
+	Publish(ctx context.Context, userID uint, typ string, data json.RawMessage) error
+	Subscribe(userID uint) *Subscription
+	// Replay 回傳 afterID 之後仍在緩衝區內的事件；ok 為 false 表示中間有事件已不在緩衝區，client 需要重新載入。
+	Replay(ctx context.Context, userID uint, afterID uint64) (events []Event, ok bool, err error)
+}
+
+// Subscription 是單一連線的事件佇列；C 被關閉表示訂閱已結束（Close 或處理太慢被中斷）。
+type Subscription struct {
+	C      <-chan Event
+	c      chan Event
+	userID uint
+	hub    *hub
+	once   sync.Once
+}
+
+func (s *Subscription) Close() {
+	s.once.Do(func() { s.hub.remove(s) })
+}
+
+// hub 在單一程序內把事件分送給同一使用者的所有訂閱者。
+type hub struct {
+	mu   sync.Mutex
+	subs map[uint]map[*Subscription]struct{}
+}
+
+func newHub() *hub {
+	return &hub{subs: map[uint]map[*Subscription]struct{}{}}
+}
+
+func (h *hub) subscribe(userID uint) *Subscription {
+	c := make(chan Event, subscriberBuffer)
+	sub := &Subscription{C: c, c: c, userID: userID, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs[userID] == nil {
+		h.subs[userID] = map[*Subscription]struct{}{}
+	}
+	h.subs[userID][sub] = struct{}{}
+	return sub
+}
+
+func (h *hub) remove(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(sub)
+}
+
+func (h *hub) removeLocked(sub *Subscription) {
+	subs := h.subs[sub.userID]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subs, sub.userID)
+	}
+	close(sub.c)
+}
+
+// broadcast 不會因為慢的訂閱者而阻塞：佇列已滿的訂閱者直接中斷。
+func (h *hub) broadcast(ev Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs[ev.UserID] {
+		select {
+		case sub.c <- ev:
+		default:
+			h.removeLocked(sub)
+		}
+	}
+}
+
+// replayFrom 依緩衝區（ID 遞增）與目前流水號計算 afterID 之後的事件。
+func replayFrom(buf []Event, seq, afterID uint64) ([]Event, bool) {
+	if afterID > seq {
+		// 流水號比目前還新（例如 Redis 資料被清除），無法判斷遺漏了什麼
+		return nil, false
+	}
+	if afterID == seq {
+		return nil, true
+	}
+	if len(buf) == 0 || buf[0].ID > afterID+1 {
+		return nil, false
+	}
+	var out []Event
+	for _, ev := range buf {
+		if ev.ID > afterID {
+			out = append(out, ev)
+		}
+	}
+	return out, true
+}
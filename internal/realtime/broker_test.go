@@ -0,0 +1,125 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+	select {
+	case ev := <-sub.C:
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+		return Event{}
+	}
+}
+
+func testBroker(t *testing.T, b Broker) {
+	ctx := context.Background()
+	sub := b.Subscribe(1)
+	other := b.Subscribe(2)
+	defer other.Close()
+
+	require.NoError(t, b.Publish(ctx, 1, "task.created", json.RawMessage(`{"id":10}`)))
+	require.NoError(t, b.Publish(ctx, 1, "task.updated", json.RawMessage(`{"id":10}`)))
+
+	ev := receive(t, sub)
+	assert.Equal(t, uint64(1), ev.ID)
+	assert.Equal(t, "task.created", ev.Type)
+	assert.JSONEq(t, `{"id":10}`, string(ev.Data))
+	assert.Equal(t, uint64(2), receive(t, sub).ID)
+	assert.Empty(t, other.C)
+
+	events, ok, err := b.Replay(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.Len(t, events, 1)
+	assert.Equal(t, "task.updated", events[0].Type)
+
+	// 已經是最新
+	events, ok, err = b.Replay(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, events)
+
+	// 比目前流水號還新
+	_, ok, err = b.Replay(ctx, 1, 99)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	sub.Close()
+	sub.Close()
+	_, open := <-sub.C
+	assert.False(t, open)
+}
+
+func TestMemoryBroker(t *testing.T) {
+	testBroker(t, NewMemoryBroker())
+}
+
+func TestRedisBroker(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := NewRedisBroker(ctx, rdb)
+	// 等 pattern 訂閱建立
+	require.Eventually(t, func() bool {
+		n, _ := rdb.Do(ctx, "PUBSUB", "NUMPAT").Int()
+		return n == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	testBroker(t, b)
+
+	// 其他實例（另一個 broker）發布的事件也會收到
+	sub := b.Subscribe(3)
+	defer sub.Close()
+	other := &redisBroker{rdb: rdb, hub: newHub()}
+	require.NoError(t, other.Publish(ctx, 3, "task.deleted", json.RawMessage(`{}`)))
+	assert.Equal(t, "task.deleted", receive(t, sub).Type)
+}
+
+func TestReplayFrom_BufferOverflow(t *testing.T) {
+	b := NewMemoryBroker()
+	ctx := context.Background()
+	for i := 0; i < BufferSize+5; i++ {
+		require.NoError(t, b.Publish(ctx, 1, "task.updated", json.RawMessage(`{}`)))
+	}
+
+	_, ok, err := b.Replay(ctx, 1, 3)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	events, ok, err := b.Replay(ctx, 1, 5)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, events, BufferSize)
+}
+
+func TestHub_DropsSlowSubscriber(t *testing.T) {
+	b := NewMemoryBroker()
+	ctx := context.Background()
+	slow := b.Subscribe(1)
+	for i := 0; i <= subscriberBuffer; i++ {
+		require.NoError(t, b.Publish(ctx, 1, "task.updated", json.RawMessage(`{}`)))
+	}
+
+	n := 0
+	for range slow.C {
+		n++
+	}
+	assert.Equal(t, subscriberBuffer, n)
+	slow.Close()
+}
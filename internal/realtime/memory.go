@@ -0,0 +1,46 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// memoryBroker 是 Redis 未啟用時使用的單一程序 broker。
+type memoryBroker struct {
+	hub *hub
+
+	mu   sync.Mutex
+	seq  map[uint]uint64
+	logs map[uint][]Event
+}
+
+func NewMemoryBroker() Broker {
+	return &memoryBroker{hub: newHub(), seq: map[uint]uint64{}, logs: map[uint][]Event{}}
+}
+
+func (b *memoryBroker) Publish(_ context.Context, userID uint, typ string, data json.RawMessage) error {
+	b.mu.Lock()
+	b.seq[userID]++
+	ev := Event{ID: b.seq[userID], UserID: userID, Type: typ, Data: data}
+	log := append(b.logs[userID], ev)
+	if len(log) > BufferSize {
+		log = append([]Event(nil), log[len(log)-BufferSize:]...)
+	}
+	b.logs[userID] = log
+	// 在鎖內廣播，保證訂閱者收到的順序與流水號一致
+	b.hub.broadcast(ev)
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *memoryBroker) Subscribe(userID uint) *Subscription {
+	return b.hub.subscribe(userID)
+}
+
+func (b *memoryBroker) Replay(_ context.Context, userID uint, afterID uint64) ([]Event, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	events, ok := replayFrom(b.logs[userID], b.seq[userID], afterID)
+	return events, ok, nil
+}
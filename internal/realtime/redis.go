@@ -0,0 +1,141 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/SoliMark/gotasker-pro/internal/cache"
+)
+
+// logTTL 是事件緩衝在沒有新事件時的保存時間。
+const logTTL = time.Hour
+
+// publishScript 原子地配發流水號、寫入緩衝並發布，確保各實例看到的順序與流水號一致。
+// 緩衝與 channel 中的值都是 "<id> <json>"。
+var publishScript = redis.NewScript(`
+local id = redis.call('INCR', KEYS[1])
+local msg = id .. ' ' .. ARGV[1]
+redis.call('RPUSH', KEYS[2], msg)
+redis.call('LTRIM', KEYS[2], -tonumber(ARGV[2]), -1)
+redis.call('EXPIRE', KEYS[2], tonumber(ARGV[3]))
+redis.call('PUBLISH', KEYS[3], msg)
+return id
+`)
+
+// redisBroker 以 Redis pub/sub 在多個實例間分送事件。每個實例只訂閱一次 pattern channel，
+// 再由本地的 hub 分給連線，不會每條連線各占一個 Redis 連線。
+type redisBroker struct {
+	rdb *redis.Client
+	hub *hub
+}
+
+// NewRedisBroker 建立 broker 並在背景訂閱，直到 ctx 結束。
+func NewRedisBroker(ctx context.Context, rdb *redis.Client) Broker {
+	b := &redisBroker{rdb: rdb, hub: newHub()}
+	ps := rdb.PSubscribe(ctx, cache.ChannelUserEventsPattern)
+	go b.run(ctx, ps)
+	return b
+}
+
+func (b *redisBroker) run(ctx context.Context, ps *redis.PubSub) {
+	defer ps.Close()
+	ch := ps.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			userID, ok := parseChannelUser(msg.Channel)
+			if !ok {
+				continue
+			}
+			ev, err := decodeEvent(userID, msg.Payload)
+			if err != nil {
+				log.Printf("realtime: decode event on %s: %v", msg.Channel, err)
+				continue
+			}
+			b.hub.broadcast(ev)
+		}
+	}
+}
+
+type wireEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+func (b *redisBroker) Publish(ctx context.Context, userID uint, typ string, data json.RawMessage) error {
+	body, err := json.Marshal(wireEvent{Type: typ, Data: data})
+	if err != nil {
+		return err
+	}
+	keys := []string{cache.KeyUserEventSeq(userID), cache.KeyUserEventLog(userID), cache.ChannelUserEvents(userID)}
+	return publishScript.Run(ctx, b.rdb, keys, body, BufferSize, int(logTTL.Seconds())).Err()
+}
+
+func (b *redisBroker) Subscribe(userID uint) *Subscription {
+	return b.hub.subscribe(userID)
+}
+
+func (b *redisBroker) Replay(ctx context.Context, userID uint, afterID uint64) ([]Event, bool, error) {
+	pipe := b.rdb.Pipeline()
+	seqCmd := pipe.Get(ctx, cache.KeyUserEventSeq(userID))
+	logCmd := pipe.LRange(ctx, cache.KeyUserEventLog(userID), 0, -1)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, false, err
+	}
+	seq, err := seqCmd.Uint64()
+	if err != nil && err != redis.Nil {
+		return nil, false, err
+	}
+
+	buf := make([]Event, 0, len(logCmd.Val()))
+	for _, raw := range logCmd.Val() {
+		ev, err := decodeEvent(userID, raw)
+		if err != nil {
+			return nil, false, err
+		}
+		buf = append(buf, ev)
+	}
+	events, ok := replayFrom(buf, seq, afterID)
+	return events, ok, nil
+}
+
+func decodeEvent(userID uint, raw string) (Event, error) {
+	idStr, body, _ := strings.Cut(raw, " ")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return Event{}, err
+	}
+	var w wireEvent
+	if err := json.Unmarshal([]byte(body), &w); err != nil {
+		return Event{}, err
+	}
+	return Event{ID: id, UserID: userID, Type: w.Type, Data: w.Data}, nil
+}
+
+// parseChannelUser 從 user:<uid>:events:v1 取出 uid。
+func parseChannelUser(channel string) (uint, bool) {
+	rest, ok := strings.CutPrefix(channel, "user:")
+	if !ok {
+		return 0, false
+	}
+	idStr, _, ok := strings.Cut(rest, ":")
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return uint(id), true
+}
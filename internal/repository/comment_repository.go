@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment *model.Comment) error
+	ListByTaskID(ctx context.Context, taskID uint) ([]*model.Comment, error)
+	ListByTaskIDs(ctx context.Context, taskIDs []uint) ([]*model.Comment, error)
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment *model.Comment) error {
+	return r.db.WithContext(ctx).Create(comment).Error
+}
+
+func (r *commentRepository) ListByTaskID(ctx context.Context, taskID uint) ([]*model.Comment, error) {
+	var comments []*model.Comment
+	err := r.db.WithContext(ctx).
+		Where("task_id = ?", taskID).
+		Order("created_at ASC, id ASC").
+		Find(&comments).Error
+	return comments, err
+}
+
+func (r *commentRepository) ListByTaskIDs(ctx context.Context, taskIDs []uint) ([]*model.Comment, error) {
+	var comments []*model.Comment
+	if len(taskIDs) == 0 {
+		return comments, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("task_id IN ?", taskIDs).
+		Order("created_at ASC, id ASC").
+		Find(&comments).Error
+	return comments, err
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+type FeedTokenRepository interface {
+	// Save 建立或取代使用者的權杖（舊的網址立即失效）。
+	Save(ctx context.Context, token *model.FeedToken) error
+	FindByHash(ctx context.Context, hash string) (*model.FeedToken, error)
+	DeleteByUserID(ctx context.Context, userID uint) error
+}
+
+type feedTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewFeedTokenRepository(db *gorm.DB) FeedTokenRepository {
+	return &feedTokenRepository{db: db}
+}
+
+func (r *feedTokenRepository) Save(ctx context.Context, token *model.FeedToken) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at"}),
+	}).Create(token).Error
+}
+
+func (r *feedTokenRepository) FindByHash(ctx context.Context, hash string) (*model.FeedToken, error) {
+	var token model.FeedToken
+	err := r.db.WithContext(ctx).First(&token, "token_hash = ?", hash).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *feedTokenRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&model.FeedToken{}).Error
+}
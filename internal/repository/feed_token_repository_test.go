@@ -0,0 +1,56 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+func TestFeedTokenRepository_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.FeedToken{}))
+	repo := repository.NewFeedTokenRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Save(ctx, &model.FeedToken{UserID: 1, TokenHash: "old"}))
+	// 再次產生時取代舊權杖
+	require.NoError(t, repo.Save(ctx, &model.FeedToken{UserID: 1, TokenHash: "new"}))
+
+	old, err := repo.FindByHash(ctx, "old")
+	require.NoError(t, err)
+	assert.Nil(t, old)
+	got, err := repo.FindByHash(ctx, "new")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, uint(1), got.UserID)
+
+	require.NoError(t, repo.DeleteByUserID(ctx, 1))
+	got, err = repo.FindByHash(ctx, "new")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestTaskRepository_ListDueByUserID_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	later := time.Now().Add(48 * time.Hour)
+	sooner := time.Now().Add(time.Hour)
+	require.NoError(t, repo.CreateTask(ctx, &model.Task{UserID: 1, Title: "later", DueAt: &later}))
+	require.NoError(t, repo.CreateTask(ctx, &model.Task{UserID: 1, Title: "no due"}))
+	require.NoError(t, repo.CreateTask(ctx, &model.Task{UserID: 1, Title: "sooner", DueAt: &sooner}))
+	require.NoError(t, repo.CreateTask(ctx, &model.Task{UserID: 2, Title: "other", DueAt: &sooner}))
+
+	tasks, err := repo.ListDueByUserID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "sooner", tasks[0].Title)
+	assert.Equal(t, "later", tasks[1].Title)
+}
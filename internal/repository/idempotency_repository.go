@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+type IdempotencyRepository interface {
+	// Find 回傳尚未過期的紀錄，找不到時回傳 nil, nil。
+	Find(ctx context.Context, userID uint, key string) (*model.IdempotencyRecord, error)
+	// Reserve 以 pending 狀態佔用 key；key 已被佔用（且未過期）時回傳 false。
+	Reserve(ctx context.Context, rec *model.IdempotencyRecord) (bool, error)
+	Complete(ctx context.Context, rec *model.IdempotencyRecord) error
+	// Extend 把仍在 pending 狀態的紀錄延長到 expiresAt。
+	Extend(ctx context.Context, userID uint, key string, expiresAt time.Time) error
+	Delete(ctx context.Context, userID uint, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+func (r *idempotencyRepository) Find(ctx context.Context, userID uint, key string) (*model.IdempotencyRecord, error) {
+	var rec model.IdempotencyRecord
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND idem_key = ? AND expires_at > ?", userID, key, time.Now()).
+		First(&rec).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (r *idempotencyRepository) Reserve(ctx context.Context, rec *model.IdempotencyRecord) (bool, error) {
+	var reserved bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 同一個 key 的過期紀錄不再有效，先清掉才能重新佔用
+		if err := tx.Where("user_id = ? AND idem_key = ? AND expires_at <= ?", rec.UserID, rec.Key, time.Now()).
+			Delete(&model.IdempotencyRecord{}).Error; err != nil {
+			return err
+		}
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(rec)
+		if res.Error != nil {
+			return res.Error
+		}
+		reserved = res.RowsAffected == 1
+		return nil
+	})
+	return reserved, err
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, rec *model.IdempotencyRecord) error {
+	return r.db.WithContext(ctx).
+		Model(&model.IdempotencyRecord{}).
+		Where("user_id = ? AND idem_key = ?", rec.UserID, rec.Key).
+		Updates(map[string]interface{}{
+			"status_code":  rec.StatusCode,
+			"content_type": rec.ContentType,
+			"body":         rec.Body,
+			"expires_at":   rec.ExpiresAt,
+		}).Error
+}
+
+func (r *idempotencyRepository) Extend(ctx context.Context, userID uint, key string, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&model.IdempotencyRecord{}).
+		Where("user_id = ? AND idem_key = ? AND status_code = 0", userID, key).
+		Update("expires_at", expiresAt).Error
+}
+
+func (r *idempotencyRepository) Delete(ctx context.Context, userID uint, key string) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND idem_key = ?", userID, key).
+		Delete(&model.IdempotencyRecord{}).Error
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&model.IdempotencyRecord{})
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,79 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+func TestIdempotencyRepository_SQLite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.IdempotencyRecord{}))
+
+	repo := repository.NewIdempotencyRepository(db)
+	ctx := context.Background()
+
+	rec := &model.IdempotencyRecord{UserID: 1, Key: "k1", Fingerprint: "fp", ExpiresAt: time.Now().Add(time.Minute)}
+	ok, err := repo.Reserve(ctx, rec)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// 已被佔用
+	ok, err = repo.Reserve(ctx, &model.IdempotencyRecord{UserID: 1, Key: "k1", Fingerprint: "other", ExpiresAt: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// 不同使用者可用同一個 key
+	ok, err = repo.Reserve(ctx, &model.IdempotencyRecord{UserID: 2, Key: "k1", Fingerprint: "fp", ExpiresAt: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// 處理中的紀錄可以延長佔用
+	extended := time.Now().Add(10 * time.Minute)
+	require.NoError(t, repo.Extend(ctx, 1, "k1", extended))
+	got, err := repo.Find(ctx, 1, "k1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.WithinDuration(t, extended, got.ExpiresAt, time.Second)
+
+	require.NoError(t, repo.Complete(ctx, &model.IdempotencyRecord{
+		UserID: 1, Key: "k1", StatusCode: 201, ContentType: "application/json", Body: []byte(`{"id":1}`),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+	got, err = repo.Find(ctx, 1, "k1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "fp", got.Fingerprint)
+	assert.Equal(t, 201, got.StatusCode)
+	assert.Equal(t, `{"id":1}`, string(got.Body))
+
+	// 已完成的紀錄不會被延長
+	require.NoError(t, repo.Extend(ctx, 1, "k1", time.Now().Add(100*time.Hour)))
+	got, err = repo.Find(ctx, 1, "k1")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), got.ExpiresAt, time.Minute)
+
+	// 過期的紀錄查不到，也可以重新佔用
+	require.NoError(t, db.Model(&model.IdempotencyRecord{}).Where("user_id = ?", 2).
+		Update("expires_at", time.Now().Add(-time.Second)).Error)
+	got, err = repo.Find(ctx, 2, "k1")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	ok, err = repo.Reserve(ctx, &model.IdempotencyRecord{UserID: 2, Key: "k1", Fingerprint: "new", ExpiresAt: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, repo.Delete(ctx, 2, "k1"))
+	n, err := repo.DeleteExpired(ctx, time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *model.ImportJob) error
+	FindByID(ctx context.Context, id uint) (*model.ImportJob, error)
+	Update(ctx context.Context, job *model.ImportJob) error
+	UpdateProgress(ctx context.Context, id uint, processed int) error
+	// FailStale 把 updated_at 早於 before 仍在 pending 或 running 的工作標為 failed，回傳筆數。
+	FailStale(ctx context.Context, before time.Time, reason string) (int64, error)
+}
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository(db *gorm.DB) ImportJobRepository {
+	return &importJobRepository{db: db}
+}
+
+func (r *importJobRepository) Create(ctx context.Context, job *model.ImportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *importJobRepository) FindByID(ctx context.Context, id uint) (*model.ImportJob, error) {
+	var job model.ImportJob
+	err := r.db.WithContext(ctx).First(&job, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *importJobRepository) Update(ctx context.Context, job *model.ImportJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+// UpdateProgress 只更新已處理筆數，不覆寫其他欄位。
+func (r *importJobRepository) UpdateProgress(ctx context.Context, id uint, processed int) error {
+	return r.db.WithContext(ctx).
+		Model(&model.ImportJob{}).
+		Where("id = ?", id).
+		Update("processed", processed).Error
+}
+
+func (r *importJobRepository) FailStale(ctx context.Context, before time.Time, reason string) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Model(&model.ImportJob{}).
+		Where("status IN ? AND updated_at < ?", []string{model.ImportJobPending, model.ImportJobRunning}, before).
+		Updates(map[string]interface{}{"status": model.ImportJobFailed, "error": reason})
+	return res.RowsAffected, res.Error
+}
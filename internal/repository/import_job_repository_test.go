@@ -0,0 +1,48 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+func TestImportJobRepository_FailStale_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.ImportJob{}))
+	repo := repository.NewImportJobRepository(db)
+	ctx := context.Background()
+
+	stale := &model.ImportJob{UserID: 1, Status: model.ImportJobRunning, Total: 10}
+	active := &model.ImportJob{UserID: 1, Status: model.ImportJobRunning, Total: 10}
+	done := &model.ImportJob{UserID: 1, Status: model.ImportJobSucceeded, Total: 10}
+	for _, job := range []*model.ImportJob{stale, active, done} {
+		require.NoError(t, repo.Create(ctx, job))
+	}
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, db.Model(&model.ImportJob{}).Where("id IN ?", []uint{stale.ID, active.ID, done.ID}).
+		UpdateColumn("updated_at", old).Error)
+
+	// 進度更新會刷新 updated_at，仍在執行的工作不會被當成中斷
+	require.NoError(t, repo.UpdateProgress(ctx, active.ID, 5))
+
+	n, err := repo.FailStale(ctx, time.Now().Add(-time.Minute), "interrupted")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	got, err := repo.FindByID(ctx, stale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportJobFailed, got.Status)
+	assert.Equal(t, "interrupted", got.Error)
+	got, err = repo.FindByID(ctx, active.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportJobRunning, got.Status)
+	got, err = repo.FindByID(ctx, done.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportJobSucceeded, got.Status)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/feed_token_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SoliMark/gotasker-pro/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFeedTokenRepository is a mock of FeedTokenRepository interface.
+type MockFeedTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedTokenRepositoryMockRecorder
+}
+
+// MockFeedTokenRepositoryMockRecorder is the mock recorder for MockFeedTokenRepository.
+type MockFeedTokenRepositoryMockRecorder struct {
+	mock *MockFeedTokenRepository
+}
+
+// NewMockFeedTokenRepository creates a new mock instance.
+func NewMockFeedTokenRepository(ctrl *gomock.Controller) *MockFeedTokenRepository {
+	mock := &MockFeedTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockFeedTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedTokenRepository) EXPECT() *MockFeedTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockFeedTokenRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockFeedTokenRepositoryMockRecorder) DeleteByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockFeedTokenRepository)(nil).DeleteByUserID), ctx, userID)
+}
+
+// FindByHash mocks base method.
+func (m *MockFeedTokenRepository) FindByHash(ctx context.Context, hash string) (*model.FeedToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", ctx, hash)
+	ret0, _ := ret[0].(*model.FeedToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockFeedTokenRepositoryMockRecorder) FindByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockFeedTokenRepository)(nil).FindByHash), ctx, hash)
+}
+
+// Save mocks base method.
+func (m *MockFeedTokenRepository) Save(ctx context.Context, token *model.FeedToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockFeedTokenRepositoryMockRecorder) Save(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockFeedTokenRepository)(nil).Save), ctx, token)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/saved_view_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SoliMark/gotasker-pro/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSavedViewRepository is a mock of SavedViewRepository interface.
+type MockSavedViewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSavedViewRepositoryMockRecorder
+}
+
+// MockSavedViewRepositoryMockRecorder is the mock recorder for MockSavedViewRepository.
+type MockSavedViewRepositoryMockRecorder struct {
+	mock *MockSavedViewRepository
+}
+
+// NewMockSavedViewRepository creates a new mock instance.
+func NewMockSavedViewRepository(ctrl *gomock.Controller) *MockSavedViewRepository {
+	mock := &MockSavedViewRepository{ctrl: ctrl}
+	mock.recorder = &MockSavedViewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSavedViewRepository) EXPECT() *MockSavedViewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSavedViewRepository) Create(ctx context.Context, view *model.SavedView) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, view)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSavedViewRepositoryMockRecorder) Create(ctx, view interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSavedViewRepository)(nil).Create), ctx, view)
+}
+
+// Delete mocks base method.
+func (m *MockSavedViewRepository) Delete(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSavedViewRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSavedViewRepository)(nil).Delete), ctx, id)
+}
+
+// FindByID mocks base method.
+func (m *MockSavedViewRepository) FindByID(ctx context.Context, id uint) (*model.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*model.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockSavedViewRepositoryMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSavedViewRepository)(nil).FindByID), ctx, id)
+}
+
+// ListByUserID mocks base method.
+func (m *MockSavedViewRepository) ListByUserID(ctx context.Context, userID uint) ([]*model.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*model.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserID indicates an expected call of ListByUserID.
+func (mr *MockSavedViewRepositoryMockRecorder) ListByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockSavedViewRepository)(nil).ListByUserID), ctx, userID)
+}
+
+// Update mocks base method.
+func (m *MockSavedViewRepository) Update(ctx context.Context, view *model.SavedView) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, view)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSavedViewRepositoryMockRecorder) Update(ctx, view interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSavedViewRepository)(nil).Update), ctx, view)
+}
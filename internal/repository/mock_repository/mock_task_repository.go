@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	model "github.com/SoliMark/gotasker-pro/internal/model"
+	repository "github.com/SoliMark/gotasker-pro/internal/repository"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -35,6 +36,35 @@ func (m *MockTaskRepository) EXPECT() *MockTaskRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddTag mocks base method.
+func (m *MockTaskRepository) AddTag(ctx context.Context, task *model.Task, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTag", ctx, task, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTag indicates an expected call of AddTag.
+func (mr *MockTaskRepositoryMockRecorder) AddTag(ctx, task, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTag", reflect.TypeOf((*MockTaskRepository)(nil).AddTag), ctx, task, name)
+}
+
+// CountByUserID mocks base method.
+func (m *MockTaskRepository) CountByUserID(ctx context.Context, userID uint, filter repository.TaskFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUserID", ctx, userID, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUserID indicates an expected call of CountByUserID.
+func (mr *MockTaskRepositoryMockRecorder) CountByUserID(ctx, userID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUserID", reflect.TypeOf((*MockTaskRepository)(nil).CountByUserID), ctx, userID, filter)
+}
+
 // CreateTask mocks base method.
 func (m *MockTaskRepository) CreateTask(ctx context.Context, task *model.Task) error {
 	m.ctrl.T.Helper()
@@ -49,6 +79,20 @@ func (mr *MockTaskRepositoryMockRecorder) CreateTask(ctx, task interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskRepository)(nil).CreateTask), ctx, task)
 }
 
+// CreateTasks mocks base method.
+func (m *MockTaskRepository) CreateTasks(ctx context.Context, userID uint, tasks []*model.Task, batchSize int, progress func(int)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTasks", ctx, userID, tasks, batchSize, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTasks indicates an expected call of CreateTasks.
+func (mr *MockTaskRepositoryMockRecorder) CreateTasks(ctx, userID, tasks, batchSize, progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTasks", reflect.TypeOf((*MockTaskRepository)(nil).CreateTasks), ctx, userID, tasks, batchSize, progress)
+}
+
 // DeleteTask mocks base method.
 func (m *MockTaskRepository) DeleteTask(ctx context.Context, id uint) error {
 	m.ctrl.T.Helper()
@@ -63,6 +107,20 @@ func (mr *MockTaskRepositoryMockRecorder) DeleteTask(ctx, id interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTask), ctx, id)
 }
 
+// DeleteTaskIfUnchanged mocks base method.
+func (m *MockTaskRepository) DeleteTaskIfUnchanged(ctx context.Context, id uint, baseSeq int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskIfUnchanged", ctx, id, baseSeq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskIfUnchanged indicates an expected call of DeleteTaskIfUnchanged.
+func (mr *MockTaskRepositoryMockRecorder) DeleteTaskIfUnchanged(ctx, id, baseSeq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskIfUnchanged", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTaskIfUnchanged), ctx, id, baseSeq)
+}
+
 // FindByID mocks base method.
 func (m *MockTaskRepository) FindByID(ctx context.Context, id uint) (*model.Task, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +136,36 @@ func (mr *MockTaskRepositoryMockRecorder) FindByID(ctx, id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTaskRepository)(nil).FindByID), ctx, id)
 }
 
+// FindByIDs mocks base method.
+func (m *MockTaskRepository) FindByIDs(ctx context.Context, ids []uint) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockTaskRepositoryMockRecorder) FindByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockTaskRepository)(nil).FindByIDs), ctx, ids)
+}
+
+// ListByParentIDs mocks base method.
+func (m *MockTaskRepository) ListByParentIDs(ctx context.Context, userID uint, parentIDs []uint) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByParentIDs", ctx, userID, parentIDs)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByParentIDs indicates an expected call of ListByParentIDs.
+func (mr *MockTaskRepositoryMockRecorder) ListByParentIDs(ctx, userID, parentIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParentIDs", reflect.TypeOf((*MockTaskRepository)(nil).ListByParentIDs), ctx, userID, parentIDs)
+}
+
 // ListByUserID mocks base method.
 func (m *MockTaskRepository) ListByUserID(ctx context.Context, userID uint) ([]*model.Task, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +181,152 @@ func (mr *MockTaskRepositoryMockRecorder) ListByUserID(ctx, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockTaskRepository)(nil).ListByUserID), ctx, userID)
 }
 
+// ListChanges mocks base method.
+func (m *MockTaskRepository) ListChanges(ctx context.Context, userID uint, after repository.ChangeKey, limit int) ([]repository.TaskChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChanges", ctx, userID, after, limit)
+	ret0, _ := ret[0].([]repository.TaskChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChanges indicates an expected call of ListChanges.
+func (mr *MockTaskRepositoryMockRecorder) ListChanges(ctx, userID, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChanges", reflect.TypeOf((*MockTaskRepository)(nil).ListChanges), ctx, userID, after, limit)
+}
+
+// ListDueByUserID mocks base method.
+func (m *MockTaskRepository) ListDueByUserID(ctx context.Context, userID uint) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueByUserID indicates an expected call of ListDueByUserID.
+func (mr *MockTaskRepositoryMockRecorder) ListDueByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueByUserID", reflect.TypeOf((*MockTaskRepository)(nil).ListDueByUserID), ctx, userID)
+}
+
+// ListPage mocks base method.
+func (m *MockTaskRepository) ListPage(ctx context.Context, userID uint, q repository.TaskPageQuery) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, userID, q)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockTaskRepositoryMockRecorder) ListPage(ctx, userID, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockTaskRepository)(nil).ListPage), ctx, userID, q)
+}
+
+// ListTagsByTaskIDs mocks base method.
+func (m *MockTaskRepository) ListTagsByTaskIDs(ctx context.Context, userID uint, taskIDs []uint) (map[uint][]*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsByTaskIDs", ctx, userID, taskIDs)
+	ret0, _ := ret[0].(map[uint][]*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsByTaskIDs indicates an expected call of ListTagsByTaskIDs.
+func (mr *MockTaskRepositoryMockRecorder) ListTagsByTaskIDs(ctx, userID, taskIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsByTaskIDs", reflect.TypeOf((*MockTaskRepository)(nil).ListTagsByTaskIDs), ctx, userID, taskIDs)
+}
+
+// NextPosition mocks base method.
+func (m *MockTaskRepository) NextPosition(ctx context.Context, userID uint, pos string, excludeID uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextPosition", ctx, userID, pos, excludeID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextPosition indicates an expected call of NextPosition.
+func (mr *MockTaskRepositoryMockRecorder) NextPosition(ctx, userID, pos, excludeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextPosition", reflect.TypeOf((*MockTaskRepository)(nil).NextPosition), ctx, userID, pos, excludeID)
+}
+
+// PrevPosition mocks base method.
+func (m *MockTaskRepository) PrevPosition(ctx context.Context, userID uint, pos string, excludeID uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrevPosition", ctx, userID, pos, excludeID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrevPosition indicates an expected call of PrevPosition.
+func (mr *MockTaskRepositoryMockRecorder) PrevPosition(ctx, userID, pos, excludeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrevPosition", reflect.TypeOf((*MockTaskRepository)(nil).PrevPosition), ctx, userID, pos, excludeID)
+}
+
+// Rebalance mocks base method.
+func (m *MockTaskRepository) Rebalance(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebalance", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rebalance indicates an expected call of Rebalance.
+func (mr *MockTaskRepositoryMockRecorder) Rebalance(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebalance", reflect.TypeOf((*MockTaskRepository)(nil).Rebalance), ctx, userID)
+}
+
+// StreamByUserID mocks base method.
+func (m *MockTaskRepository) StreamByUserID(ctx context.Context, userID uint, batchSize int, fn func([]*model.Task) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamByUserID", ctx, userID, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamByUserID indicates an expected call of StreamByUserID.
+func (mr *MockTaskRepositoryMockRecorder) StreamByUserID(ctx, userID, batchSize, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamByUserID", reflect.TypeOf((*MockTaskRepository)(nil).StreamByUserID), ctx, userID, batchSize, fn)
+}
+
+// Transaction mocks base method.
+func (m *MockTaskRepository) Transaction(ctx context.Context, fn func(repository.TaskRepository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockTaskRepositoryMockRecorder) Transaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockTaskRepository)(nil).Transaction), ctx, fn)
+}
+
+// UpdatePosition mocks base method.
+func (m *MockTaskRepository) UpdatePosition(ctx context.Context, id uint, pos string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePosition", ctx, id, pos)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePosition indicates an expected call of UpdatePosition.
+func (mr *MockTaskRepositoryMockRecorder) UpdatePosition(ctx, id, pos interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePosition", reflect.TypeOf((*MockTaskRepository)(nil).UpdatePosition), ctx, id, pos)
+}
+
 // UpdateTask mocks base method.
 func (m *MockTaskRepository) UpdateTask(ctx context.Context, task *model.Task) error {
 	m.ctrl.T.Helper()
@@ -106,3 +340,17 @@ func (mr *MockTaskRepositoryMockRecorder) UpdateTask(ctx, task interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTask), ctx, task)
 }
+
+// UpdateTaskIfUnchanged mocks base method.
+func (m *MockTaskRepository) UpdateTaskIfUnchanged(ctx context.Context, task *model.Task, baseSeq int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskIfUnchanged", ctx, task, baseSeq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaskIfUnchanged indicates an expected call of UpdateTaskIfUnchanged.
+func (mr *MockTaskRepositoryMockRecorder) UpdateTaskIfUnchanged(ctx, task, baseSeq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskIfUnchanged", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTaskIfUnchanged), ctx, task, baseSeq)
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+type ProjectRepository interface {
+	Create(ctx context.Context, project *model.Project) error
+	FindByID(ctx context.Context, id uint) (*model.Project, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]*model.Project, error)
+	// ListByUserID 列出使用者擁有或是成員的專案。
+	ListByUserID(ctx context.Context, userID uint) ([]*model.Project, error)
+	// AddMember 加入成員；已經是成員時不做任何事。
+	AddMember(ctx context.Context, member *model.ProjectMember) error
+	RemoveMember(ctx context.Context, projectID, userID uint) error
+	IsMember(ctx context.Context, projectID, userID uint) (bool, error)
+	ListMembers(ctx context.Context, projectID uint) ([]*model.ProjectMember, error)
+}
+
+type projectRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) ProjectRepository {
+	return &projectRepository{db: db}
+}
+
+func (r *projectRepository) Create(ctx context.Context, project *model.Project) error {
+	return r.db.WithContext(ctx).Create(project).Error
+}
+
+func (r *projectRepository) FindByID(ctx context.Context, id uint) (*model.Project, error) {
+	var project model.Project
+	err := r.db.WithContext(ctx).First(&project, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *projectRepository) FindByIDs(ctx context.Context, ids []uint) ([]*model.Project, error) {
+	var projects []*model.Project
+	if len(ids) == 0 {
+		return projects, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&projects).Error
+	return projects, err
+}
+
+func (r *projectRepository) ListByUserID(ctx context.Context, userID uint) ([]*model.Project, error) {
+	var projects []*model.Project
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? OR id IN (?)", userID,
+			r.db.Model(&model.ProjectMember{}).Select("project_id").Where("user_id = ?", userID)).
+		Order("name ASC, id ASC").
+		Find(&projects).Error
+	return projects, err
+}
+
+func (r *projectRepository) AddMember(ctx context.Context, member *model.ProjectMember) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(member).Error
+}
+
+func (r *projectRepository) RemoveMember(ctx context.Context, projectID, userID uint) error {
+	return r.db.WithContext(ctx).
+		Where("project_id = ? AND user_id = ?", projectID, userID).
+		Delete(&model.ProjectMember{}).Error
+}
+
+func (r *projectRepository) IsMember(ctx context.Context, projectID, userID uint) (bool, error) {
+	var n int64
+	err := r.db.WithContext(ctx).Model(&model.ProjectMember{}).
+		Where("project_id = ? AND user_id = ?", projectID, userID).
+		Count(&n).Error
+	return n > 0, err
+}
+
+func (r *projectRepository) ListMembers(ctx context.Context, projectID uint) ([]*model.ProjectMember, error) {
+	var members []*model.ProjectMember
+	err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("created_at ASC, user_id ASC").
+		Find(&members).Error
+	return members, err
+}
@@ -0,0 +1,54 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+func TestProjectRepository_Members_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Project{}, &model.ProjectMember{}))
+	repo := repository.NewProjectRepository(db)
+	ctx := context.Background()
+
+	home := &model.Project{UserID: 1, Name: "home"}
+	shared := &model.Project{UserID: 2, Name: "shared"}
+	private := &model.Project{UserID: 2, Name: "private"}
+	for _, p := range []*model.Project{home, shared, private} {
+		require.NoError(t, repo.Create(ctx, p))
+	}
+
+	// 重複加入不會出錯
+	require.NoError(t, repo.AddMember(ctx, &model.ProjectMember{ProjectID: shared.ID, UserID: 1}))
+	require.NoError(t, repo.AddMember(ctx, &model.ProjectMember{ProjectID: shared.ID, UserID: 1}))
+
+	ok, err := repo.IsMember(ctx, shared.ID, 1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = repo.IsMember(ctx, private.ID, 1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	members, err := repo.ListMembers(ctx, shared.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, uint(1), members[0].UserID)
+
+	// 擁有與參與的專案都會列出
+	projects, err := repo.ListByUserID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+	assert.Equal(t, home.ID, projects[0].ID)
+	assert.Equal(t, shared.ID, projects[1].ID)
+
+	require.NoError(t, repo.RemoveMember(ctx, shared.ID, 1))
+	projects, err = repo.ListByUserID(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, projects, 1)
+}
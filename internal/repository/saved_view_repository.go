@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+type SavedViewRepository interface {
+	Create(ctx context.Context, view *model.SavedView) error
+	FindByID(ctx context.Context, id uint) (*model.SavedView, error)
+	ListByUserID(ctx context.Context, userID uint) ([]*model.SavedView, error)
+	Update(ctx context.Context, view *model.SavedView) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type savedViewRepository struct {
+	db *gorm.DB
+}
+
+func NewSavedViewRepository(db *gorm.DB) SavedViewRepository {
+	return &savedViewRepository{db: db}
+}
+
+func (r *savedViewRepository) Create(ctx context.Context, view *model.SavedView) error {
+	return r.db.WithContext(ctx).Create(view).Error
+}
+
+func (r *savedViewRepository) FindByID(ctx context.Context, id uint) (*model.SavedView, error) {
+	var view model.SavedView
+	err := r.db.WithContext(ctx).First(&view, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+func (r *savedViewRepository) ListByUserID(ctx context.Context, userID uint) ([]*model.SavedView, error) {
+	var views []*model.SavedView
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("name ASC, id ASC").
+		Find(&views).Error
+	return views, err
+}
+
+func (r *savedViewRepository) Update(ctx context.Context, view *model.SavedView) error {
+	return r.db.WithContext(ctx).Save(view).Error
+}
+
+func (r *savedViewRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&model.SavedView{}, id).Error
+}
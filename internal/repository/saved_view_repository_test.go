@@ -0,0 +1,46 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+func TestSavedViewRepository_CRUD_SQLite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.SavedView{}))
+
+	repo := repository.NewSavedViewRepository(db)
+	ctx := context.Background()
+
+	b := &model.SavedView{UserID: 1, Name: "b", Filter: "{}", Sort: "-created_at,-id"}
+	a := &model.SavedView{UserID: 1, Name: "a", Filter: "{}", Sort: "title,id"}
+	other := &model.SavedView{UserID: 2, Name: "c", Filter: "{}", Sort: "id"}
+	for _, v := range []*model.SavedView{b, a, other} {
+		require.NoError(t, repo.Create(ctx, v))
+	}
+
+	views, err := repo.ListByUserID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+	assert.Equal(t, "a", views[0].Name)
+
+	a.Name = "renamed"
+	require.NoError(t, repo.Update(ctx, a))
+	got, err := repo.FindByID(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", got.Name)
+
+	require.NoError(t, repo.Delete(ctx, a.ID))
+	got, err = repo.FindByID(ctx, a.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
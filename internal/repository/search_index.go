@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+// SearchHit 是一筆搜尋結果；Snippet 內的命中字詞以 <mark></mark> 標示，其餘內容已做 HTML escape。
+type SearchHit struct {
+	Task    *model.Task
+	Rank    float64
+	Snippet string
+}
+
+// SearchIndex 提供任務的全文檢索（標題、內容與留言）。
+// Index / Remove 由寫入路徑呼叫以保持索引同步。
+type SearchIndex interface {
+	Index(ctx context.Context, taskID uint) error
+	Remove(ctx context.Context, taskID uint) error
+	Search(ctx context.Context, userID uint, query string, limit int) ([]SearchHit, error)
+}
+
+// NewSearchIndex 依資料庫種類選擇實作：Postgres 使用 tsvector + GIN，其餘（SQLite）退回 LIKE 查詢。
+func NewSearchIndex(db *gorm.DB) (SearchIndex, error) {
+	if db.Dialector.Name() == "postgres" {
+		idx := &pgSearchIndex{db: db}
+		if err := idx.migrate(); err != nil {
+			return nil, err
+		}
+		return idx, nil
+	}
+	return &likeSearchIndex{db: db}, nil
+}
+
+// searchTerms 把查詢字串切成字詞（只保留字母與數字），並轉成小寫。
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// ==================== Postgres ====================
+
+const (
+	pgSearchHighlightStart = "\x01"
+	pgSearchHighlightStop  = "\x02"
+)
+
+type pgSearchIndex struct {
+	db *gorm.DB
+}
+
+// migrate 建立搜尋文件表與 GIN 索引；第一次建立時回填既有任務。
+func (i *pgSearchIndex) migrate() error {
+	var exists bool
+	if err := i.db.Raw("SELECT to_regclass('task_search_documents') IS NOT NULL").Scan(&exists).Error; err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	return i.db.Transaction(func(tx *gorm.DB) error {
+		stmts := []string{
+			`CREATE TABLE task_search_documents (
+				task_id BIGINT PRIMARY KEY,
+				user_id BIGINT NOT NULL,
+				title   TEXT NOT NULL DEFAULT '',
+				body    TEXT NOT NULL DEFAULT '',
+				tsv     tsvector GENERATED ALWAYS AS (
+					setweight(to_tsvector('simple', title), 'A') ||
+					setweight(to_tsvector('simple', body), 'B')
+				) STORED
+			)`,
+			`CREATE INDEX idx_task_search_documents_tsv ON task_search_documents USING GIN (tsv)`,
+			`CREATE INDEX idx_task_search_documents_user ON task_search_documents (user_id)`,
+			`INSERT INTO task_search_documents (task_id, user_id, title, body) ` + pgSearchDocumentSelect + ` GROUP BY t.id`,
+		}
+		for _, stmt := range stmts {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+const pgSearchDocumentSelect = `
+	SELECT t.id, t.user_id, t.title,
+		COALESCE(t.content, '') || ' ' || COALESCE(string_agg(c.body, ' ' ORDER BY c.id), '')
+	FROM tasks t
+	LEFT JOIN comments c ON c.task_id = t.id`
+
+func (i *pgSearchIndex) Index(ctx context.Context, taskID uint) error {
+	return i.db.WithContext(ctx).Exec(`
+		INSERT INTO task_search_documents (task_id, user_id, title, body)`+pgSearchDocumentSelect+`
+		WHERE t.id = ?
+		GROUP BY t.id
+		ON CONFLICT (task_id) DO UPDATE
+		SET user_id = EXCLUDED.user_id, title = EXCLUDED.title, body = EXCLUDED.body`, taskID).Error
+}
+
+func (i *pgSearchIndex) Remove(ctx context.Context, taskID uint) error {
+	return i.db.WithContext(ctx).Exec(`DELETE FROM task_search_documents WHERE task_id = ?`, taskID).Error
+}
+
+type pgSearchRow struct {
+	model.Task `gorm:"embedded"`
+	Rank       float64
+	Snippet    string
+}
+
+func (i *pgSearchIndex) Search(ctx context.Context, userID uint, query string, limit int) ([]SearchHit, error) {
+	tsquery := prefixTSQuery(query)
+	if tsquery == "" {
+		return nil, nil
+	}
+
+	// ts_headline 的標記先用控制字元，escape 後再換成 <mark>，避免使用者內容被當成 HTML。
+	headlineOpts := "StartSel=" + pgSearchHighlightStart + ",StopSel=" + pgSearchHighlightStop +
+		",MaxFragments=2,MaxWords=20,MinWords=5"
+
+	var rows []pgSearchRow
+	err := i.db.WithContext(ctx).Raw(`
+		SELECT t.*,
+			ts_rank_cd(d.tsv, q) AS rank,
+			ts_headline('simple', d.title || ' ' || d.body, q, ?) AS snippet
+		FROM task_search_documents d
+		JOIN tasks t ON t.id = d.task_id,
+			to_tsquery('simple', ?) q
+		WHERE d.user_id = ? AND d.tsv @@ q
+		ORDER BY rank DESC, t.id DESC
+		LIMIT ?`, headlineOpts, tsquery, userID, limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]SearchHit, 0, len(rows))
+	for j := range rows {
+		snippet := html.EscapeString(rows[j].Snippet)
+		snippet = strings.ReplaceAll(snippet, pgSearchHighlightStart, "<mark>")
+		snippet = strings.ReplaceAll(snippet, pgSearchHighlightStop, "</mark>")
+		task := rows[j].Task
+		hits = append(hits, SearchHit{Task: &task, Rank: rows[j].Rank, Snippet: snippet})
+	}
+	return hits, nil
+}
+
+// prefixTSQuery 將使用者輸入轉成「每個字詞都需以前綴命中」的 tsquery，例如 "rep fin" → "rep:* & fin:*"。
+// 只保留字母與數字，所以不會產生 tsquery 語法錯誤。
+func prefixTSQuery(query string) string {
+	terms := searchTerms(query)
+	for j := range terms {
+		terms[j] += ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// ==================== Fallback (SQLite) ====================
+
+const likeSnippetRadius = 40
+
+// likeSearchIndex 直接查詢 tasks / comments，不需要額外維護索引，適合開發與測試用的 SQLite。
+type likeSearchIndex struct {
+	db *gorm.DB
+}
+
+func (i *likeSearchIndex) Index(context.Context, uint) error  { return nil }
+func (i *likeSearchIndex) Remove(context.Context, uint) error { return nil }
+
+func (i *likeSearchIndex) Search(ctx context.Context, userID uint, query string, limit int) ([]SearchHit, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	tx := i.db.WithContext(ctx).Where("user_id = ?", userID)
+	for _, term := range terms {
+		pattern := "%" + escapeLike(term) + "%"
+		tx = tx.Where(`(LOWER(title) LIKE ? ESCAPE '\' OR LOWER(content) LIKE ? ESCAPE '\' OR id IN (`+
+			`SELECT task_id FROM comments WHERE LOWER(body) LIKE ? ESCAPE '\'))`, pattern, pattern, pattern)
+	}
+	var tasks []*model.Task
+	if err := tx.Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	hits := make([]SearchHit, 0, len(tasks))
+	for _, t := range tasks {
+		var bodies []string
+		if err := i.db.WithContext(ctx).Model(&model.Comment{}).
+			Where("task_id = ?", t.ID).Order("id ASC").Pluck("body", &bodies).Error; err != nil {
+			return nil, err
+		}
+		body := strings.TrimSpace(t.Content + " " + strings.Join(bodies, " "))
+		hits = append(hits, SearchHit{
+			Task:    t,
+			Rank:    likeRank(t.Title, body, terms),
+			Snippet: likeSnippet(t.Title+" "+body, terms),
+		})
+	}
+
+	sort.SliceStable(hits, func(a, b int) bool {
+		if hits[a].Rank != hits[b].Rank {
+			return hits[a].Rank > hits[b].Rank
+		}
+		return hits[a].Task.ID > hits[b].Task.ID
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// likeRank 以命中次數計分，標題命中的權重較高（對應 Postgres 的 A/B weight）。
+func likeRank(title, body string, terms []string) float64 {
+	title, body = strings.ToLower(title), strings.ToLower(body)
+	var rank float64
+	for _, term := range terms {
+		rank += 1.0*float64(strings.Count(title, term)) + 0.4*float64(strings.Count(body, term))
+	}
+	return rank
+}
+
+// likeSnippet 擷取第一個命中字詞附近的文字，並以 <mark> 標示所有命中。
+func likeSnippet(text string, terms []string) string {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// 少數字元轉小寫後長度會改變，此時無法對齊位置，改用原文比對
+		lower = text
+	}
+	first := -1
+	for _, term := range terms {
+		if j := strings.Index(lower, term); j >= 0 && (first < 0 || j < first) {
+			first = j
+		}
+	}
+	if first < 0 {
+		first = 0
+	}
+
+	start, end := first-likeSnippetRadius, first+likeSnippetRadius*2
+	if start < 0 {
+		start = 0
+	}
+	if end > len(text) {
+		end = len(text)
+	}
+	// 避免切在多位元組字元中間
+	for start > 0 && !isRuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !isRuneStart(text[end]) {
+		end++
+	}
+
+	fragment, lowerFragment := text[start:end], lower[start:end]
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for pos := 0; pos < len(fragment); {
+		matched := 0
+		for _, term := range terms {
+			if strings.HasPrefix(lowerFragment[pos:], term) && len(term) > matched {
+				matched = len(term)
+			}
+		}
+		if matched > 0 {
+			b.WriteString("<mark>" + html.EscapeString(fragment[pos:pos+matched]) + "</mark>")
+			pos += matched
+			continue
+		}
+		next := pos + 1
+		for next < len(fragment) && !isRuneStart(fragment[next]) {
+			next++
+		}
+		b.WriteString(html.EscapeString(fragment[pos:next]))
+		pos = next
+	}
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+func isRuneStart(b byte) bool { return b&0xC0 != 0x80 }
@@ -0,0 +1,66 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+func TestSearchIndex_LikeFallback_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Comment{}))
+	ctx := context.Background()
+
+	tasks := []*model.Task{
+		{UserID: 1, Title: "Quarterly report", Content: "draft numbers"},
+		{UserID: 1, Title: "Groceries", Content: "buy milk for the report meeting"},
+		{UserID: 1, Title: "Call plumber", Content: "kitchen sink"},
+		{UserID: 2, Title: "Report for someone else", Content: ""},
+	}
+	for _, task := range tasks {
+		require.NoError(t, db.Create(task).Error)
+	}
+	require.NoError(t, db.Create(&model.Comment{TaskID: tasks[2].ID, UserID: 1, Body: "He said <b>reporting</b> tomorrow"}).Error)
+
+	idx, err := repository.NewSearchIndex(db)
+	require.NoError(t, err)
+	require.NoError(t, idx.Index(ctx, tasks[0].ID))
+
+	// 前綴命中，標題命中排在內容 / 留言命中之前，且只回傳自己的任務
+	hits, err := idx.Search(ctx, 1, "repo", 10)
+	require.NoError(t, err)
+	require.Len(t, hits, 3)
+	assert.Equal(t, tasks[0].ID, hits[0].Task.ID)
+	assert.Contains(t, hits[0].Snippet, "<mark>repo</mark>rt")
+
+	var viaComment *repository.SearchHit
+	for i := range hits {
+		if hits[i].Task.ID == tasks[2].ID {
+			viaComment = &hits[i]
+		}
+	}
+	require.NotNil(t, viaComment)
+	assert.Contains(t, viaComment.Snippet, "<mark>repo</mark>rting")
+	assert.Contains(t, viaComment.Snippet, "&lt;b&gt;")
+
+	// 多個字詞須全部命中
+	hits, err = idx.Search(ctx, 1, "report milk", 10)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, tasks[1].ID, hits[0].Task.ID)
+
+	// limit
+	hits, err = idx.Search(ctx, 1, "repo", 1)
+	require.NoError(t, err)
+	assert.Len(t, hits, 1)
+
+	// 只有符號的查詢不會命中任何東西
+	hits, err = idx.Search(ctx, 1, "%_'", 10)
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+var ErrUnknownSortColumn = errors.New("unknown sort column")
+
+type columnKind int
+
+const (
+	kindString columnKind = iota
+	kindTime
+	kindUint
+	kindInt
+	// kindNullableTime 的 NULL 不論排序方向都排在最後；cursor 中以空字串表示 NULL。
+	kindNullableTime
+)
+
+// taskSortColumns 是允許排序的欄位（allowlist），只有這裡列出的欄位名稱會被拼進 SQL。
+var taskSortColumns = map[string]columnKind{
+	"id":         kindUint,
+	"title":      kindString,
+	"status":     kindString,
+	"position":   kindString,
+	"priority":   kindInt,
+	"due_at":     kindNullableTime,
+	"created_at": kindTime,
+	"updated_at": kindTime,
+}
+
+// IsTaskSortColumn 判斷欄位是否在排序 allowlist 內。
+func IsTaskSortColumn(name string) bool {
+	_, ok := taskSortColumns[name]
+	return ok
+}
+
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// TaskFilter 描述列表的篩選條件；零值欄位表示不篩選。
+type TaskFilter struct {
+	Statuses      []string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+	TitlePrefix   string
+}
+
+// IsZero 判斷是否沒有任何篩選條件。
+func (f TaskFilter) IsZero() bool {
+	return len(f.Statuses) == 0 &&
+		f.CreatedAfter == nil && f.CreatedBefore == nil &&
+		f.UpdatedAfter == nil && f.UpdatedBefore == nil &&
+		f.TitlePrefix == ""
+}
+
+// Scope 將篩選條件轉成 GORM scope，所有值都以參數綁定。
+func (f TaskFilter) Scope(db *gorm.DB) *gorm.DB {
+	if len(f.Statuses) > 0 {
+		db = db.Where("status IN ?", f.Statuses)
+	}
+	if f.CreatedAfter != nil {
+		db = db.Where("created_at >= ?", *f.CreatedAfter)
+	}
+	if f.CreatedBefore != nil {
+		db = db.Where("created_at < ?", *f.CreatedBefore)
+	}
+	if f.UpdatedAfter != nil {
+		db = db.Where("updated_at >= ?", *f.UpdatedAfter)
+	}
+	if f.UpdatedBefore != nil {
+		db = db.Where("updated_at < ?", *f.UpdatedBefore)
+	}
+	if f.TitlePrefix != "" {
+		db = db.Where(`title LIKE ? ESCAPE '\'`, escapeLike(f.TitlePrefix)+"%")
+	}
+	return db
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// orderScope 依排序欄位產生 ORDER BY；欄位必須在 allowlist 內。
+func orderScope(sort []SortField) (func(*gorm.DB) *gorm.DB, error) {
+	parts := make([]string, 0, len(sort))
+	for _, f := range sort {
+		if !IsTaskSortColumn(f.Column) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSortColumn, f.Column)
+		}
+		dir := "ASC"
+		if f.Desc {
+			dir = "DESC"
+		}
+		if taskSortColumns[f.Column] == kindNullableTime {
+			// 明確指定 NULL 的位置，PostgreSQL 與 SQLite 的預設不同
+			parts = append(parts, f.Column+" IS NULL")
+		}
+		parts = append(parts, f.Column+" "+dir)
+	}
+	order := strings.Join(parts, ", ")
+	return func(db *gorm.DB) *gorm.DB { return db.Order(order) }, nil
+}
+
+// keysetScope 產生「排在 after 之後」的條件：
+// (c1 > v1) OR (c1 = v1 AND c2 > v2) OR ...，方向依各欄位的 ASC/DESC 決定。
+func keysetScope(sort []SortField, after []string) (func(*gorm.DB) *gorm.DB, error) {
+	if len(after) != len(sort) {
+		return nil, errors.New("keyset: value count does not match sort fields")
+	}
+
+	values := make([]interface{}, len(after))
+	for i, f := range sort {
+		kind, ok := taskSortColumns[f.Column]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSortColumn, f.Column)
+		}
+		v, err := parseSortValue(kind, after[i])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	var (
+		ors  []string
+		args []interface{}
+	)
+	for i, f := range sort {
+		var ands []string
+		for j := 0; j < i; j++ {
+			if values[j] == nil {
+				ands = append(ands, sort[j].Column+" IS NULL")
+				continue
+			}
+			ands = append(ands, sort[j].Column+" = ?")
+			args = append(args, values[j])
+		}
+		op := " > ?"
+		if f.Desc {
+			op = " < ?"
+		}
+		switch {
+		case values[i] == nil:
+			// NULL 排在最後，這個欄位上沒有更後面的值
+			continue
+		case taskSortColumns[f.Column] == kindNullableTime:
+			ands = append(ands, "("+f.Column+op+" OR "+f.Column+" IS NULL)")
+		default:
+			ands = append(ands, f.Column+op)
+		}
+		args = append(args, values[i])
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+	if len(ors) == 0 {
+		return func(db *gorm.DB) *gorm.DB { return db.Where("1 = 0") }, nil
+	}
+	cond := strings.Join(ors, " OR ")
+	return func(db *gorm.DB) *gorm.DB { return db.Where(cond, args...) }, nil
+}
+
+func parseSortValue(kind columnKind, s string) (interface{}, error) {
+	switch kind {
+	case kindTime:
+		return time.Parse(time.RFC3339Nano, s)
+	case kindNullableTime:
+		if s == "" {
+			return nil, nil
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case kindUint:
+		return strconv.ParseUint(s, 10, 64)
+	case kindInt:
+		return strconv.ParseInt(s, 10, 64)
+	default:
+		return s, nil
+	}
+}
+
+// SortValues 取出 task 在各排序欄位上的值，作為下一頁 keyset 的起點。
+func SortValues(t *model.Task, sort []SortField) []string {
+	out := make([]string, len(sort))
+	for i, f := range sort {
+		switch f.Column {
+		case "id":
+			out[i] = strconv.FormatUint(uint64(t.ID), 10)
+		case "title":
+			out[i] = t.Title
+		case "status":
+			out[i] = t.Status
+		case "position":
+			out[i] = t.Position
+		case "priority":
+			out[i] = strconv.Itoa(t.Priority)
+		case "due_at":
+			if t.DueAt != nil {
+				out[i] = t.DueAt.Format(time.RFC3339Nano)
+			}
+		case "created_at":
+			out[i] = t.CreatedAt.Format(time.RFC3339Nano)
+		case "updated_at":
+			out[i] = t.UpdatedAt.Format(time.RFC3339Nano)
+		}
+	}
+	return out
+}
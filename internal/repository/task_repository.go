@@ -2,18 +2,58 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/util"
 )
 
+// TaskPageQuery 描述一次 keyset 分頁查詢。Sort 的最後一個欄位必須能唯一決定順序（通常是 id）；
+// After 為上一頁最後一筆在各排序欄位上的值（見 SortValues），nil 表示第一頁。
+type TaskPageQuery struct {
+	Filter TaskFilter
+	Sort   []SortField
+	Limit  int
+	After  []string
+}
+
 type TaskRepository interface {
 	CreateTask(ctx context.Context, task *model.Task) error
 	FindByID(ctx context.Context, id uint) (*model.Task, error)
 	UpdateTask(ctx context.Context, task *model.Task) error
 	DeleteTask(ctx context.Context, id uint) error
+	// UpdateTaskIfUnchanged 與 UpdateTask 相同，但只在任務目前的 change_seq 不大於 baseSeq 時寫入，
+	// 檢查與寫入在同一個 UPDATE 完成；任務已被修改或刪除時回傳 ErrTaskChanged。
+	UpdateTaskIfUnchanged(ctx context.Context, task *model.Task, baseSeq int64) error
+	// DeleteTaskIfUnchanged 與 DeleteTask 相同，條件同 UpdateTaskIfUnchanged。
+	DeleteTaskIfUnchanged(ctx context.Context, id uint, baseSeq int64) error
 	ListByUserID(ctx context.Context, userID uint) ([]*model.Task, error)
+	PrevPosition(ctx context.Context, userID uint, pos string, excludeID uint) (string, error)
+	NextPosition(ctx context.Context, userID uint, pos string, excludeID uint) (string, error)
+	UpdatePosition(ctx context.Context, id uint, pos string) error
+	Rebalance(ctx context.Context, userID uint) error
+	ListPage(ctx context.Context, userID uint, q TaskPageQuery) ([]*model.Task, error)
+	CountByUserID(ctx context.Context, userID uint, filter TaskFilter) (int64, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]*model.Task, error)
+	// ListDueByUserID 回傳使用者有截止時間的任務，依截止時間排序。
+	ListDueByUserID(ctx context.Context, userID uint) ([]*model.Task, error)
+	// StreamByUserID 依 id 順序分批讀出使用者的任務，每批交給 fn 處理，不會一次載入全部。
+	StreamByUserID(ctx context.Context, userID uint, batchSize int, fn func(batch []*model.Task) error) error
+	AddTag(ctx context.Context, task *model.Task, name string) error
+	// ListByParentIDs 一次查出多個任務中屬於 userID 的子任務，依 position 排序。
+	ListByParentIDs(ctx context.Context, userID uint, parentIDs []uint) ([]*model.Task, error)
+	// ListTagsByTaskIDs 一次查出多個任務上屬於 userID 的標籤，依任務 id 分組、標籤名稱排序。
+	ListTagsByTaskIDs(ctx context.Context, userID uint, taskIDs []uint) (map[uint][]*model.Tag, error)
+	// CreateTasks 把 tasks 依序接在使用者清單的最後面，每 batchSize 筆寫入一次並以已寫入筆數呼叫 progress。
+	// 本身不開交易，需要全有或全無時請在 Transaction 內呼叫。
+	CreateTasks(ctx context.Context, userID uint, tasks []*model.Task, batchSize int, progress func(done int)) error
+	// ListChanges 依 (change_seq, id) 順序回傳 after 之後異動過的任務與刪除紀錄，最多 limit 筆。
+	ListChanges(ctx context.Context, userID uint, after ChangeKey, limit int) ([]TaskChange, error)
+	// Transaction 在同一個交易中執行 fn；在 fn 內再呼叫 tx.Transaction 會建立 savepoint。
+	Transaction(ctx context.Context, fn func(tx TaskRepository) error) error
 }
 
 type taskRepository struct {
@@ -24,8 +64,42 @@ func NewTaskRepository(db *gorm.DB) TaskRepository {
 	return &taskRepository{db: db}
 }
 
+// CreateTask 新增任務；未指定 Position 時排在使用者清單的最後面。
 func (r *taskRepository) CreateTask(ctx context.Context, task *model.Task) error {
-	return r.db.WithContext(ctx).Create(task).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		seq, err := nextChangeSeq(tx, task.UserID)
+		if err != nil {
+			return err
+		}
+		task.ChangeSeq = seq
+
+		if task.Position == "" {
+			last, err := lastPosition(tx, task.UserID)
+			if err != nil {
+				return err
+			}
+			if task.Position, err = util.RankAfter(last); err != nil {
+				return err
+			}
+		}
+		return tx.Create(task).Error
+	})
+}
+
+// lastPosition 鎖住使用者的 sync_counters 列後回傳目前最大的 position，必須在 nextChangeSeq 之後、
+// 同一個交易內呼叫。鎖會保留到交易結束，同一使用者的並發新增在這裡排隊，讀到的 MAX 已包含先提交的任務。
+func lastPosition(tx *gorm.DB, userID uint) (string, error) {
+	var counter model.SyncCounter
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&counter, "user_id = ?", userID).Error
+	if err != nil {
+		return "", err
+	}
+	var last string
+	err = tx.Model(&model.Task{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(MAX(position), '')").
+		Scan(&last).Error
+	return last, err
 }
 
 func (r *taskRepository) FindByID(ctx context.Context, id uint) (*model.Task, error) {
@@ -41,12 +115,203 @@ func (r *taskRepository) FindByID(ctx context.Context, id uint) (*model.Task, er
 	return &task, nil
 }
 
+// taskUpdateColumns 是 UpdateTask 寫入的欄位。position 由 UpdatePosition 維護、標籤由 AddTag 等維護，
+// 不用 Save 寫回整列，呼叫端的任務是較舊的副本時也不會蓋掉並發的移動或標籤變更。
+var taskUpdateColumns = []string{
+	"title", "content", "status", "project_id", "priority", "due_at", "completed_at", "change_seq", "updated_at",
+}
+
 func (r *taskRepository) UpdateTask(ctx context.Context, task *model.Task) error {
-	return r.db.WithContext(ctx).Save(task).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		seq, err := nextChangeSeq(tx, task.UserID)
+		if err != nil {
+			return err
+		}
+		task.ChangeSeq = seq
+		return tx.Model(task).Select(taskUpdateColumns).Updates(task).Error
+	})
 }
 
+func (r *taskRepository) UpdateTaskIfUnchanged(ctx context.Context, task *model.Task, baseSeq int64) error {
+	prev := task.ChangeSeq
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		seq, err := nextChangeSeq(tx, task.UserID)
+		if err != nil {
+			return err
+		}
+		task.ChangeSeq = seq
+		res := tx.Model(task).Where("change_seq <= ?", baseSeq).Select(taskUpdateColumns).Updates(task)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrTaskChanged
+		}
+		return nil
+	})
+	if err != nil {
+		task.ChangeSeq = prev
+	}
+	return err
+}
+
+// DeleteTask 刪除任務與其標籤關聯（標籤本身保留），並留下供增量同步使用的刪除紀錄。
 func (r *taskRepository) DeleteTask(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&model.Task{}, id).Error
+	return r.deleteTask(ctx, id, nil)
+}
+
+func (r *taskRepository) DeleteTaskIfUnchanged(ctx context.Context, id uint, baseSeq int64) error {
+	return r.deleteTask(ctx, id, &baseSeq)
+}
+
+// deleteTask 在 baseSeq 不為 nil 時只刪除 change_seq 不大於 baseSeq 的任務；不符合時整個交易回滾。
+func (r *taskRepository) deleteTask(ctx context.Context, id uint, baseSeq *int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		userID, ok, err := taskOwner(tx, id)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if baseSeq != nil {
+				return ErrTaskChanged
+			}
+			return nil
+		}
+		seq, err := nextChangeSeq(tx, userID)
+		if err != nil {
+			return err
+		}
+		tomb := model.TaskTombstone{UserID: userID, TaskID: id, ChangeSeq: seq, DeletedAt: time.Now()}
+		if err := tx.Create(&tomb).Error; err != nil {
+			return err
+		}
+		q := tx.Select("Tags")
+		if baseSeq != nil {
+			q = q.Where("change_seq <= ?", *baseSeq)
+		}
+		res := q.Delete(&model.Task{ID: id})
+		if res.Error == nil && baseSeq != nil && res.RowsAffected == 0 {
+			return ErrTaskChanged
+		}
+		if res.Error != nil {
+			return res.Error
+		}
+		// 子任務保留並改為頂層任務，流水號與刪除相同，增量同步會帶出這些變更
+		return tx.Model(&model.Task{}).Where("parent_id = ?", id).
+			Updates(map[string]interface{}{"parent_id": nil, "change_seq": seq}).Error
+	})
+}
+
+func (r *taskRepository) ListByParentIDs(ctx context.Context, userID uint, parentIDs []uint) ([]*model.Task, error) {
+	var tasks []*model.Task
+	if len(parentIDs) == 0 {
+		return tasks, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND parent_id IN ?", userID, parentIDs).
+		Order("position ASC, id ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) FindByIDs(ctx context.Context, ids []uint) ([]*model.Task, error) {
+	var tasks []*model.Task
+	if len(ids) == 0 {
+		return tasks, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) ListDueByUserID(ctx context.Context, userID uint) ([]*model.Task, error) {
+	var tasks []*model.Task
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND due_at IS NOT NULL", userID).
+		Order("due_at ASC, id ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) StreamByUserID(ctx context.Context, userID uint, batchSize int, fn func(batch []*model.Task) error) error {
+	var batch []*model.Task
+	return r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
+// AddTag 為任務加上標籤；標籤以 (user_id, name) 查找，不存在時建立。
+func (r *taskRepository) AddTag(ctx context.Context, task *model.Task, name string) error {
+	db := r.db.WithContext(ctx)
+	tag := model.Tag{UserID: task.UserID, Name: name}
+	if err := db.Where("user_id = ? AND name = ?", task.UserID, name).FirstOrCreate(&tag).Error; err != nil {
+		return err
+	}
+	return db.Model(task).Association("Tags").Append(&tag)
+}
+
+func (r *taskRepository) ListTagsByTaskIDs(ctx context.Context, userID uint, taskIDs []uint) (map[uint][]*model.Tag, error) {
+	res := make(map[uint][]*model.Tag, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return res, nil
+	}
+	var rows []struct {
+		TaskID uint
+		model.Tag
+	}
+	err := r.db.WithContext(ctx).
+		Table("tags").
+		Select("task_tags.task_id, tags.*").
+		Joins("JOIN task_tags ON task_tags.tag_id = tags.id").
+		Where("tags.user_id = ? AND task_tags.task_id IN ?", userID, taskIDs).
+		Order("tags.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		res[rows[i].TaskID] = append(res[rows[i].TaskID], &rows[i].Tag)
+	}
+	return res, nil
+}
+
+func (r *taskRepository) CreateTasks(ctx context.Context, userID uint, tasks []*model.Task, batchSize int, progress func(done int)) error {
+	return r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		seq, err := nextChangeSeq(db, userID)
+		if err != nil {
+			return err
+		}
+		last, err := lastPosition(db, userID)
+		if err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			t.UserID = userID
+			t.ChangeSeq = seq
+			if t.Position, err = util.RankAfter(last); err != nil {
+				return err
+			}
+			last = t.Position
+		}
+
+		for start := 0; start < len(tasks); start += batchSize {
+			end := min(start+batchSize, len(tasks))
+			if err := db.Create(tasks[start:end]).Error; err != nil {
+				return err
+			}
+			if progress != nil {
+				progress(end)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *taskRepository) Transaction(ctx context.Context, fn func(tx TaskRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&taskRepository{db: tx})
+	})
 }
 
 func (r *taskRepository) ListByUserID(ctx context.Context, userID uint) ([]*model.Task, error) {
@@ -57,3 +322,100 @@ func (r *taskRepository) ListByUserID(ctx context.Context, userID uint) ([]*mode
 		Find(&tasks).Error
 	return tasks, err
 }
+
+// PrevPosition 回傳小於 pos 的最大 position（排除 excludeID），沒有時回傳空字串。
+func (r *taskRepository) PrevPosition(ctx context.Context, userID uint, pos string, excludeID uint) (string, error) {
+	var prev string
+	err := r.db.WithContext(ctx).
+		Model(&model.Task{}).
+		Where("user_id = ? AND position < ? AND id <> ?", userID, pos, excludeID).
+		Select("COALESCE(MAX(position), '')").
+		Scan(&prev).Error
+	return prev, err
+}
+
+// NextPosition 回傳大於 pos 的最小 position（排除 excludeID），沒有時回傳空字串。
+func (r *taskRepository) NextPosition(ctx context.Context, userID uint, pos string, excludeID uint) (string, error) {
+	var next string
+	err := r.db.WithContext(ctx).
+		Model(&model.Task{}).
+		Where("user_id = ? AND position > ? AND id <> ?", userID, pos, excludeID).
+		Select("COALESCE(MIN(position), '')").
+		Scan(&next).Error
+	return next, err
+}
+
+func (r *taskRepository) UpdatePosition(ctx context.Context, id uint, pos string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		userID, ok, err := taskOwner(tx, id)
+		if err != nil || !ok {
+			return err
+		}
+		seq, err := nextChangeSeq(tx, userID)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model.Task{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{"position": pos, "change_seq": seq}).Error
+	})
+}
+
+// Rebalance 依目前順序重新配置使用者所有任務的 position，讓 rank 回到短且等距的狀態。
+func (r *taskRepository) Rebalance(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		err := tx.Model(&model.Task{}).
+			Where("user_id = ?", userID).
+			Order("position ASC, id ASC").
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		seq, err := nextChangeSeq(tx, userID)
+		if err != nil {
+			return err
+		}
+		for i, pos := range util.EvenRanks(len(ids)) {
+			err := tx.Model(&model.Task{}).
+				Where("id = ?", ids[i]).
+				Updates(map[string]interface{}{"position": pos, "change_seq": seq}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *taskRepository) ListPage(ctx context.Context, userID uint, q TaskPageQuery) ([]*model.Task, error) {
+	order, err := orderScope(q.Sort)
+	if err != nil {
+		return nil, err
+	}
+	tx := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Scopes(q.Filter.Scope, order)
+
+	if q.After != nil {
+		keyset, err := keysetScope(q.Sort, q.After)
+		if err != nil {
+			return nil, err
+		}
+		tx = tx.Scopes(keyset)
+	}
+
+	var tasks []*model.Task
+	err = tx.Limit(q.Limit).Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) CountByUserID(ctx context.Context, userID uint, filter TaskFilter) (int64, error) {
+	var n int64
+	err := r.db.WithContext(ctx).
+		Model(&model.Task{}).
+		Where("user_id = ?", userID).
+		Scopes(filter.Scope).
+		Count(&n).Error
+	return n, err
+}
@@ -0,0 +1,210 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+func TestTaskRepository_TransactionAndTags_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Tag{}))
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	a := &model.Task{UserID: 1, Title: "a", Status: model.TaskStatusPending}
+	b := &model.Task{UserID: 1, Title: "b", Status: model.TaskStatusPending}
+	require.NoError(t, repo.CreateTask(ctx, a))
+	require.NoError(t, repo.CreateTask(ctx, b))
+
+	got, err := repo.FindByIDs(ctx, []uint{a.ID, b.ID, 999})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	// 巢狀交易（savepoint）失敗只回滾自己的變更
+	err = repo.Transaction(ctx, func(tx repository.TaskRepository) error {
+		a.Status = model.TaskStatusDone
+		require.NoError(t, tx.UpdateTask(ctx, a))
+		inner := tx.Transaction(ctx, func(tx repository.TaskRepository) error {
+			require.NoError(t, tx.DeleteTask(ctx, b.ID))
+			return errors.New("boom")
+		})
+		assert.Error(t, inner)
+		return tx.AddTag(ctx, a, "urgent")
+	})
+	require.NoError(t, err)
+
+	stillThere, err := repo.FindByID(ctx, b.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, stillThere)
+
+	// 同名標籤重複使用
+	require.NoError(t, repo.AddTag(ctx, b, "urgent"))
+	var tags int64
+	db.Model(&model.Tag{}).Count(&tags)
+	assert.Equal(t, int64(1), tags)
+
+	var reloaded model.Task
+	require.NoError(t, db.Preload("Tags").First(&reloaded, a.ID).Error)
+	assert.Equal(t, model.TaskStatusDone, reloaded.Status)
+	require.Len(t, reloaded.Tags, 1)
+	assert.Equal(t, "urgent", reloaded.Tags[0].Name)
+
+	// 外層交易失敗時全部回滾
+	err = repo.Transaction(ctx, func(tx repository.TaskRepository) error {
+		require.NoError(t, tx.DeleteTask(ctx, a.ID))
+		return errors.New("abort")
+	})
+	assert.Error(t, err)
+	stillThere, err = repo.FindByID(ctx, a.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, stillThere)
+
+	// 刪除任務一併移除標籤關聯
+	require.NoError(t, repo.DeleteTask(ctx, a.ID))
+	var links int64
+	db.Table("task_tags").Where("task_id = ?", a.ID).Count(&links)
+	assert.Zero(t, links)
+}
+
+func TestTaskRepository_ListTagsByTaskIDs_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Tag{}))
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	a := &model.Task{UserID: 1, Title: "a", Status: model.TaskStatusPending}
+	b := &model.Task{UserID: 1, Title: "b", Status: model.TaskStatusPending}
+	other := &model.Task{UserID: 2, Title: "other", Status: model.TaskStatusPending}
+	for _, task := range []*model.Task{a, b, other} {
+		require.NoError(t, repo.CreateTask(ctx, task))
+	}
+	require.NoError(t, repo.AddTag(ctx, a, "work"))
+	require.NoError(t, repo.AddTag(ctx, a, "urgent"))
+	require.NoError(t, repo.AddTag(ctx, b, "work"))
+	require.NoError(t, repo.AddTag(ctx, other, "work"))
+
+	tags, err := repo.ListTagsByTaskIDs(ctx, 1, []uint{a.ID, b.ID, other.ID})
+	require.NoError(t, err)
+	require.Len(t, tags[a.ID], 2)
+	assert.Equal(t, "urgent", tags[a.ID][0].Name)
+	assert.Equal(t, "work", tags[a.ID][1].Name)
+	require.Len(t, tags[b.ID], 1)
+	assert.Equal(t, tags[a.ID][1].ID, tags[b.ID][0].ID)
+	// 其他使用者的標籤不會被查出
+	assert.Empty(t, tags[other.ID])
+}
+
+func TestTaskRepository_Subtasks_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	parent := &model.Task{UserID: 1, Title: "parent"}
+	require.NoError(t, repo.CreateTask(ctx, parent))
+	first := &model.Task{UserID: 1, Title: "first", ParentID: &parent.ID}
+	second := &model.Task{UserID: 1, Title: "second", ParentID: &parent.ID}
+	other := &model.Task{UserID: 2, Title: "other", ParentID: &parent.ID}
+	for _, task := range []*model.Task{first, second, other} {
+		require.NoError(t, repo.CreateTask(ctx, task))
+	}
+
+	subtasks, err := repo.ListByParentIDs(ctx, 1, []uint{parent.ID})
+	require.NoError(t, err)
+	// 依 position 排序，其他使用者的任務不會被查出
+	require.Len(t, subtasks, 2)
+	assert.Equal(t, first.ID, subtasks[0].ID)
+	assert.Equal(t, second.ID, subtasks[1].ID)
+
+	// 刪除父任務後子任務留下並成為頂層任務
+	require.NoError(t, repo.DeleteTask(ctx, parent.ID))
+	detached, err := repo.FindByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Nil(t, detached.ParentID)
+	assert.Greater(t, detached.ChangeSeq, first.ChangeSeq)
+}
+
+func TestTaskRepository_StreamByUserID_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	for i := 0; i < 7; i++ {
+		require.NoError(t, repo.CreateTask(ctx, &model.Task{UserID: 1, Title: "t"}))
+	}
+	require.NoError(t, repo.CreateTask(ctx, &model.Task{UserID: 2, Title: "other"}))
+
+	var sizes []int
+	var ids []uint
+	err := repo.StreamByUserID(ctx, 1, 3, func(batch []*model.Task) error {
+		sizes = append(sizes, len(batch))
+		for _, task := range batch {
+			ids = append(ids, task.ID)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 3, 1}, sizes)
+	assert.Len(t, ids, 7)
+	for i := 1; i < len(ids); i++ {
+		assert.Less(t, ids[i-1], ids[i])
+	}
+
+	// fn 回傳錯誤時停止
+	stop := errors.New("stop")
+	calls := 0
+	err = repo.StreamByUserID(ctx, 1, 3, func([]*model.Task) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestTaskRepository_CreateTasks_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	existing := &model.Task{UserID: 1, Title: "existing", Status: model.TaskStatusPending}
+	require.NoError(t, repo.CreateTask(ctx, existing))
+
+	tasks := []*model.Task{
+		{Title: "a", Status: model.TaskStatusPending},
+		{Title: "b", Status: model.TaskStatusPending},
+		{Title: "c", Status: model.TaskStatusPending},
+	}
+	var progress []int
+	err := repo.Transaction(ctx, func(tx repository.TaskRepository) error {
+		return tx.CreateTasks(ctx, 1, tasks, 2, func(done int) { progress = append(progress, done) })
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, progress)
+
+	// 依序接在既有任務之後
+	prev := existing.Position
+	for _, task := range tasks {
+		assert.NotZero(t, task.ID)
+		assert.Equal(t, uint(1), task.UserID)
+		assert.Greater(t, task.Position, prev)
+		prev = task.Position
+	}
+
+	// 交易失敗時整批回滾
+	err = repo.Transaction(ctx, func(tx repository.TaskRepository) error {
+		if err := tx.CreateTasks(ctx, 1, []*model.Task{{Title: "d"}}, 10, nil); err != nil {
+			return err
+		}
+		return errors.New("boom")
+	})
+	assert.Error(t, err)
+	n, err := repo.CountByUserID(ctx, 1, repository.TaskFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+}
@@ -0,0 +1,146 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+func TestTaskRepository_ListChanges_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	a := &model.Task{UserID: 1, Title: "a"}
+	b := &model.Task{UserID: 1, Title: "b"}
+	other := &model.Task{UserID: 2, Title: "other"}
+	require.NoError(t, repo.CreateTask(ctx, a))
+	require.NoError(t, repo.CreateTask(ctx, b))
+	require.NoError(t, repo.CreateTask(ctx, other))
+	assert.Equal(t, int64(1), a.ChangeSeq)
+	assert.Equal(t, int64(2), b.ChangeSeq)
+	assert.Equal(t, int64(1), other.ChangeSeq, "流水號依使用者各自遞增")
+
+	changes, err := repo.ListChanges(ctx, 1, repository.ChangeKey{}, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, a.ID, changes[0].Task.ID)
+	since := changes[1].Key
+
+	// 修改、搬移、刪除都會產生新的流水號
+	a.Title = "a2"
+	require.NoError(t, repo.UpdateTask(ctx, a))
+	require.NoError(t, repo.UpdatePosition(ctx, a.ID, "zzz"))
+	require.NoError(t, repo.DeleteTask(ctx, b.ID))
+	imported := []*model.Task{{Title: "c"}, {Title: "d"}}
+	require.NoError(t, repo.CreateTasks(ctx, 1, imported, 10, nil))
+
+	changes, err = repo.ListChanges(ctx, 1, since, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+	assert.Equal(t, a.ID, changes[0].Task.ID)
+	assert.Equal(t, int64(4), changes[0].Key.Seq)
+	assert.Equal(t, "zzz", changes[0].Task.Position)
+	assert.Nil(t, changes[1].Task)
+	assert.Equal(t, b.ID, changes[1].Key.ID)
+	assert.NotNil(t, changes[1].DeletedAt)
+	// 同一次匯入共用流水號，以 id 分出先後
+	assert.Equal(t, changes[2].Key.Seq, changes[3].Key.Seq)
+	assert.Equal(t, imported[0].ID, changes[2].Task.ID)
+
+	// keyset 可以從同一個流水號的中間接續
+	page, err := repo.ListChanges(ctx, 1, changes[2].Key, 10)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, imported[1].ID, page[0].Task.ID)
+
+	page, err = repo.ListChanges(ctx, 1, since, 2)
+	require.NoError(t, err)
+	assert.Len(t, page, 2)
+
+	// 刪除不存在的任務不會留下紀錄
+	require.NoError(t, repo.DeleteTask(ctx, 999))
+	var tombs int64
+	db.Model(&model.TaskTombstone{}).Count(&tombs)
+	assert.Equal(t, int64(1), tombs)
+}
+
+func TestTaskRepository_WriteIfUnchanged_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Tag{}))
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := &model.Task{UserID: 1, Title: "a"}
+	require.NoError(t, repo.CreateTask(ctx, task))
+	base := task.ChangeSeq
+
+	// 其他請求先寫入：base 之後的條件式寫入不會套用，也不會消耗流水號
+	other := *task
+	other.Title = "server"
+	require.NoError(t, repo.UpdateTask(ctx, &other))
+
+	stale := *task
+	stale.Title = "offline"
+	assert.ErrorIs(t, repo.UpdateTaskIfUnchanged(ctx, &stale, base), repository.ErrTaskChanged)
+	assert.Equal(t, base, stale.ChangeSeq)
+	assert.ErrorIs(t, repo.DeleteTaskIfUnchanged(ctx, task.ID, base), repository.ErrTaskChanged)
+	got, err := repo.FindByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "server", got.Title)
+
+	// 以最新的流水號為 base 時正常寫入
+	fresh := *got
+	fresh.Title = "offline"
+	require.NoError(t, repo.UpdateTaskIfUnchanged(ctx, &fresh, got.ChangeSeq))
+	assert.Greater(t, fresh.ChangeSeq, got.ChangeSeq)
+	require.NoError(t, repo.DeleteTaskIfUnchanged(ctx, task.ID, fresh.ChangeSeq))
+	got, err = repo.FindByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// 已刪除的任務
+	assert.ErrorIs(t, repo.UpdateTaskIfUnchanged(ctx, &fresh, fresh.ChangeSeq), repository.ErrTaskChanged)
+	assert.ErrorIs(t, repo.DeleteTaskIfUnchanged(ctx, task.ID, fresh.ChangeSeq), repository.ErrTaskChanged)
+
+	var tombs int64
+	db.Model(&model.TaskTombstone{}).Count(&tombs)
+	assert.Equal(t, int64(1), tombs)
+}
+
+func TestTaskRepository_UpdateTaskWithStaleCopy_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Tag{}))
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := &model.Task{UserID: 1, Title: "a"}
+	require.NoError(t, repo.CreateTask(ctx, task))
+	stale := *task
+
+	// 讀出副本之後任務被移動、加上標籤：以副本更新只寫入可修改的欄位
+	require.NoError(t, repo.UpdatePosition(ctx, task.ID, "m"))
+	require.NoError(t, repo.AddTag(ctx, task, "work"))
+	stale.Title = "b"
+	require.NoError(t, repo.UpdateTask(ctx, &stale))
+
+	got, err := repo.FindByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "b", got.Title)
+	assert.Equal(t, "m", got.Position)
+	var links int64
+	db.Table("task_tags").Where("task_id = ?", task.ID).Count(&links)
+	assert.Equal(t, int64(1), links)
+
+	// 已刪除的任務不會被更新重新建立
+	require.NoError(t, repo.DeleteTask(ctx, task.ID))
+	require.NoError(t, repo.UpdateTask(ctx, &stale))
+	got, err = repo.FindByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -2,9 +2,14 @@ package repository_test
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
@@ -17,8 +22,8 @@ func setupSQLiteTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	assert.NoError(t, err)
 
-	// 自動 migrate Task model
-	err = db.AutoMigrate(&model.Task{})
+	// 自動 migrate Task model 與同步用的流水號、刪除紀錄
+	err = db.AutoMigrate(&model.Task{}, &model.SyncCounter{}, &model.TaskTombstone{})
 	assert.NoError(t, err)
 
 	return db
@@ -67,3 +72,307 @@ func TestTaskRepository_CRUD_SQLite(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, deleted)
 }
+
+func TestTaskRepository_Positions_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	// 新增的任務依序排在最後
+	var tasks []*model.Task
+	for _, title := range []string{"A", "B", "C"} {
+		task := &model.Task{UserID: 1, Title: title, Status: "pending"}
+		assert.NoError(t, repo.CreateTask(ctx, task))
+		tasks = append(tasks, task)
+	}
+	assert.Less(t, tasks[0].Position, tasks[1].Position)
+	assert.Less(t, tasks[1].Position, tasks[2].Position)
+
+	prev, err := repo.PrevPosition(ctx, 1, tasks[1].Position, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, tasks[0].Position, prev)
+
+	next, err := repo.NextPosition(ctx, 1, tasks[1].Position, tasks[2].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "", next)
+
+	byPosition := repository.TaskPageQuery{
+		Sort:  []repository.SortField{{Column: "position"}, {Column: "id"}},
+		Limit: 10,
+	}
+
+	// 把 C 移到最前面
+	assert.NoError(t, repo.UpdatePosition(ctx, tasks[2].ID, "0"))
+	list, err := repo.ListPage(ctx, 1, byPosition)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C", "A", "B"}, []string{list[0].Title, list[1].Title, list[2].Title})
+
+	// Rebalance 保留順序
+	assert.NoError(t, repo.Rebalance(ctx, 1))
+	list, err = repo.ListPage(ctx, 1, byPosition)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C", "A", "B"}, []string{list[0].Title, list[1].Title, list[2].Title})
+	assert.NotEqual(t, "0", list[0].Position)
+}
+
+// 連續附加大量任務時 rank 長度只會緩慢成長，不會超過欄位長度。
+func TestTaskRepository_AppendManyKeepsPositionsShort_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	const singles, imported = 2000, 3000
+	prev := ""
+	for i := 0; i < singles; i++ {
+		task := &model.Task{UserID: 1, Title: "t", Status: "pending"}
+		require.NoError(t, repo.CreateTask(ctx, task))
+		require.Greater(t, task.Position, prev)
+		prev = task.Position
+	}
+
+	batch := make([]*model.Task, imported)
+	for i := range batch {
+		batch[i] = &model.Task{Title: "i", Status: "pending"}
+	}
+	require.NoError(t, repo.CreateTasks(ctx, 1, batch, 500, nil))
+	for _, task := range batch {
+		require.Greater(t, task.Position, prev)
+		prev = task.Position
+	}
+	assert.LessOrEqual(t, len(prev), 8)
+}
+
+func TestTaskRepository_ConcurrentCreatesGetDistinctPositions_SQLite(t *testing.T) {
+	// 並發寫入需要多條連線共用同一個資料庫，改用檔案；_txlock=immediate 讓交易一開始就取得寫入鎖
+	dsn := filepath.Join(t.TempDir(), "tasks.db") + "?_busy_timeout=5000&_txlock=immediate"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.Task{}, &model.SyncCounter{}, &model.TaskTombstone{}))
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- repo.CreateTask(ctx, &model.Task{UserID: 1, Title: "t", Status: "pending"})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	var positions []string
+	require.NoError(t, db.Model(&model.Task{}).Where("user_id = ?", 1).Pluck("position", &positions).Error)
+	require.Len(t, positions, n)
+	seen := map[string]bool{}
+	for _, p := range positions {
+		assert.False(t, seen[p], "duplicate position %q", p)
+		seen[p] = true
+	}
+}
+
+func TestTaskRepository_ListPage_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		task := &model.Task{UserID: 1, Title: fmt.Sprintf("T%d", i), CreatedAt: base.Add(time.Duration(i) * time.Minute)}
+		assert.NoError(t, repo.CreateTask(ctx, task))
+	}
+	// 相同 created_at 時以 id 決定順序
+	assert.NoError(t, repo.CreateTask(ctx, &model.Task{UserID: 1, Title: "T4b", CreatedAt: base.Add(4 * time.Minute)}))
+	assert.NoError(t, repo.CreateTask(ctx, &model.Task{UserID: 2, Title: "other"}))
+
+	var titles []string
+	q := repository.TaskPageQuery{
+		Sort:  []repository.SortField{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+		Limit: 2,
+	}
+	for {
+		page, err := repo.ListPage(ctx, 1, q)
+		assert.NoError(t, err)
+		for _, task := range page {
+			titles = append(titles, task.Title)
+		}
+		if len(page) < q.Limit {
+			break
+		}
+		q.After = repository.SortValues(page[len(page)-1], q.Sort)
+	}
+	assert.Equal(t, []string{"T4b", "T4", "T3", "T2", "T1", "T0"}, titles)
+
+	n, err := repo.CountByUserID(ctx, 1, repository.TaskFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), n)
+}
+
+func TestTaskRepository_FilterAndMultiSort_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	seed := []struct {
+		title, status string
+		offset        time.Duration
+	}{
+		{"report draft", "todo", 0},
+		{"report final", "done", time.Hour},
+		{"review 100%", "todo", 2 * time.Hour},
+		{"reviewer", "done", 3 * time.Hour},
+		{"groceries", "todo", 4 * time.Hour},
+	}
+	for _, s := range seed {
+		task := &model.Task{UserID: 1, Title: s.title, Status: s.status, CreatedAt: base.Add(s.offset)}
+		assert.NoError(t, repo.CreateTask(ctx, task))
+	}
+
+	sortByStatusThenNewest := []repository.SortField{
+		{Column: "status"},
+		{Column: "created_at", Desc: true},
+		{Column: "id", Desc: true},
+	}
+	list := func(f repository.TaskFilter) []string {
+		var titles []string
+		q := repository.TaskPageQuery{Filter: f, Sort: sortByStatusThenNewest, Limit: 2}
+		for {
+			page, err := repo.ListPage(ctx, 1, q)
+			assert.NoError(t, err)
+			for _, task := range page {
+				titles = append(titles, task.Title)
+			}
+			if len(page) < q.Limit {
+				return titles
+			}
+			q.After = repository.SortValues(page[len(page)-1], q.Sort)
+		}
+	}
+
+	assert.Equal(t,
+		[]string{"reviewer", "report final", "groceries", "review 100%", "report draft"},
+		list(repository.TaskFilter{}))
+
+	assert.Equal(t,
+		[]string{"groceries", "review 100%", "report draft"},
+		list(repository.TaskFilter{Statuses: []string{"todo"}}))
+
+	after := base.Add(time.Hour)
+	before := base.Add(4 * time.Hour)
+	assert.Equal(t,
+		[]string{"reviewer", "report final", "review 100%"},
+		list(repository.TaskFilter{CreatedAfter: &after, CreatedBefore: &before}))
+
+	// LIKE 的萬用字元要被跳脫
+	assert.Equal(t, []string{"review 100%"}, list(repository.TaskFilter{TitlePrefix: "review 1"}))
+	assert.Empty(t, list(repository.TaskFilter{TitlePrefix: "re_"}))
+	assert.Equal(t, []string{"reviewer", "report final", "review 100%", "report draft"},
+		list(repository.TaskFilter{TitlePrefix: "re"}))
+
+	n, err := repo.CountByUserID(ctx, 1, repository.TaskFilter{Statuses: []string{"done"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	// 不在 allowlist 的欄位
+	_, err = repo.ListPage(ctx, 1, repository.TaskPageQuery{
+		Sort:  []repository.SortField{{Column: "user_id; DROP TABLE tasks"}},
+		Limit: 1,
+	})
+	assert.ErrorIs(t, err, repository.ErrUnknownSortColumn)
+}
+
+func TestTaskRepository_SortByPriorityAndDueAt_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	day := func(d int) *time.Time {
+		v := time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	seed := []struct {
+		title    string
+		priority int
+		due      *time.Time
+	}{
+		{"a", 1, day(3)},
+		{"b", 5, nil},
+		{"c", 5, day(1)},
+		{"d", 0, nil},
+		{"e", 9, day(2)},
+	}
+	for _, s := range seed {
+		require.NoError(t, repo.CreateTask(ctx, &model.Task{UserID: 1, Title: s.title, Priority: s.priority, DueAt: s.due}))
+	}
+
+	list := func(sort ...repository.SortField) []string {
+		var titles []string
+		q := repository.TaskPageQuery{Sort: append(sort, repository.SortField{Column: "id"}), Limit: 2}
+		for {
+			page, err := repo.ListPage(ctx, 1, q)
+			require.NoError(t, err)
+			for _, task := range page {
+				titles = append(titles, task.Title)
+			}
+			if len(page) < q.Limit {
+				return titles
+			}
+			q.After = repository.SortValues(page[len(page)-1], q.Sort)
+		}
+	}
+
+	// 沒有到期日的任務不論方向都排在最後，跨頁時也一樣
+	assert.Equal(t, []string{"c", "e", "a", "b", "d"}, list(repository.SortField{Column: "due_at"}))
+	assert.Equal(t, []string{"a", "e", "c", "b", "d"}, list(repository.SortField{Column: "due_at", Desc: true}))
+	assert.Equal(t, []string{"e", "c", "b", "a", "d"},
+		list(repository.SortField{Column: "priority", Desc: true}, repository.SortField{Column: "due_at"}))
+}
+
+func TestTaskRepository_SortByPriorityThenCreatedAt_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	repo := repository.NewTaskRepository(db)
+	ctx := context.Background()
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	seed := []struct {
+		title    string
+		priority int
+		offset   time.Duration
+	}{
+		{"old low", 9, 0},
+		{"new high", 1, 3 * time.Minute},
+		{"old high", 1, time.Minute},
+		{"unset", 0, 2 * time.Minute},
+		{"new low", 9, 4 * time.Minute},
+	}
+	for _, s := range seed {
+		task := &model.Task{UserID: 1, Title: s.title, Priority: s.priority, CreatedAt: base.Add(s.offset)}
+		require.NoError(t, repo.CreateTask(ctx, task))
+	}
+
+	// sort=-priority,created_at 經 ParseTaskSort 補上 id 後的欄位
+	q := repository.TaskPageQuery{
+		Sort:  []repository.SortField{{Column: "priority", Desc: true}, {Column: "created_at"}, {Column: "id"}},
+		Limit: 2,
+	}
+	var titles []string
+	for {
+		page, err := repo.ListPage(ctx, 1, q)
+		require.NoError(t, err)
+		for _, task := range page {
+			titles = append(titles, task.Title)
+		}
+		if len(page) < q.Limit {
+			break
+		}
+		q.After = repository.SortValues(page[len(page)-1], q.Sort)
+	}
+	assert.Equal(t, []string{"old low", "new low", "old high", "new high", "unset"}, titles)
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+// ErrTaskChanged 表示任務在指定的 change_seq 之後已被修改或刪除，條件式寫入沒有套用。
+var ErrTaskChanged = errors.New("task changed since base sequence")
+
+// ChangeKey 是增量同步的位置：(change_seq, 任務 id)。同一次寫入的多筆任務共用流水號，以 id 區分先後。
+type ChangeKey struct {
+	Seq int64
+	ID  uint
+}
+
+// TaskChange 是一筆同步變更；Task 為 nil 表示任務已刪除，DeletedAt 為刪除時間。
+type TaskChange struct {
+	Key       ChangeKey
+	Task      *model.Task
+	DeletedAt *time.Time
+}
+
+func (r *taskRepository) ListChanges(ctx context.Context, userID uint, after ChangeKey, limit int) ([]TaskChange, error) {
+	db := r.db.WithContext(ctx)
+
+	var tasks []*model.Task
+	err := db.Where("user_id = ?", userID).
+		Where("change_seq > ? OR (change_seq = ? AND id > ?)", after.Seq, after.Seq, after.ID).
+		Order("change_seq ASC, id ASC").
+		Limit(limit).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var tombs []model.TaskTombstone
+	err = db.Where("user_id = ?", userID).
+		Where("change_seq > ? OR (change_seq = ? AND task_id > ?)", after.Seq, after.Seq, after.ID).
+		Order("change_seq ASC, task_id ASC").
+		Limit(limit).
+		Find(&tombs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]TaskChange, 0, len(tasks)+len(tombs))
+	for _, t := range tasks {
+		changes = append(changes, TaskChange{Key: ChangeKey{Seq: t.ChangeSeq, ID: t.ID}, Task: t})
+	}
+	for i := range tombs {
+		tb := &tombs[i]
+		changes = append(changes, TaskChange{Key: ChangeKey{Seq: tb.ChangeSeq, ID: tb.TaskID}, DeletedAt: &tb.DeletedAt})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i].Key, changes[j].Key
+		return a.Seq < b.Seq || (a.Seq == b.Seq && a.ID < b.ID)
+	})
+	if len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
+
+// nextChangeSeq 遞增並回傳使用者的變更流水號，必須與任務的寫入在同一個交易中呼叫。
+// 計數列在交易結束前保持鎖定，同一使用者的寫入因此依流水號順序提交，增量同步不會跳過尚未提交的變更。
+func nextChangeSeq(tx *gorm.DB, userID uint) (int64, error) {
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"seq": gorm.Expr("sync_counters.seq + 1")}),
+	}).Create(&model.SyncCounter{UserID: userID, Seq: 1}).Error
+	if err != nil {
+		return 0, err
+	}
+	var counter model.SyncCounter
+	if err := tx.Take(&counter, "user_id = ?", userID).Error; err != nil {
+		return 0, err
+	}
+	return counter.Seq, nil
+}
+
+// taskOwner 回傳任務的擁有者；任務不存在時 ok 為 false。
+func taskOwner(tx *gorm.DB, id uint) (userID uint, ok bool, err error) {
+	var task model.Task
+	err = tx.Select("id", "user_id").Take(&task, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return task.UserID, true, nil
+}
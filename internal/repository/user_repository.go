@@ -13,6 +13,7 @@ type UserRepository interface {
 	Create(ctx context.Context, user *model.User) error
 	FindByEmail(ctx context.Context, email string) (*model.User, error)
 	FindByID(ctx context.Context, id uint) (*model.User, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]*model.User, error)
 }
 
 type userRepository struct {
@@ -45,3 +46,12 @@ func (r *userRepository) FindByID(ctx context.Context, id uint) (*model.User, er
 	}
 	return &user, nil
 }
+
+func (r *userRepository) FindByIDs(ctx context.Context, ids []uint) ([]*model.User, error) {
+	var users []*model.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
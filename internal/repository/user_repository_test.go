@@ -53,4 +53,8 @@ func TestUserRepository_CreateAndFind(t *testing.T) {
 	foundByID, err := repo.FindByID(ctx, user.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, "sol", foundByID.Username)
+
+	byIDs, err := repo.FindByIDs(ctx, []uint{user.ID, 999})
+	assert.NoError(t, err)
+	assert.Len(t, byIDs, 1)
 }
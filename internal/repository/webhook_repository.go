@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, hook *model.Webhook) error
+	FindByID(ctx context.Context, id uint) (*model.Webhook, error)
+	ListByUserID(ctx context.Context, userID uint) ([]*model.Webhook, error)
+	// Delete 刪除 webhook 與其投遞紀錄。
+	Delete(ctx context.Context, id uint) error
+
+	CreateDeliveries(ctx context.Context, deliveries []*model.WebhookDelivery) error
+	FindDelivery(ctx context.Context, id uint) (*model.WebhookDelivery, error)
+	// ListDeliveries 回傳最近的投遞紀錄（新到舊）。
+	ListDeliveries(ctx context.Context, webhookID uint, limit int) ([]*model.WebhookDelivery, error)
+	// DueDeliveries 回傳 NextAttemptAt 已到的待投遞紀錄。
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error)
+	// ClaimDelivery 以 Attempts 作為樂觀鎖認領一筆投遞：Attempts 加一並把 NextAttemptAt 延到 leaseUntil，
+	// 讓其他 worker 在租約期間不會重複送出。回傳 false 表示已被別人認領。
+	ClaimDelivery(ctx context.Context, d *model.WebhookDelivery, leaseUntil time.Time) (bool, error)
+	// UpdateDelivery 寫回投遞結果；紀錄已隨 webhook 刪除時回傳 ErrDeliveryGone，不會重新建立。
+	UpdateDelivery(ctx context.Context, d *model.WebhookDelivery) error
+}
+
+// ErrDeliveryGone 表示要更新的投遞紀錄已不存在（webhook 被刪除時一併刪除）。
+var ErrDeliveryGone = errors.New("webhook delivery no longer exists")
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, hook *model.Webhook) error {
+	return r.db.WithContext(ctx).Create(hook).Error
+}
+
+func (r *webhookRepository) FindByID(ctx context.Context, id uint) (*model.Webhook, error) {
+	var hook model.Webhook
+	err := r.db.WithContext(ctx).First(&hook, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+func (r *webhookRepository) ListByUserID(ctx context.Context, userID uint) ([]*model.Webhook, error) {
+	var hooks []*model.Webhook
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("id ASC").
+		Find(&hooks).Error
+	return hooks, err
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&model.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Webhook{}, id).Error
+	})
+}
+
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []*model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(deliveries).Error
+}
+
+func (r *webhookRepository) FindDelivery(ctx context.Context, id uint) (*model.WebhookDelivery, error) {
+	var d model.WebhookDelivery
+	err := r.db.WithContext(ctx).First(&d, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, webhookID uint, limit int) ([]*model.WebhookDelivery, error) {
+	var deliveries []*model.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Where("webhook_id = ?", webhookID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *webhookRepository) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	var deliveries []*model.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", model.DeliveryPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *webhookRepository) ClaimDelivery(ctx context.Context, d *model.WebhookDelivery, leaseUntil time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Model(&model.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ?", d.ID, model.DeliveryPending, d.Attempts).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": leaseUntil,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+	d.Attempts++
+	d.NextAttemptAt = leaseUntil
+	return true, nil
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, d *model.WebhookDelivery) error {
+	res := r.db.WithContext(ctx).
+		Model(&model.WebhookDelivery{}).
+		Where("id = ?", d.ID).
+		Updates(map[string]interface{}{
+			"status":          d.Status,
+			"response_code":   d.ResponseCode,
+			"error":           d.Error,
+			"next_attempt_at": d.NextAttemptAt,
+			"updated_at":      time.Now(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrDeliveryGone
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+func TestWebhookRepository_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Webhook{}, &model.WebhookDelivery{}))
+	repo := repository.NewWebhookRepository(db)
+	ctx := context.Background()
+
+	hook := &model.Webhook{UserID: 1, URL: "https://example.com/hook", Secret: "s", Events: "task.created"}
+	require.NoError(t, repo.Create(ctx, hook))
+
+	now := time.Now()
+	due := &model.WebhookDelivery{WebhookID: hook.ID, Event: "task.created", Payload: "{}", Status: model.DeliveryPending, NextAttemptAt: now.Add(-time.Second)}
+	later := &model.WebhookDelivery{WebhookID: hook.ID, Event: "task.created", Payload: "{}", Status: model.DeliveryPending, NextAttemptAt: now.Add(time.Hour)}
+	done := &model.WebhookDelivery{WebhookID: hook.ID, Event: "task.created", Payload: "{}", Status: model.DeliverySucceeded, NextAttemptAt: now.Add(-time.Hour)}
+	require.NoError(t, repo.CreateDeliveries(ctx, []*model.WebhookDelivery{due, later, done}))
+
+	got, err := repo.DueDeliveries(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, due.ID, got[0].ID)
+
+	// 第一個 worker 認領成功，拿著舊 Attempts 的第二個 worker 失敗
+	stale := *got[0]
+	ok, err := repo.ClaimDelivery(ctx, got[0], now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, got[0].Attempts)
+	ok, err = repo.ClaimDelivery(ctx, &stale, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// 租約期間不會再被取出
+	got, err = repo.DueDeliveries(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	log, err := repo.ListDeliveries(ctx, hook.ID, 2)
+	require.NoError(t, err)
+	require.Len(t, log, 2)
+	assert.Equal(t, done.ID, log[0].ID)
+
+	// 寫回投遞結果
+	delivered, err := repo.FindDelivery(ctx, due.ID)
+	require.NoError(t, err)
+	delivered.Status = model.DeliverySucceeded
+	delivered.ResponseCode = 200
+	require.NoError(t, repo.UpdateDelivery(ctx, delivered))
+	d, err := repo.FindDelivery(ctx, due.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.DeliverySucceeded, d.Status)
+	assert.Equal(t, 200, d.ResponseCode)
+
+	require.NoError(t, repo.Delete(ctx, hook.ID))
+	found, err := repo.FindByID(ctx, hook.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+	d, err = repo.FindDelivery(ctx, due.ID)
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	// 投遞途中 webhook 被刪除：寫回結果不會重新建立紀錄
+	assert.ErrorIs(t, repo.UpdateDelivery(ctx, delivered), repository.ErrDeliveryGone)
+	d, err = repo.FindDelivery(ctx, due.ID)
+	require.NoError(t, err)
+	assert.Nil(t, d)
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+type WorkflowRepository interface {
+	FindByUserID(ctx context.Context, userID uint) (*model.Workflow, error)
+	Save(ctx context.Context, wf *model.Workflow) error
+}
+
+type workflowRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkflowRepository(db *gorm.DB) WorkflowRepository {
+	return &workflowRepository{db: db}
+}
+
+func (r *workflowRepository) FindByUserID(ctx context.Context, userID uint) (*model.Workflow, error) {
+	var wf model.Workflow
+	err := r.db.WithContext(ctx).
+		Preload("States").
+		Preload("Transitions").
+		Where("user_id = ?", userID).
+		First(&wf).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &wf, nil
+}
+
+// Save 以整份取代的方式寫入使用者的流程（舊的狀態與轉換會一併移除）。
+func (r *workflowRepository) Save(ctx context.Context, wf *model.Workflow) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing model.Workflow
+		err := tx.Where("user_id = ?", wf.UserID).First(&existing).Error
+		switch {
+		case err == nil:
+			if err := tx.Where("workflow_id = ?", existing.ID).Delete(&model.WorkflowState{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("workflow_id = ?", existing.ID).Delete(&model.WorkflowTransition{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&existing).Error; err != nil {
+				return err
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		wf.ID = 0
+		for i := range wf.States {
+			wf.States[i].ID = 0
+		}
+		for i := range wf.Transitions {
+			wf.Transitions[i].ID = 0
+		}
+		return tx.Create(wf).Error
+	})
+}
@@ -0,0 +1,58 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+func TestWorkflowRepository_SaveAndFind_SQLite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.Workflow{}, &model.WorkflowState{}, &model.WorkflowTransition{}))
+
+	repo := repository.NewWorkflowRepository(db)
+	ctx := context.Background()
+
+	// 尚未設定
+	wf, err := repo.FindByUserID(ctx, 7)
+	assert.NoError(t, err)
+	assert.Nil(t, wf)
+
+	// 第一次儲存
+	err = repo.Save(ctx, &model.Workflow{
+		UserID:        7,
+		Name:          "kanban",
+		InitialStatus: "todo",
+		States:        []model.WorkflowState{{Name: "todo"}, {Name: "done", Completes: true}},
+		Transitions:   []model.WorkflowTransition{{FromStatus: "todo", ToStatus: "done"}},
+	})
+	require.NoError(t, err)
+
+	// 覆寫：舊的狀態與轉換不應殘留
+	err = repo.Save(ctx, &model.Workflow{
+		UserID:        7,
+		Name:          "simple",
+		InitialStatus: "open",
+		States:        []model.WorkflowState{{Name: "open"}, {Name: "closed", Completes: true}},
+	})
+	require.NoError(t, err)
+
+	wf, err = repo.FindByUserID(ctx, 7)
+	require.NoError(t, err)
+	require.NotNil(t, wf)
+	assert.Equal(t, "simple", wf.Name)
+	assert.Len(t, wf.States, 2)
+	assert.Empty(t, wf.Transitions)
+
+	var states int64
+	db.Model(&model.WorkflowState{}).Count(&states)
+	assert.Equal(t, int64(2), states)
+}
@@ -1,31 +1,52 @@
 package router
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 
+	"github.com/SoliMark/gotasker-pro/internal/apidoc"
 	"github.com/SoliMark/gotasker-pro/internal/app"
+	"github.com/SoliMark/gotasker-pro/internal/handler"
+	"github.com/SoliMark/gotasker-pro/internal/middleware"
+)
+
+// 未加版本的舊路徑（/login、/api/tasks…）是 /v1 的別名，回應帶 Deprecation 與 Sunset header。
+var (
+	legacyDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	legacySunset       = time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
 )
 
 func SetupRoutes(r *gin.Engine, c *app.Container) {
-	// Public routes
-	r.POST("/register", c.UserHandler.Register)
-	r.POST("/login", c.UserHandler.Login)
-
-	// Protected routes with JWT
-	api := r.Group("/api")
-	api.Use(c.JWTMiddleware)
-	{
-		// User Profile
-		api.GET("/profile", c.UserHandler.Profile)
-
-		// Task CRUD
-		tasks := api.Group("/tasks")
-		{
-			tasks.POST("", c.TaskHandler.CreateTask)
-			tasks.GET("", c.TaskHandler.ListTasks)
-			tasks.GET("/:id", c.TaskHandler.GetTask)
-			tasks.PUT("/:id", c.TaskHandler.UpdateTask)
-			tasks.DELETE("/:id", c.TaskHandler.DeleteTask)
-		}
-	}
+	// Errors reported with c.Error are rendered as application/problem+json
+	r.Use(c.ErrorMW)
+
+	// API documentation
+	r.GET("/openapi.json", apidoc.ServeSpec)
+	r.GET("/docs", apidoc.ServeUI)
+	r.GET("/docs/assets/:file", apidoc.ServeUIAsset)
+
+	// Calendar subscription (the token in the URL is the credential, so the URL never changes)
+	r.GET("/feeds/:file", c.FeedHandler.GetFeed)
+
+	// GraphQL (the schema evolves by adding fields, so the path is not versioned)
+	r.POST("/graphql", c.JWTMiddleware, c.GraphQLHandler.Serve)
+
+	// Batch sub-requests are dispatched back into this engine
+	batch := handler.NewBatchHandler(r)
+
+	// v1
+	v1 := r.Group("/v1")
+	v1Protected := v1.Group("", c.JWTMiddleware, c.IdempotencyMW)
+	registerV1(v1, v1Protected, c)
+	v1Protected.POST("/batch", batch.Batch)
+
+	// Legacy unversioned paths: /register, /login and /api/* serve v1
+	legacy := r.Group("", middleware.Deprecated(legacyDeprecatedAt, legacySunset, "", "/v1"))
+	legacyAPI := r.Group("/api",
+		middleware.Deprecated(legacyDeprecatedAt, legacySunset, "/api", "/v1"),
+		c.JWTMiddleware, c.IdempotencyMW,
+	)
+	registerV1(legacy, legacyAPI, c)
+	legacyAPI.POST("/batch", batch.Batch)
 }
@@ -0,0 +1,33 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/apidoc"
+	"github.com/SoliMark/gotasker-pro/internal/app"
+)
+
+// 新增路由時必須同時在 internal/apidoc/routes.go 補上文件。
+func TestRoutesAreDocumented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	SetupRoutes(r, &app.Container{})
+
+	documented := map[apidoc.Route]bool{}
+	for _, route := range apidoc.Routes() {
+		documented[route] = true
+	}
+
+	registered := map[apidoc.Route]bool{}
+	for _, info := range r.Routes() {
+		route := apidoc.Route{Method: info.Method, Path: info.Path}
+		registered[route] = true
+		require.True(t, documented[route], "route %s %s is missing from the OpenAPI spec", info.Method, info.Path)
+	}
+	for route := range documented {
+		require.True(t, registered[route], "documented route %s %s is not registered", route.Method, route.Path)
+	}
+}
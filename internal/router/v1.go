@@ -0,0 +1,84 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/SoliMark/gotasker-pro/internal/app"
+)
+
+// registerV1 註冊 v1 的路由：public 不需要登入，protected 已套用 JWT 與 Idempotency middleware。
+// 之後的版本以自己的 registerVn 註冊，回應格式不同的路由換成該版本的 handler，其餘沿用 v1 的 handler。
+func registerV1(public, protected *gin.RouterGroup, c *app.Container) {
+	// Auth
+	public.POST("/register", c.UserHandler.Register)
+	public.POST("/login", c.UserHandler.Login)
+
+	// User Profile
+	protected.GET("/profile", c.UserHandler.Profile)
+
+	// Real-time task events (SSE)
+	protected.GET("/stream", c.StreamHandler.Stream)
+
+	// Project board collaboration (WebSocket)
+	protected.GET("/boards/ws", c.BoardHandler.Connect)
+
+	// Task CRUD
+	tasks := protected.Group("/tasks")
+	{
+		tasks.POST("", c.TaskHandler.CreateTask)
+		tasks.GET("", c.TaskHandler.ListTasks)
+		tasks.GET("/search", c.TaskHandler.SearchTasks)
+		tasks.GET("/export", c.TaskHandler.ExportTasks)
+		tasks.POST("/bulk", c.TaskHandler.BulkTasks)
+		tasks.POST("/import", c.ImportHandler.ImportTasks)
+		tasks.GET("/import/jobs/:job_id", c.ImportHandler.GetImportJob)
+		tasks.GET("/:id", c.TaskHandler.GetTask)
+		tasks.PUT("/:id", c.TaskHandler.UpdateTask)
+		tasks.PATCH("/:id", c.TaskHandler.PatchTask)
+		tasks.DELETE("/:id", c.TaskHandler.DeleteTask)
+		tasks.POST("/:id/move", c.TaskHandler.MoveTask)
+		tasks.GET("/:id/comments", c.CommentHandler.ListComments)
+		tasks.POST("/:id/comments", c.CommentHandler.AddComment)
+	}
+
+	// Delta sync for offline clients
+	protected.GET("/sync", c.SyncHandler.GetChanges)
+	protected.POST("/sync", c.SyncHandler.ApplyMutations)
+
+	// Projects
+	protected.POST("/projects", c.ProjectHandler.CreateProject)
+	protected.GET("/projects", c.ProjectHandler.ListProjects)
+	protected.GET("/projects/:id/members", c.ProjectHandler.ListMembers)
+	protected.PUT("/projects/:id/members/:user_id", c.ProjectHandler.AddMember)
+	protected.DELETE("/projects/:id/members/:user_id", c.ProjectHandler.RemoveMember)
+
+	// Saved views
+	views := protected.Group("/views")
+	{
+		views.POST("", c.ViewHandler.CreateView)
+		views.GET("", c.ViewHandler.ListViews)
+		views.GET("/:id", c.ViewHandler.GetView)
+		views.PUT("/:id", c.ViewHandler.UpdateView)
+		views.DELETE("/:id", c.ViewHandler.DeleteView)
+		views.GET("/:id/tasks", c.ViewHandler.ListViewTasks)
+	}
+
+	// Calendar feed token
+	protected.POST("/feed/token", c.FeedHandler.RotateFeedToken)
+	protected.DELETE("/feed/token", c.FeedHandler.RevokeFeedToken)
+
+	// Webhooks
+	webhooks := protected.Group("/webhooks")
+	{
+		webhooks.POST("", c.WebhookHandler.CreateWebhook)
+		webhooks.GET("", c.WebhookHandler.ListWebhooks)
+		webhooks.DELETE("/:id", c.WebhookHandler.DeleteWebhook)
+		webhooks.POST("/:id/ping", c.WebhookHandler.Ping)
+		webhooks.GET("/:id/deliveries", c.WebhookHandler.ListDeliveries)
+		webhooks.POST("/:id/deliveries/:delivery_id/redeliver", c.WebhookHandler.Redeliver)
+	}
+
+	// Status workflow
+	protected.GET("/workflow", c.WorkflowHandler.GetWorkflow)
+	protected.PUT("/workflow", c.WorkflowHandler.SaveWorkflow)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/SoliMark/gotasker-pro/internal/realtime"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+var (
+	ErrBoardNotSubscribed = errors.New("board not subscribed")
+	ErrTooManyBoards      = errors.New("too many boards")
+	ErrInvalidPresence    = errors.New("invalid presence")
+)
+
+// BoardService 管理專案看板的協作連線：擁有者與成員可以訂閱，presence 在同一看板的連線之間廣播，
+// 任務異動則從專案擁有者的事件 broker 轉送，因此在多台部署時也會送達。
+// 成員被移除後，下一次更新 presence 或收到任務異動時就會被移出看板。
+type BoardService interface {
+	Connect(userID uint) *realtime.BoardClient
+	// Subscribe 每次都重新確認連線的使用者是專案的擁有者或成員，回傳看板上其他連線的 presence。
+	Subscribe(ctx context.Context, c *realtime.BoardClient, projectID uint) ([]realtime.Presence, error)
+	Unsubscribe(c *realtime.BoardClient, projectID uint)
+	// UpdatePresence 也會重新確認成員資格，已被移除的成員會被移出看板。
+	UpdatePresence(ctx context.Context, c *realtime.BoardClient, projectID uint, taskID *uint, state string) error
+	// Send 把回應放進連線的送出佇列，與廣播共用同一條佇列以保持順序。
+	Send(c *realtime.BoardClient, msg realtime.BoardMessage)
+	Disconnect(c *realtime.BoardClient)
+}
+
+type boardService struct {
+	hub      *realtime.BoardHub
+	broker   realtime.Broker
+	projects repository.ProjectRepository
+
+	mu      sync.Mutex
+	watched map[*realtime.BoardClient]map[uint]struct{} // 每條連線正在轉送哪些擁有者的事件
+}
+
+func NewBoardService(hub *realtime.BoardHub, broker realtime.Broker, projects repository.ProjectRepository) BoardService {
+	return &boardService{hub: hub, broker: broker, projects: projects, watched: map[*realtime.BoardClient]map[uint]struct{}{}}
+}
+
+func (s *boardService) Connect(userID uint) *realtime.BoardClient {
+	c := s.hub.Connect(userID)
+	s.watch(c, userID)
+	return c
+}
+
+// watch 開始把 ownerID 的任務事件轉給連線；同一位擁有者只轉送一次，連線結束時停止。
+func (s *boardService) watch(c *realtime.BoardClient, ownerID uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	owners := s.watched[c]
+	if owners == nil {
+		owners = map[uint]struct{}{}
+		s.watched[c] = owners
+		go func() {
+			<-c.Done()
+			s.mu.Lock()
+			delete(s.watched, c)
+			s.mu.Unlock()
+		}()
+	}
+	if _, ok := owners[ownerID]; ok {
+		return
+	}
+	owners[ownerID] = struct{}{}
+	go s.forward(c, ownerID, s.broker.Subscribe(ownerID))
+}
+
+// forward 把擁有者的任務事件轉給連線已訂閱的看板；broker 因連線太慢中斷訂閱時一併中斷連線。
+// 轉送他人的任務前會重新確認成員資格，已被移除的成員會被移出看板。
+func (s *boardService) forward(c *realtime.BoardClient, ownerID uint, sub *realtime.Subscription) {
+	defer sub.Close()
+	for {
+		select {
+		case <-c.Done():
+			return
+		case ev, ok := <-sub.C:
+			if !ok {
+				s.hub.Disconnect(c)
+				return
+			}
+			var task struct {
+				ProjectID *uint `json:"project_id"`
+			}
+			if err := json.Unmarshal(ev.Data, &task); err != nil || task.ProjectID == nil {
+				continue
+			}
+			if !s.hub.Joined(c, *task.ProjectID) {
+				continue
+			}
+			if ownerID != c.UserID {
+				if _, err := accessibleProject(context.Background(), s.projects, c.UserID, *task.ProjectID); err != nil {
+					s.hub.Leave(c, *task.ProjectID)
+					continue
+				}
+			}
+			s.hub.Send(c, realtime.BoardMessage{Type: ev.Type, ProjectID: *task.ProjectID, Data: ev.Data})
+		}
+	}
+}
+
+func (s *boardService) Subscribe(ctx context.Context, c *realtime.BoardClient, projectID uint) ([]realtime.Presence, error) {
+	project, err := accessibleProject(ctx, s.projects, c.UserID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.hub.Join(c, projectID)
+	if errors.Is(err, realtime.ErrTooManyBoards) {
+		return nil, ErrTooManyBoards
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.watch(c, project.UserID)
+	return members, nil
+}
+
+func (s *boardService) Unsubscribe(c *realtime.BoardClient, projectID uint) {
+	s.hub.Leave(c, projectID)
+}
+
+func (s *boardService) UpdatePresence(ctx context.Context, c *realtime.BoardClient, projectID uint, taskID *uint, state string) error {
+	switch state {
+	case realtime.PresenceViewing, realtime.PresenceEditing, realtime.PresenceIdle:
+	default:
+		return ErrInvalidPresence
+	}
+	if state == realtime.PresenceEditing && taskID == nil {
+		return ErrInvalidPresence
+	}
+	if !s.hub.Joined(c, projectID) {
+		return ErrBoardNotSubscribed
+	}
+	if _, err := accessibleProject(ctx, s.projects, c.UserID, projectID); err != nil {
+		if errors.Is(err, ErrPermissionDenied) || errors.Is(err, ErrProjectNotFound) {
+			s.hub.Leave(c, projectID)
+		}
+		return err
+	}
+	err := s.hub.SetPresence(c, projectID, taskID, state)
+	if errors.Is(err, realtime.ErrNotJoined) {
+		return ErrBoardNotSubscribed
+	}
+	return err
+}
+
+func (s *boardService) Send(c *realtime.BoardClient, msg realtime.BoardMessage) {
+	s.hub.Send(c, msg)
+}
+
+func (s *boardService) Disconnect(c *realtime.BoardClient) {
+	s.hub.Disconnect(c)
+}
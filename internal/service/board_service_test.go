@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/realtime"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func receiveBoard(t *testing.T, c *realtime.BoardClient) realtime.BoardMessage {
+	t.Helper()
+	select {
+	case msg := <-c.Send:
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("no board message received")
+		return realtime.BoardMessage{}
+	}
+}
+
+func TestBoardService(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	projects := mock_repository.NewMockProjectRepository(ctrl)
+	projects.EXPECT().FindByID(gomock.Any(), uint(10)).Return(&model.Project{ID: 10, UserID: 1}, nil).AnyTimes()
+	projects.EXPECT().FindByID(gomock.Any(), uint(20)).Return(&model.Project{ID: 20, UserID: 2}, nil).AnyTimes()
+	projects.EXPECT().FindByID(gomock.Any(), uint(30)).Return(nil, nil).AnyTimes()
+	projects.EXPECT().IsMember(gomock.Any(), uint(20), uint(1)).Return(false, nil).AnyTimes()
+
+	broker := realtime.NewMemoryBroker()
+	stream := service.NewStreamService(broker)
+	svc := service.NewBoardService(realtime.NewBoardHub(), broker, projects)
+
+	c := svc.Connect(1)
+	defer svc.Disconnect(c)
+
+	_, err := svc.Subscribe(ctx, c, 20)
+	assert.ErrorIs(t, err, service.ErrPermissionDenied)
+	_, err = svc.Subscribe(ctx, c, 30)
+	assert.ErrorIs(t, err, service.ErrProjectNotFound)
+	assert.ErrorIs(t, svc.UpdatePresence(ctx, c, 20, nil, realtime.PresenceViewing), service.ErrBoardNotSubscribed)
+
+	_, err = svc.Subscribe(ctx, c, 10)
+	require.NoError(t, err)
+	assert.ErrorIs(t, svc.UpdatePresence(ctx, c, 10, nil, "sleeping"), service.ErrInvalidPresence)
+	assert.ErrorIs(t, svc.UpdatePresence(ctx, c, 10, nil, realtime.PresenceEditing), service.ErrInvalidPresence)
+
+	// 只轉送已訂閱看板的任務異動
+	other, inBoard := uint(11), uint(10)
+	stream.PublishTaskEvent(ctx, service.EventTaskUpdated, &model.Task{ID: 1, UserID: 1, ProjectID: &other})
+	stream.PublishTaskEvent(ctx, service.EventTaskUpdated, &model.Task{ID: 2, UserID: 1})
+	stream.PublishTaskEvent(ctx, service.EventTaskCreated, &model.Task{ID: 3, UserID: 1, ProjectID: &inBoard, Title: "card"})
+
+	msg := receiveBoard(t, c)
+	assert.Equal(t, service.EventTaskCreated, msg.Type)
+	assert.Equal(t, uint(10), msg.ProjectID)
+	assert.Contains(t, string(msg.Data), `"title":"card"`)
+
+	svc.Unsubscribe(c, 10)
+	stream.PublishTaskEvent(ctx, service.EventTaskDeleted, &model.Task{ID: 3, UserID: 1, ProjectID: &inBoard})
+	svc.Send(c, realtime.BoardMessage{Type: "pong"})
+	assert.Equal(t, "pong", receiveBoard(t, c).Type)
+}
+
+func TestBoardService_Members(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	var member atomic.Bool
+	member.Store(true)
+	projects := mock_repository.NewMockProjectRepository(ctrl)
+	projects.EXPECT().FindByID(gomock.Any(), uint(10)).Return(&model.Project{ID: 10, UserID: 1}, nil).AnyTimes()
+	projects.EXPECT().IsMember(gomock.Any(), uint(10), uint(2)).
+		DoAndReturn(func(context.Context, uint, uint) (bool, error) { return member.Load(), nil }).AnyTimes()
+
+	broker := realtime.NewMemoryBroker()
+	stream := service.NewStreamService(broker)
+	svc := service.NewBoardService(realtime.NewBoardHub(), broker, projects)
+
+	owner := svc.Connect(1)
+	defer svc.Disconnect(owner)
+	guest := svc.Connect(2)
+	defer svc.Disconnect(guest)
+
+	_, err := svc.Subscribe(ctx, owner, 10)
+	require.NoError(t, err)
+	members, err := svc.Subscribe(ctx, guest, 10)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, uint(1), members[0].UserID)
+	assert.Equal(t, uint(2), receiveBoard(t, owner).Presence.UserID)
+
+	// 成員收到擁有者的任務異動
+	projectID := uint(10)
+	stream.PublishTaskEvent(ctx, service.EventTaskUpdated, &model.Task{ID: 3, UserID: 1, ProjectID: &projectID, Title: "card"})
+	for _, c := range []*realtime.BoardClient{owner, guest} {
+		msg := receiveBoard(t, c)
+		assert.Equal(t, service.EventTaskUpdated, msg.Type)
+		assert.Contains(t, string(msg.Data), `"title":"card"`)
+	}
+
+	// 被移除的成員更新 presence 時會被移出看板
+	member.Store(false)
+	assert.ErrorIs(t, svc.UpdatePresence(ctx, guest, 10, nil, realtime.PresenceIdle), service.ErrPermissionDenied)
+	msg := receiveBoard(t, owner)
+	assert.Equal(t, uint(2), msg.Presence.UserID)
+	assert.Equal(t, realtime.PresenceLeft, msg.Presence.State)
+	assert.ErrorIs(t, svc.UpdatePresence(ctx, guest, 10, nil, realtime.PresenceIdle), service.ErrBoardNotSubscribed)
+}
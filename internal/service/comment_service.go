@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+var ErrEmptyComment = errors.New("comment body is required")
+
+type CommentService interface {
+	AddComment(ctx context.Context, userID, taskID uint, body string) (*model.Comment, error)
+	ListComments(ctx context.Context, userID, taskID uint) ([]*model.Comment, error)
+	// ListCommentsByTasks 一次查出多個任務的留言，依任務 id 分組；不屬於 userID 的任務直接略過。
+	ListCommentsByTasks(ctx context.Context, userID uint, taskIDs []uint) (map[uint][]*model.Comment, error)
+}
+
+type commentService struct {
+	comments repository.CommentRepository
+	tasks    repository.TaskRepository
+	search   repository.SearchIndex
+}
+
+// NewCommentService 建立留言服務；search 可為 nil，新增留言後會更新該任務的檢索文件。
+func NewCommentService(comments repository.CommentRepository, tasks repository.TaskRepository, search repository.SearchIndex) CommentService {
+	return &commentService{comments: comments, tasks: tasks, search: search}
+}
+
+func (s *commentService) AddComment(ctx context.Context, userID, taskID uint, body string) (*model.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, ErrEmptyComment
+	}
+	if err := s.checkOwner(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	comment := &model.Comment{TaskID: taskID, UserID: userID, Body: body}
+	if err := s.comments.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+	if s.search != nil {
+		if err := s.search.Index(ctx, taskID); err != nil {
+			log.Printf("search: index task %d: %v", taskID, err)
+		}
+	}
+	return comment, nil
+}
+
+func (s *commentService) ListComments(ctx context.Context, userID, taskID uint) ([]*model.Comment, error) {
+	if err := s.checkOwner(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+	return s.comments.ListByTaskID(ctx, taskID)
+}
+
+func (s *commentService) ListCommentsByTasks(ctx context.Context, userID uint, taskIDs []uint) (map[uint][]*model.Comment, error) {
+	tasks, err := s.tasks.FindByIDs(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	owned := make([]uint, 0, len(tasks))
+	for _, t := range tasks {
+		if t.UserID == userID {
+			owned = append(owned, t.ID)
+		}
+	}
+
+	comments, err := s.comments.ListByTaskIDs(ctx, owned)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[uint][]*model.Comment, len(owned))
+	for _, c := range comments {
+		res[c.TaskID] = append(res[c.TaskID], c)
+	}
+	return res, nil
+}
+
+func (s *commentService) checkOwner(ctx context.Context, userID, taskID uint) error {
+	task, err := s.tasks.FindByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return ErrTaskNotFound
+	}
+	if task.UserID != userID {
+		return ErrPermissionDenied
+	}
+	return nil
+}
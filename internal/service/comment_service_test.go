@@ -0,0 +1,66 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func TestCommentService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockComments := mock_repository.NewMockCommentRepository(ctrl)
+	mockTasks := mock_repository.NewMockTaskRepository(ctrl)
+	mockIndex := mock_repository.NewMockSearchIndex(ctrl)
+	svc := service.NewCommentService(mockComments, mockTasks, mockIndex)
+	ctx := context.Background()
+
+	t.Run("add comment reindexes task", func(t *testing.T) {
+		mockTasks.EXPECT().FindByID(ctx, uint(5)).Return(&model.Task{ID: 5, UserID: 1}, nil)
+		mockComments.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		mockIndex.EXPECT().Index(ctx, uint(5)).Return(nil)
+
+		cm, err := svc.AddComment(ctx, 1, 5, "  looks good ")
+		require.NoError(t, err)
+		assert.Equal(t, "looks good", cm.Body)
+		assert.Equal(t, uint(5), cm.TaskID)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		_, err := svc.AddComment(ctx, 1, 5, " ")
+		assert.ErrorIs(t, err, service.ErrEmptyComment)
+	})
+
+	t.Run("task not found", func(t *testing.T) {
+		mockTasks.EXPECT().FindByID(ctx, uint(6)).Return(nil, nil)
+		_, err := svc.ListComments(ctx, 1, 6)
+		assert.ErrorIs(t, err, service.ErrTaskNotFound)
+	})
+
+	t.Run("other user's task", func(t *testing.T) {
+		mockTasks.EXPECT().FindByID(ctx, uint(5)).Return(&model.Task{ID: 5, UserID: 2}, nil)
+		_, err := svc.AddComment(ctx, 1, 5, "hi")
+		assert.ErrorIs(t, err, service.ErrPermissionDenied)
+	})
+
+	t.Run("batch skips other user's tasks", func(t *testing.T) {
+		mockTasks.EXPECT().FindByIDs(ctx, []uint{5, 6, 7}).
+			Return([]*model.Task{{ID: 5, UserID: 1}, {ID: 6, UserID: 2}, {ID: 7, UserID: 1}}, nil)
+		mockComments.EXPECT().ListByTaskIDs(ctx, []uint{5, 7}).
+			Return([]*model.Comment{{ID: 1, TaskID: 5}, {ID: 2, TaskID: 7}, {ID: 3, TaskID: 5}}, nil)
+
+		got, err := svc.ListCommentsByTasks(ctx, 1, []uint{5, 6, 7})
+		require.NoError(t, err)
+		assert.Len(t, got[5], 2)
+		assert.Len(t, got[7], 1)
+		assert.Empty(t, got[6])
+	})
+}
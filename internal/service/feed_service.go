@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+// ErrFeedNotFound 表示權杖不存在或已撤銷；對外不區分兩者。
+var ErrFeedNotFound = errors.New("feed not found")
+
+// feedTokenBytes 為權杖的隨機位元組數（base64url 後 43 字元）。
+const feedTokenBytes = 32
+
+type FeedService interface {
+	// RotateToken 產生新的權杖並取代舊的；明文只在這裡回傳一次。
+	RotateToken(ctx context.Context, userID uint) (string, error)
+	RevokeToken(ctx context.Context, userID uint) error
+	// FeedTasks 以權杖找出使用者並回傳有截止時間的任務。
+	FeedTasks(ctx context.Context, token string) ([]*model.Task, error)
+}
+
+type feedService struct {
+	tokens repository.FeedTokenRepository
+	tasks  repository.TaskRepository
+}
+
+func NewFeedService(tokens repository.FeedTokenRepository, tasks repository.TaskRepository) FeedService {
+	return &feedService{tokens: tokens, tasks: tasks}
+}
+
+func (s *feedService) RotateToken(ctx context.Context, userID uint) (string, error) {
+	b := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	if err := s.tokens.Save(ctx, &model.FeedToken{UserID: userID, TokenHash: hashFeedToken(token)}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *feedService) RevokeToken(ctx context.Context, userID uint) error {
+	return s.tokens.DeleteByUserID(ctx, userID)
+}
+
+func (s *feedService) FeedTasks(ctx context.Context, token string) ([]*model.Task, error) {
+	if len(token) != base64.RawURLEncoding.EncodedLen(feedTokenBytes) {
+		return nil, ErrFeedNotFound
+	}
+	ft, err := s.tokens.FindByHash(ctx, hashFeedToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if ft == nil {
+		return nil, ErrFeedNotFound
+	}
+	return s.tasks.ListDueByUserID(ctx, ft.UserID)
+}
+
+// hashFeedToken 只保存雜湊，資料庫外洩時無法還原出可用的訂閱網址。
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
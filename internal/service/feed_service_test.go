@@ -0,0 +1,66 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func TestFeedService(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokens := mock_repository.NewMockFeedTokenRepository(ctrl)
+	tasks := mock_repository.NewMockTaskRepository(ctrl)
+	svc := service.NewFeedService(tokens, tasks)
+
+	var saved *model.FeedToken
+	tokens.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, ft *model.FeedToken) error {
+		saved = ft
+		return nil
+	})
+	token, err := svc.RotateToken(ctx, 7)
+	require.NoError(t, err)
+	assert.Len(t, token, 43)
+
+	// 只保存雜湊
+	sum := sha256.Sum256([]byte(token))
+	assert.Equal(t, hex.EncodeToString(sum[:]), saved.TokenHash)
+	assert.Equal(t, uint(7), saved.UserID)
+
+	t.Run("valid token", func(t *testing.T) {
+		tokens.EXPECT().FindByHash(ctx, saved.TokenHash).Return(saved, nil)
+		tasks.EXPECT().ListDueByUserID(ctx, uint(7)).Return([]*model.Task{{ID: 1}}, nil)
+
+		got, err := svc.FeedTasks(ctx, token)
+		require.NoError(t, err)
+		assert.Len(t, got, 1)
+	})
+
+	t.Run("revoked token", func(t *testing.T) {
+		tokens.EXPECT().FindByHash(ctx, saved.TokenHash).Return(nil, nil)
+
+		_, err := svc.FeedTasks(ctx, token)
+		assert.ErrorIs(t, err, service.ErrFeedNotFound)
+	})
+
+	t.Run("malformed token skips lookup", func(t *testing.T) {
+		_, err := svc.FeedTasks(ctx, "short")
+		assert.ErrorIs(t, err, service.ErrFeedNotFound)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		tokens.EXPECT().DeleteByUserID(ctx, uint(7)).Return(nil)
+		assert.NoError(t, svc.RevokeToken(ctx, 7))
+	})
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/SoliMark/gotasker-pro/internal/cache"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+var (
+	ErrIdempotencyMismatch   = errors.New("idempotency key reused with a different request")
+	ErrIdempotencyInProgress = errors.New("request with this idempotency key is in progress")
+)
+
+// IdempotencyLockTTL 是請求處理中的佔用時間；超過後視為放棄，同一個 key 可以重新執行。
+// 處理時間較長的請求由呼叫端定期以 Extend 延長。
+const IdempotencyLockTTL = 30 * time.Second
+
+// StoredResponse 是第一次請求的回應，重送時原樣回放。
+type StoredResponse struct {
+	StatusCode  int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// IdempotencyService 記錄 Idempotency-Key 對應的請求指紋與回應。
+//
+// Begin 的結果：
+//   - (nil, nil)：取得 key，呼叫端執行請求後以 Complete 保存回應，或以 Release 放棄（例如 5xx）；
+//     執行期間每隔不到 IdempotencyLockTTL 呼叫一次 Extend，避免佔用在請求完成前過期。
+//   - (resp, nil)：相同請求已完成，直接回放 resp。
+//   - ErrIdempotencyMismatch：同一個 key 搭配不同的請求內容。
+//   - ErrIdempotencyInProgress：相同請求仍在處理中。
+type IdempotencyService interface {
+	Begin(ctx context.Context, userID uint, key, fingerprint string) (*StoredResponse, error)
+	Complete(ctx context.Context, userID uint, key, fingerprint string, resp StoredResponse) error
+	Release(ctx context.Context, userID uint, key string) error
+	Extend(ctx context.Context, userID uint, key string) error
+}
+
+type idempotencyService struct {
+	repo repository.IdempotencyRepository
+	rdb  *redis.Client
+	ttl  time.Duration
+}
+
+// NewIdempotencyService 在 rdb 不為 nil 時使用 Redis，否則退回資料表；ttl 為回應保存的時間。
+func NewIdempotencyService(repo repository.IdempotencyRepository, rdb *redis.Client, ttl time.Duration) IdempotencyService {
+	return &idempotencyService{repo: repo, rdb: rdb, ttl: ttl}
+}
+
+// idempotencyEntry 是 Redis 中的值；Response 為 nil 表示處理中。
+type idempotencyEntry struct {
+	Fingerprint string          `json:"fp"`
+	Response    *StoredResponse `json:"resp,omitempty"`
+}
+
+func (e idempotencyEntry) resolve(fingerprint string) (*StoredResponse, error) {
+	if e.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyMismatch
+	}
+	if e.Response == nil {
+		return nil, ErrIdempotencyInProgress
+	}
+	return e.Response, nil
+}
+
+func (s *idempotencyService) Begin(ctx context.Context, userID uint, key, fingerprint string) (*StoredResponse, error) {
+	if s.rdb == nil {
+		return s.beginDB(ctx, userID, key, fingerprint)
+	}
+
+	rk := cache.KeyIdempotency(userID, key)
+	pending, _ := json.Marshal(idempotencyEntry{Fingerprint: fingerprint})
+	ok, err := s.rdb.SetNX(ctx, rk, pending, IdempotencyLockTTL).Result()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return nil, nil
+	}
+
+	b, err := s.rdb.Get(ctx, rk).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// 剛好過期：視為處理中，讓 client 稍後重試
+		return nil, ErrIdempotencyInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entry idempotencyEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return nil, err
+	}
+	return entry.resolve(fingerprint)
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, userID uint, key, fingerprint string, resp StoredResponse) error {
+	if s.rdb == nil {
+		return s.repo.Complete(ctx, &model.IdempotencyRecord{
+			UserID:      userID,
+			Key:         key,
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.ContentType,
+			Body:        resp.Body,
+			ExpiresAt:   time.Now().Add(s.ttl),
+		})
+	}
+
+	data, err := json.Marshal(idempotencyEntry{Fingerprint: fingerprint, Response: &resp})
+	if err != nil {
+		return err
+	}
+	return s.rdb.Set(ctx, cache.KeyIdempotency(userID, key), data, s.ttl).Err()
+}
+
+func (s *idempotencyService) Release(ctx context.Context, userID uint, key string) error {
+	if s.rdb == nil {
+		return s.repo.Delete(ctx, userID, key)
+	}
+	return s.rdb.Del(ctx, cache.KeyIdempotency(userID, key)).Err()
+}
+
+// Extend 把處理中的佔用再延長 IdempotencyLockTTL；已完成的回應不受影響。
+func (s *idempotencyService) Extend(ctx context.Context, userID uint, key string) error {
+	if s.rdb == nil {
+		return s.repo.Extend(ctx, userID, key, time.Now().Add(IdempotencyLockTTL))
+	}
+	rk := cache.KeyIdempotency(userID, key)
+	b, err := s.rdb.Get(ctx, rk).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var entry idempotencyEntry
+	if err := json.Unmarshal(b, &entry); err != nil || entry.Response != nil {
+		return err
+	}
+	return s.rdb.Expire(ctx, rk, IdempotencyLockTTL).Err()
+}
+
+func (s *idempotencyService) beginDB(ctx context.Context, userID uint, key, fingerprint string) (*StoredResponse, error) {
+	ok, err := s.repo.Reserve(ctx, &model.IdempotencyRecord{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		ExpiresAt:   time.Now().Add(IdempotencyLockTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return nil, nil
+	}
+
+	rec, err := s.repo.Find(ctx, userID, key)
+	if err != nil {
+		return nil, err
+	}
+	if rec == nil {
+		return nil, ErrIdempotencyInProgress
+	}
+	entry := idempotencyEntry{Fingerprint: rec.Fingerprint}
+	if rec.StatusCode != 0 {
+		entry.Response = &StoredResponse{StatusCode: rec.StatusCode, ContentType: rec.ContentType, Body: rec.Body}
+	}
+	return entry.resolve(fingerprint)
+}
@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/golang/mock/gomock"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/cache"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func TestIdempotencyService_Redis(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	svc := service.NewIdempotencyService(nil, rdb, time.Hour)
+	ctx := context.Background()
+
+	resp, err := svc.Begin(ctx, 1, "k", "fp")
+	require.NoError(t, err)
+	assert.Nil(t, resp)
+
+	_, err = svc.Begin(ctx, 1, "k", "fp")
+	assert.ErrorIs(t, err, service.ErrIdempotencyInProgress)
+
+	// 處理中的 key 可以延長佔用
+	mr.FastForward(service.IdempotencyLockTTL - time.Second)
+	require.NoError(t, svc.Extend(ctx, 1, "k"))
+	assert.Equal(t, service.IdempotencyLockTTL, mr.TTL(cache.KeyIdempotency(1, "k")))
+
+	stored := service.StoredResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{"id":1}`)}
+	require.NoError(t, svc.Complete(ctx, 1, "k", "fp", stored))
+
+	resp, err = svc.Begin(ctx, 1, "k", "fp")
+	require.NoError(t, err)
+	assert.Equal(t, &stored, resp)
+
+	// 已完成的回應不受 Extend 影響
+	require.NoError(t, svc.Extend(ctx, 1, "k"))
+	assert.Equal(t, time.Hour, mr.TTL(cache.KeyIdempotency(1, "k")))
+
+	_, err = svc.Begin(ctx, 1, "k", "other")
+	assert.ErrorIs(t, err, service.ErrIdempotencyMismatch)
+
+	// 保存期限到了之後 key 可以重新使用
+	mr.FastForward(2 * time.Hour)
+	resp, err = svc.Begin(ctx, 1, "k", "other")
+	require.NoError(t, err)
+	assert.Nil(t, resp)
+}
+
+func TestIdempotencyService_DBFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockIdempotencyRepository(ctrl)
+	svc := service.NewIdempotencyService(mockRepo, nil, time.Hour)
+	ctx := context.Background()
+
+	t.Run("reserved", func(t *testing.T) {
+		mockRepo.EXPECT().Reserve(ctx, gomock.Any()).Return(true, nil)
+		resp, err := svc.Begin(ctx, 1, "k", "fp")
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		mockRepo.EXPECT().Reserve(ctx, gomock.Any()).Return(false, nil)
+		mockRepo.EXPECT().Find(ctx, uint(1), "k").Return(&model.IdempotencyRecord{
+			Fingerprint: "fp", StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`),
+		}, nil)
+		resp, err := svc.Begin(ctx, 1, "k", "fp")
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, 201, resp.StatusCode)
+	})
+
+	t.Run("in progress and mismatch", func(t *testing.T) {
+		mockRepo.EXPECT().Reserve(ctx, gomock.Any()).Return(false, nil).Times(2)
+		mockRepo.EXPECT().Find(ctx, uint(1), "k").Return(&model.IdempotencyRecord{Fingerprint: "fp"}, nil).Times(2)
+		_, err := svc.Begin(ctx, 1, "k", "fp")
+		assert.ErrorIs(t, err, service.ErrIdempotencyInProgress)
+		_, err = svc.Begin(ctx, 1, "k", "other")
+		assert.ErrorIs(t, err, service.ErrIdempotencyMismatch)
+	})
+
+	t.Run("complete and release", func(t *testing.T) {
+		mockRepo.EXPECT().Complete(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, rec *model.IdempotencyRecord) error {
+			assert.Equal(t, 201, rec.StatusCode)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), rec.ExpiresAt, time.Minute)
+			return nil
+		})
+		require.NoError(t, svc.Complete(ctx, 1, "k", "fp", service.StoredResponse{StatusCode: 201}))
+
+		mockRepo.EXPECT().Delete(ctx, uint(1), "k").Return(nil)
+		require.NoError(t, svc.Release(ctx, 1, "k"))
+	})
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/SoliMark/gotasker-pro/internal/importer"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+var ErrImportJobNotFound = errors.New("import job not found")
+
+// AsyncImportThreshold 超過此列數的匯入改以背景工作執行，client 透過工作 ID 查詢進度。
+const AsyncImportThreshold = 1000
+
+// StaleImportAfter 是背景匯入沒有任何進度更新多久後視為中斷；執行中的工作每寫入一批就會更新 updated_at。
+const StaleImportAfter = 10 * time.Minute
+
+// errImportInterrupted 是中斷的工作記錄的錯誤訊息。
+const errImportInterrupted = "import interrupted; please retry"
+
+type ImportService interface {
+	Import(ctx context.Context, userID uint, rows []importer.Row, dryRun bool) (*ImportReport, error)
+	StartImport(ctx context.Context, userID uint, format string, rows []importer.Row) (*model.ImportJob, error)
+	GetJob(ctx context.Context, userID, jobID uint) (*model.ImportJob, error)
+	// FailStaleJobs 把超過 StaleImportAfter 沒有進度的 pending/running 工作標為 failed，
+	// 例如程序在匯入途中重啟；回傳標記的筆數。
+	FailStaleJobs(ctx context.Context) (int64, error)
+}
+
+type importService struct {
+	tasks TaskService
+	jobs  repository.ImportJobRepository
+}
+
+func NewImportService(tasks TaskService, jobs repository.ImportJobRepository) ImportService {
+	return &importService{tasks: tasks, jobs: jobs}
+}
+
+// Import 同步檢查並寫入（或試跑）。
+func (s *importService) Import(ctx context.Context, userID uint, rows []importer.Row, dryRun bool) (*ImportReport, error) {
+	return s.tasks.ImportTasks(ctx, userID, rows, ImportOptions{DryRun: dryRun})
+}
+
+// StartImport 建立匯入工作後立即返回，實際寫入在背景進行。
+// 背景工作不綁定請求的 context，client 斷線不會中斷匯入；程序重啟時中斷的工作由 FailStaleJobs 標為 failed。
+func (s *importService) StartImport(ctx context.Context, userID uint, format string, rows []importer.Row) (*model.ImportJob, error) {
+	job := &model.ImportJob{
+		UserID: userID,
+		Format: format,
+		Status: model.ImportJobPending,
+		Total:  len(rows),
+	}
+	if err := s.jobs.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	snapshot := *job
+	go s.run(snapshot, rows)
+	return job, nil
+}
+
+func (s *importService) run(job model.ImportJob, rows []importer.Row) {
+	ctx := context.Background()
+
+	job.Status = model.ImportJobRunning
+	if err := s.jobs.Update(ctx, &job); err != nil {
+		log.Printf("import: job %d: %v", job.ID, err)
+	}
+
+	report, err := s.tasks.ImportTasks(ctx, job.UserID, rows, ImportOptions{
+		Progress: func(done int) {
+			if err := s.jobs.UpdateProgress(ctx, job.ID, done); err != nil {
+				log.Printf("import: job %d progress: %v", job.ID, err)
+			}
+		},
+	})
+	switch {
+	case err == nil:
+		job.Status = model.ImportJobSucceeded
+		job.Processed = report.Imported
+	case errors.Is(err, ErrImportInvalid):
+		job.Status = model.ImportJobFailed
+		job.Error = err.Error()
+		if b, mErr := json.Marshal(report.Errors); mErr == nil {
+			job.Errors = string(b)
+		}
+	default:
+		log.Printf("import: job %d: %v", job.ID, err)
+		job.Status = model.ImportJobFailed
+		job.Error = "internal error"
+	}
+	if err := s.jobs.Update(ctx, &job); err != nil {
+		log.Printf("import: job %d: %v", job.ID, err)
+	}
+}
+
+func (s *importService) FailStaleJobs(ctx context.Context) (int64, error) {
+	return s.jobs.FailStale(ctx, time.Now().Add(-StaleImportAfter), errImportInterrupted)
+}
+
+func (s *importService) GetJob(ctx context.Context, userID, jobID uint) (*model.ImportJob, error) {
+	job, err := s.jobs.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	// 不屬於自己的工作一律視為不存在，避免洩漏工作 ID
+	if job == nil || job.UserID != userID {
+		return nil, ErrImportJobNotFound
+	}
+	return job, nil
+}
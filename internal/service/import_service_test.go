@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/importer"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
+)
+
+func TestImportService_StartImport(t *testing.T) {
+	ctx := context.Background()
+	rows := []importer.Row{{Line: 2, Task: model.Task{Title: "a"}}}
+
+	setup := func(t *testing.T) (*mock_service.MockTaskService, *mock_repository.MockImportJobRepository, service.ImportService) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+		tasks := mock_service.NewMockTaskService(ctrl)
+		jobs := mock_repository.NewMockImportJobRepository(ctrl)
+		return tasks, jobs, service.NewImportService(tasks, jobs)
+	}
+
+	t.Run("runs in the background and records progress", func(t *testing.T) {
+		tasks, jobs, svc := setup(t)
+		finished := make(chan model.ImportJob, 1)
+
+		jobs.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, job *model.ImportJob) error {
+			job.ID = 3
+			return nil
+		})
+		gomock.InOrder(
+			jobs.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job *model.ImportJob) error {
+				assert.Equal(t, model.ImportJobRunning, job.Status)
+				return nil
+			}),
+			jobs.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job *model.ImportJob) error {
+				finished <- *job
+				return nil
+			}),
+		)
+		jobs.EXPECT().UpdateProgress(gomock.Any(), uint(3), 1).Return(nil)
+		tasks.EXPECT().ImportTasks(gomock.Any(), uint(7), rows, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, _ []importer.Row, opts service.ImportOptions) (*service.ImportReport, error) {
+				assert.False(t, opts.DryRun)
+				opts.Progress(1)
+				return &service.ImportReport{Total: 1, Valid: 1, Imported: 1}, nil
+			})
+
+		job, err := svc.StartImport(ctx, 7, importer.FormatCSV, rows)
+		require.NoError(t, err)
+		assert.Equal(t, model.ImportJobPending, job.Status)
+		assert.Equal(t, 1, job.Total)
+
+		select {
+		case got := <-finished:
+			assert.Equal(t, model.ImportJobSucceeded, got.Status)
+			assert.Equal(t, 1, got.Processed)
+		case <-time.After(time.Second):
+			t.Fatal("import job did not finish")
+		}
+	})
+
+	t.Run("invalid rows fail the job with row errors", func(t *testing.T) {
+		tasks, jobs, svc := setup(t)
+		finished := make(chan model.ImportJob, 1)
+
+		jobs.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		gomock.InOrder(
+			jobs.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil),
+			jobs.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job *model.ImportJob) error {
+				finished <- *job
+				return nil
+			}),
+		)
+		tasks.EXPECT().ImportTasks(gomock.Any(), uint(7), rows, gomock.Any()).
+			Return(&service.ImportReport{Total: 1, Errors: []service.RowError{{Line: 2, Error: "title is required"}}}, service.ErrImportInvalid)
+
+		_, err := svc.StartImport(ctx, 7, importer.FormatCSV, rows)
+		require.NoError(t, err)
+
+		select {
+		case got := <-finished:
+			assert.Equal(t, model.ImportJobFailed, got.Status)
+			assert.JSONEq(t, `[{"line":2,"error":"title is required"}]`, got.Errors)
+		case <-time.After(time.Second):
+			t.Fatal("import job did not finish")
+		}
+	})
+}
+
+func TestImportService_GetJob(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobs := mock_repository.NewMockImportJobRepository(ctrl)
+	svc := service.NewImportService(mock_service.NewMockTaskService(ctrl), jobs)
+
+	jobs.EXPECT().FindByID(ctx, uint(3)).Return(&model.ImportJob{ID: 3, UserID: 7}, nil).Times(2)
+	jobs.EXPECT().FindByID(ctx, uint(4)).Return(nil, nil)
+
+	job, err := svc.GetJob(ctx, 7, 3)
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), job.ID)
+
+	_, err = svc.GetJob(ctx, 8, 3)
+	assert.ErrorIs(t, err, service.ErrImportJobNotFound)
+	_, err = svc.GetJob(ctx, 7, 4)
+	assert.ErrorIs(t, err, service.ErrImportJobNotFound)
+}
+
+func TestImportService_FailStaleJobs(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobs := mock_repository.NewMockImportJobRepository(ctrl)
+	svc := service.NewImportService(mock_service.NewMockTaskService(ctrl), jobs)
+
+	jobs.EXPECT().FailStale(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time, reason string) (int64, error) {
+		assert.WithinDuration(t, time.Now().Add(-service.StaleImportAfter), before, time.Second)
+		assert.NotEmpty(t, reason)
+		return 2, nil
+	})
+	n, err := svc.FailStaleJobs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+}
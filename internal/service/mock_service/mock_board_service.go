@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/board_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	realtime "github.com/SoliMark/gotasker-pro/internal/realtime"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBoardService is a mock of BoardService interface.
+type MockBoardService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardServiceMockRecorder
+}
+
+// MockBoardServiceMockRecorder is the mock recorder for MockBoardService.
+type MockBoardServiceMockRecorder struct {
+	mock *MockBoardService
+}
+
+// NewMockBoardService creates a new mock instance.
+func NewMockBoardService(ctrl *gomock.Controller) *MockBoardService {
+	mock := &MockBoardService{ctrl: ctrl}
+	mock.recorder = &MockBoardServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardService) EXPECT() *MockBoardServiceMockRecorder {
+	return m.recorder
+}
+
+// Connect mocks base method.
+func (m *MockBoardService) Connect(userID uint) *realtime.BoardClient {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Connect", userID)
+	ret0, _ := ret[0].(*realtime.BoardClient)
+	return ret0
+}
+
+// Connect indicates an expected call of Connect.
+func (mr *MockBoardServiceMockRecorder) Connect(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockBoardService)(nil).Connect), userID)
+}
+
+// Disconnect mocks base method.
+func (m *MockBoardService) Disconnect(c *realtime.BoardClient) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Disconnect", c)
+}
+
+// Disconnect indicates an expected call of Disconnect.
+func (mr *MockBoardServiceMockRecorder) Disconnect(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnect", reflect.TypeOf((*MockBoardService)(nil).Disconnect), c)
+}
+
+// Send mocks base method.
+func (m *MockBoardService) Send(c *realtime.BoardClient, msg realtime.BoardMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Send", c, msg)
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockBoardServiceMockRecorder) Send(c, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockBoardService)(nil).Send), c, msg)
+}
+
+// Subscribe mocks base method.
+func (m *MockBoardService) Subscribe(ctx context.Context, c *realtime.BoardClient, projectID uint) ([]realtime.Presence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, c, projectID)
+	ret0, _ := ret[0].([]realtime.Presence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockBoardServiceMockRecorder) Subscribe(ctx, c, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockBoardService)(nil).Subscribe), ctx, c, projectID)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockBoardService) Unsubscribe(c *realtime.BoardClient, projectID uint) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe", c, projectID)
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockBoardServiceMockRecorder) Unsubscribe(c, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockBoardService)(nil).Unsubscribe), c, projectID)
+}
+
+// UpdatePresence mocks base method.
+func (m *MockBoardService) UpdatePresence(ctx context.Context, c *realtime.BoardClient, projectID uint, taskID *uint, state string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePresence", ctx, c, projectID, taskID, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePresence indicates an expected call of UpdatePresence.
+func (mr *MockBoardServiceMockRecorder) UpdatePresence(ctx, c, projectID, taskID, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePresence", reflect.TypeOf((*MockBoardService)(nil).UpdatePresence), ctx, c, projectID, taskID, state)
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/stream_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SoliMark/gotasker-pro/internal/model"
+	service "github.com/SoliMark/gotasker-pro/internal/service"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStreamService is a mock of StreamService interface.
+type MockStreamService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamServiceMockRecorder
+}
+
+// MockStreamServiceMockRecorder is the mock recorder for MockStreamService.
+type MockStreamServiceMockRecorder struct {
+	mock *MockStreamService
+}
+
+// NewMockStreamService creates a new mock instance.
+func NewMockStreamService(ctrl *gomock.Controller) *MockStreamService {
+	mock := &MockStreamService{ctrl: ctrl}
+	mock.recorder = &MockStreamServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamService) EXPECT() *MockStreamServiceMockRecorder {
+	return m.recorder
+}
+
+// Open mocks base method.
+func (m *MockStreamService) Open(ctx context.Context, userID uint, lastEventID string) (*service.Stream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, userID, lastEventID)
+	ret0, _ := ret[0].(*service.Stream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockStreamServiceMockRecorder) Open(ctx, userID, lastEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockStreamService)(nil).Open), ctx, userID, lastEventID)
+}
+
+// PublishTaskEvent mocks base method.
+func (m *MockStreamService) PublishTaskEvent(ctx context.Context, event string, task *model.Task) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishTaskEvent", ctx, event, task)
+}
+
+// PublishTaskEvent indicates an expected call of PublishTaskEvent.
+func (mr *MockStreamServiceMockRecorder) PublishTaskEvent(ctx, event, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishTaskEvent", reflect.TypeOf((*MockStreamService)(nil).PublishTaskEvent), ctx, event, task)
+}
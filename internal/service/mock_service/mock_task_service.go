@@ -8,7 +8,10 @@ import (
 	context "context"
 	reflect "reflect"
 
+	importer "github.com/SoliMark/gotasker-pro/internal/importer"
 	model "github.com/SoliMark/gotasker-pro/internal/model"
+	repository "github.com/SoliMark/gotasker-pro/internal/repository"
+	service "github.com/SoliMark/gotasker-pro/internal/service"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -35,6 +38,36 @@ func (m *MockTaskService) EXPECT() *MockTaskServiceMockRecorder {
 	return m.recorder
 }
 
+// ApplySync mocks base method.
+func (m *MockTaskService) ApplySync(ctx context.Context, userID uint, mutations []service.SyncMutation) ([]service.SyncResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySync", ctx, userID, mutations)
+	ret0, _ := ret[0].([]service.SyncResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplySync indicates an expected call of ApplySync.
+func (mr *MockTaskServiceMockRecorder) ApplySync(ctx, userID, mutations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySync", reflect.TypeOf((*MockTaskService)(nil).ApplySync), ctx, userID, mutations)
+}
+
+// BulkApply mocks base method.
+func (m *MockTaskService) BulkApply(ctx context.Context, userID uint, mode service.BulkMode, ops []service.BulkOperation) ([]service.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkApply", ctx, userID, mode, ops)
+	ret0, _ := ret[0].([]service.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkApply indicates an expected call of BulkApply.
+func (mr *MockTaskServiceMockRecorder) BulkApply(ctx, userID, mode, ops interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkApply", reflect.TypeOf((*MockTaskService)(nil).BulkApply), ctx, userID, mode, ops)
+}
+
 // CreateTask mocks base method.
 func (m *MockTaskService) CreateTask(ctx context.Context, task *model.Task) error {
 	m.ctrl.T.Helper()
@@ -63,6 +96,20 @@ func (mr *MockTaskServiceMockRecorder) DeleteTask(ctx, userID, taskID interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskService)(nil).DeleteTask), ctx, userID, taskID)
 }
 
+// ExportTasks mocks base method.
+func (m *MockTaskService) ExportTasks(ctx context.Context, userID uint, fn func(*model.Task) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTasks", ctx, userID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTasks indicates an expected call of ExportTasks.
+func (mr *MockTaskServiceMockRecorder) ExportTasks(ctx, userID, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTasks", reflect.TypeOf((*MockTaskService)(nil).ExportTasks), ctx, userID, fn)
+}
+
 // GetTask mocks base method.
 func (m *MockTaskService) GetTask(ctx context.Context, id uint) (*model.Task, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +125,66 @@ func (mr *MockTaskServiceMockRecorder) GetTask(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskService)(nil).GetTask), ctx, id)
 }
 
+// ImportTasks mocks base method.
+func (m *MockTaskService) ImportTasks(ctx context.Context, userID uint, rows []importer.Row, opts service.ImportOptions) (*service.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTasks", ctx, userID, rows, opts)
+	ret0, _ := ret[0].(*service.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTasks indicates an expected call of ImportTasks.
+func (mr *MockTaskServiceMockRecorder) ImportTasks(ctx, userID, rows, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTasks", reflect.TypeOf((*MockTaskService)(nil).ImportTasks), ctx, userID, rows, opts)
+}
+
+// ListSubtasks mocks base method.
+func (m *MockTaskService) ListSubtasks(ctx context.Context, userID uint, parentIDs []uint) (map[uint][]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubtasks", ctx, userID, parentIDs)
+	ret0, _ := ret[0].(map[uint][]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubtasks indicates an expected call of ListSubtasks.
+func (mr *MockTaskServiceMockRecorder) ListSubtasks(ctx, userID, parentIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubtasks", reflect.TypeOf((*MockTaskService)(nil).ListSubtasks), ctx, userID, parentIDs)
+}
+
+// ListTaskPage mocks base method.
+func (m *MockTaskService) ListTaskPage(ctx context.Context, userID uint, req service.TaskPageRequest) (*service.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskPage", ctx, userID, req)
+	ret0, _ := ret[0].(*service.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskPage indicates an expected call of ListTaskPage.
+func (mr *MockTaskServiceMockRecorder) ListTaskPage(ctx, userID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskPage", reflect.TypeOf((*MockTaskService)(nil).ListTaskPage), ctx, userID, req)
+}
+
+// ListTaskTags mocks base method.
+func (m *MockTaskService) ListTaskTags(ctx context.Context, userID uint, taskIDs []uint) (map[uint][]*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskTags", ctx, userID, taskIDs)
+	ret0, _ := ret[0].(map[uint][]*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskTags indicates an expected call of ListTaskTags.
+func (mr *MockTaskServiceMockRecorder) ListTaskTags(ctx, userID, taskIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskTags", reflect.TypeOf((*MockTaskService)(nil).ListTaskTags), ctx, userID, taskIDs)
+}
+
 // ListTasks mocks base method.
 func (m *MockTaskService) ListTasks(ctx context.Context, userID uint) ([]*model.Task, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +200,51 @@ func (mr *MockTaskServiceMockRecorder) ListTasks(ctx, userID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskService)(nil).ListTasks), ctx, userID)
 }
 
+// MoveTask mocks base method.
+func (m *MockTaskService) MoveTask(ctx context.Context, userID, taskID uint, opts service.MoveOptions) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", ctx, userID, taskID, opts)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockTaskServiceMockRecorder) MoveTask(ctx, userID, taskID, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockTaskService)(nil).MoveTask), ctx, userID, taskID, opts)
+}
+
+// SearchTasks mocks base method.
+func (m *MockTaskService) SearchTasks(ctx context.Context, userID uint, query string, limit int) ([]repository.SearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTasks", ctx, userID, query, limit)
+	ret0, _ := ret[0].([]repository.SearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTasks indicates an expected call of SearchTasks.
+func (mr *MockTaskServiceMockRecorder) SearchTasks(ctx, userID, query, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTasks", reflect.TypeOf((*MockTaskService)(nil).SearchTasks), ctx, userID, query, limit)
+}
+
+// SyncChanges mocks base method.
+func (m *MockTaskService) SyncChanges(ctx context.Context, userID uint, token string, limit int) (*service.SyncPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncChanges", ctx, userID, token, limit)
+	ret0, _ := ret[0].(*service.SyncPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncChanges indicates an expected call of SyncChanges.
+func (mr *MockTaskServiceMockRecorder) SyncChanges(ctx, userID, token, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncChanges", reflect.TypeOf((*MockTaskService)(nil).SyncChanges), ctx, userID, token, limit)
+}
+
 // UpdateTask mocks base method.
 func (m *MockTaskService) UpdateTask(ctx context.Context, task *model.Task) error {
 	m.ctrl.T.Helper()
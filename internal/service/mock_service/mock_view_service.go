@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/view_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SoliMark/gotasker-pro/internal/model"
+	service "github.com/SoliMark/gotasker-pro/internal/service"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockViewService is a mock of ViewService interface.
+type MockViewService struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewServiceMockRecorder
+}
+
+// MockViewServiceMockRecorder is the mock recorder for MockViewService.
+type MockViewServiceMockRecorder struct {
+	mock *MockViewService
+}
+
+// NewMockViewService creates a new mock instance.
+func NewMockViewService(ctrl *gomock.Controller) *MockViewService {
+	mock := &MockViewService{ctrl: ctrl}
+	mock.recorder = &MockViewServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockViewService) EXPECT() *MockViewServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateView mocks base method.
+func (m *MockViewService) CreateView(ctx context.Context, userID uint, in service.ViewInput) (*model.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateView", ctx, userID, in)
+	ret0, _ := ret[0].(*model.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateView indicates an expected call of CreateView.
+func (mr *MockViewServiceMockRecorder) CreateView(ctx, userID, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateView", reflect.TypeOf((*MockViewService)(nil).CreateView), ctx, userID, in)
+}
+
+// DeleteView mocks base method.
+func (m *MockViewService) DeleteView(ctx context.Context, userID, viewID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteView", ctx, userID, viewID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteView indicates an expected call of DeleteView.
+func (mr *MockViewServiceMockRecorder) DeleteView(ctx, userID, viewID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteView", reflect.TypeOf((*MockViewService)(nil).DeleteView), ctx, userID, viewID)
+}
+
+// GetView mocks base method.
+func (m *MockViewService) GetView(ctx context.Context, userID, viewID uint) (*model.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetView", ctx, userID, viewID)
+	ret0, _ := ret[0].(*model.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetView indicates an expected call of GetView.
+func (mr *MockViewServiceMockRecorder) GetView(ctx, userID, viewID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetView", reflect.TypeOf((*MockViewService)(nil).GetView), ctx, userID, viewID)
+}
+
+// ListViews mocks base method.
+func (m *MockViewService) ListViews(ctx context.Context, userID uint) ([]*model.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListViews", ctx, userID)
+	ret0, _ := ret[0].([]*model.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListViews indicates an expected call of ListViews.
+func (mr *MockViewServiceMockRecorder) ListViews(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViews", reflect.TypeOf((*MockViewService)(nil).ListViews), ctx, userID)
+}
+
+// RunView mocks base method.
+func (m *MockViewService) RunView(ctx context.Context, userID, viewID uint, limit int, cursor string) (*service.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunView", ctx, userID, viewID, limit, cursor)
+	ret0, _ := ret[0].(*service.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunView indicates an expected call of RunView.
+func (mr *MockViewServiceMockRecorder) RunView(ctx, userID, viewID, limit, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunView", reflect.TypeOf((*MockViewService)(nil).RunView), ctx, userID, viewID, limit, cursor)
+}
+
+// UpdateView mocks base method.
+func (m *MockViewService) UpdateView(ctx context.Context, userID, viewID uint, in service.ViewInput) (*model.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateView", ctx, userID, viewID, in)
+	ret0, _ := ret[0].(*model.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateView indicates an expected call of UpdateView.
+func (mr *MockViewServiceMockRecorder) UpdateView(ctx, userID, viewID, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateView", reflect.TypeOf((*MockViewService)(nil).UpdateView), ctx, userID, viewID, in)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+	ErrInvalidProject  = errors.New("invalid project")
+	ErrInvalidMember   = errors.New("member must be an existing user other than the owner")
+)
+
+type ProjectService interface {
+	CreateProject(ctx context.Context, userID uint, name string) (*model.Project, error)
+	// ListProjects 列出使用者擁有或是成員的專案。
+	ListProjects(ctx context.Context, userID uint) ([]*model.Project, error)
+	// AddMember 只有擁有者可以加入成員。
+	AddMember(ctx context.Context, ownerID, projectID, memberID uint) error
+	// RemoveMember 擁有者可以移除任何成員，成員也可以移除自己（退出專案）。
+	RemoveMember(ctx context.Context, userID, projectID, memberID uint) error
+	// ListMembers 擁有者與成員都可以查看成員。
+	ListMembers(ctx context.Context, userID, projectID uint) ([]*model.ProjectMember, error)
+	// GetProjects 一次查出多個專案，只回傳屬於 userID 的專案。
+	GetProjects(ctx context.Context, userID uint, ids []uint) ([]*model.Project, error)
+}
+
+type projectService struct {
+	repo  repository.ProjectRepository
+	users repository.UserRepository
+}
+
+func NewProjectService(repo repository.ProjectRepository, users repository.UserRepository) ProjectService {
+	return &projectService{repo: repo, users: users}
+}
+
+func (s *projectService) CreateProject(ctx context.Context, userID uint, name string) (*model.Project, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, ErrInvalidProject
+	}
+	project := &model.Project{UserID: userID, Name: name}
+	if err := s.repo.Create(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (s *projectService) ListProjects(ctx context.Context, userID uint) ([]*model.Project, error) {
+	return s.repo.ListByUserID(ctx, userID)
+}
+
+func (s *projectService) AddMember(ctx context.Context, ownerID, projectID, memberID uint) error {
+	if _, err := ownedProject(ctx, s.repo, ownerID, projectID); err != nil {
+		return err
+	}
+	if memberID == ownerID {
+		return ErrInvalidMember
+	}
+	user, err := s.users.FindByID(ctx, memberID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrInvalidMember
+	}
+	return s.repo.AddMember(ctx, &model.ProjectMember{ProjectID: projectID, UserID: memberID})
+}
+
+func (s *projectService) RemoveMember(ctx context.Context, userID, projectID, memberID uint) error {
+	if userID == memberID {
+		if _, err := accessibleProject(ctx, s.repo, userID, projectID); err != nil {
+			return err
+		}
+	} else if _, err := ownedProject(ctx, s.repo, userID, projectID); err != nil {
+		return err
+	}
+	return s.repo.RemoveMember(ctx, projectID, memberID)
+}
+
+func (s *projectService) ListMembers(ctx context.Context, userID, projectID uint) ([]*model.ProjectMember, error) {
+	if _, err := accessibleProject(ctx, s.repo, userID, projectID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMembers(ctx, projectID)
+}
+
+func (s *projectService) GetProjects(ctx context.Context, userID uint, ids []uint) ([]*model.Project, error) {
+	projects, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	owned := projects[:0]
+	for _, p := range projects {
+		if p.UserID == userID {
+			owned = append(owned, p)
+		}
+	}
+	return owned, nil
+}
+
+// ownedProject 確認專案存在且屬於 userID。
+func ownedProject(ctx context.Context, repo repository.ProjectRepository, userID, projectID uint) (*model.Project, error) {
+	if repo == nil {
+		return nil, ErrProjectNotFound
+	}
+	project, err := repo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	if project.UserID != userID {
+		return nil, ErrPermissionDenied
+	}
+	return project, nil
+}
+
+// accessibleProject 確認專案存在，且 userID 是擁有者或成員。
+func accessibleProject(ctx context.Context, repo repository.ProjectRepository, userID, projectID uint) (*model.Project, error) {
+	if repo == nil {
+		return nil, ErrProjectNotFound
+	}
+	project, err := repo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	if project.UserID == userID {
+		return project, nil
+	}
+	member, err := repo.IsMember(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member {
+		return nil, ErrPermissionDenied
+	}
+	return project, nil
+}
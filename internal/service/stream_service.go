@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+
+	"github.com/SoliMark/gotasker-pro/internal/export"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/realtime"
+)
+
+// Stream 是一條即時事件連線。Replay 為依 Last-Event-ID 補送的事件，之後再讀 Events；
+// Reset 表示 Last-Event-ID 已超出緩衝區，client 應重新載入任務清單。
+type Stream struct {
+	Replay []realtime.Event
+	Reset  bool
+	Events <-chan realtime.Event
+	sub    *realtime.Subscription
+}
+
+func (s *Stream) Close() {
+	s.sub.Close()
+}
+
+// StreamService 同時實作 TaskEventPublisher，把任務事件推送給該使用者的所有連線。
+type StreamService interface {
+	PublishTaskEvent(ctx context.Context, event string, task *model.Task)
+	Open(ctx context.Context, userID uint, lastEventID string) (*Stream, error)
+}
+
+type streamService struct {
+	broker realtime.Broker
+}
+
+func NewStreamService(broker realtime.Broker) StreamService {
+	return &streamService{broker: broker}
+}
+
+func (s *streamService) PublishTaskEvent(ctx context.Context, event string, task *model.Task) {
+	data, err := json.Marshal(export.NewRecord(task))
+	if err == nil {
+		err = s.broker.Publish(ctx, task.UserID, event, data)
+	}
+	if err != nil {
+		log.Printf("stream: publish %s for task %d: %v", event, task.ID, err)
+	}
+}
+
+// Open 先訂閱再讀緩衝區，確保兩者之間發生的事件不會遺漏；重複的事件由呼叫端依 ID 略過。
+func (s *streamService) Open(ctx context.Context, userID uint, lastEventID string) (*Stream, error) {
+	sub := s.broker.Subscribe(userID)
+	st := &Stream{Events: sub.C, sub: sub}
+	if lastEventID == "" {
+		return st, nil
+	}
+
+	after, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil {
+		st.Reset = true
+		return st, nil
+	}
+	events, ok, err := s.broker.Replay(ctx, userID, after)
+	if err != nil {
+		sub.Close()
+		return nil, err
+	}
+	st.Replay, st.Reset = events, !ok
+	return st, nil
+}
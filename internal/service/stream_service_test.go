@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/realtime"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func TestStreamService(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewStreamService(realtime.NewMemoryBroker())
+
+	svc.PublishTaskEvent(ctx, service.EventTaskCreated, &model.Task{ID: 1, UserID: 7, Title: "a"})
+	svc.PublishTaskEvent(ctx, service.EventTaskUpdated, &model.Task{ID: 1, UserID: 7, Title: "b"})
+
+	t.Run("resume from Last-Event-ID", func(t *testing.T) {
+		st, err := svc.Open(ctx, 7, "1")
+		require.NoError(t, err)
+		defer st.Close()
+
+		assert.False(t, st.Reset)
+		require.Len(t, st.Replay, 1)
+		assert.Equal(t, service.EventTaskUpdated, st.Replay[0].Type)
+		assert.Contains(t, string(st.Replay[0].Data), `"title":"b"`)
+
+		svc.PublishTaskEvent(ctx, service.EventTaskDeleted, &model.Task{ID: 1, UserID: 7})
+		ev := <-st.Events
+		assert.Equal(t, uint64(3), ev.ID)
+	})
+
+	t.Run("new connection gets no replay", func(t *testing.T) {
+		st, err := svc.Open(ctx, 7, "")
+		require.NoError(t, err)
+		defer st.Close()
+		assert.Empty(t, st.Replay)
+		assert.False(t, st.Reset)
+	})
+
+	t.Run("unknown id asks for reset", func(t *testing.T) {
+		st, err := svc.Open(ctx, 7, "not-a-number")
+		require.NoError(t, err)
+		defer st.Close()
+		assert.True(t, st.Reset)
+	})
+}
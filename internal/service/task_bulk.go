@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+var (
+	ErrInvalidBulk   = errors.New("invalid bulk request")
+	ErrInvalidBulkOp = errors.New("invalid bulk operation")
+	ErrBulkAborted   = errors.New("bulk operation aborted")
+)
+
+const MaxBulkOperations = 500
+
+// BulkMode 決定單筆失敗時的行為：atomic 全部回滾，best_effort 只略過失敗的項目。
+type BulkMode string
+
+const (
+	BulkAtomic     BulkMode = "atomic"
+	BulkBestEffort BulkMode = "best_effort"
+)
+
+const (
+	BulkOpSetStatus   = "set_status"
+	BulkOpDelete      = "delete"
+	BulkOpMoveProject = "move_project"
+	BulkOpAddTag      = "add_tag"
+)
+
+const (
+	BulkResultOK         = "ok"
+	BulkResultFailed     = "failed"
+	BulkResultRolledBack = "rolled_back"
+)
+
+// BulkOperation 是對單一任務的操作；依 Op 使用 Status、ProjectID（nil 表示移出專案）或 Tag。
+type BulkOperation struct {
+	Op        string
+	TaskID    uint
+	Status    string
+	ProjectID *uint
+	Tag       string
+}
+
+// BulkResult 與 BulkOperation 一一對應；Status 為 ok / failed / rolled_back，失敗時 Err 為原因。
+type BulkResult struct {
+	TaskID uint
+	Status string
+	Err    error
+}
+
+// BulkApply 在同一個交易中依序執行 ops，每個任務都會檢查擁有者，最後只清一次快取。
+// atomic 模式下任一項失敗即整批回滾並回傳 ErrBulkAborted（results 仍標示每一項的結果）；
+// best_effort 模式下每一項各自使用 savepoint，失敗的項目不影響其他項目。
+func (s *taskService) BulkApply(ctx context.Context, userID uint, mode BulkMode, ops []BulkOperation) ([]BulkResult, error) {
+	if mode != BulkAtomic && mode != BulkBestEffort {
+		return nil, ErrInvalidBulk
+	}
+	if len(ops) == 0 || len(ops) > MaxBulkOperations {
+		return nil, ErrInvalidBulk
+	}
+
+	ids := make([]uint, 0, len(ops))
+	needWorkflow := false
+	for _, op := range ops {
+		ids = append(ids, op.TaskID)
+		needWorkflow = needWorkflow || op.Op == BulkOpSetStatus
+	}
+	found, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make(map[uint]*model.Task, len(found))
+	wasCompleted := make(map[uint]bool, len(found))
+	for _, t := range found {
+		tasks[t.ID] = t
+		wasCompleted[t.ID] = t.CompletedAt != nil
+	}
+
+	var wf *model.Workflow
+	if needWorkflow {
+		if wf, err = loadWorkflow(ctx, s.workflows, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	b := &bulkRun{s: s, userID: userID, wf: wf, tasks: tasks, projects: map[uint]error{}}
+	results := make([]BulkResult, len(ops))
+	deleted := map[uint]bool{}
+	touched := map[uint]bool{}
+	aborted := false
+
+	err = s.repo.Transaction(ctx, func(tx repository.TaskRepository) error {
+		for i, op := range ops {
+			results[i].TaskID = op.TaskID
+
+			task, err := b.target(op.TaskID, deleted)
+			if err == nil {
+				snapshot := *task
+				apply := func(r repository.TaskRepository) error { return b.apply(ctx, r, task, op) }
+				if mode == BulkAtomic {
+					err = apply(tx)
+				} else {
+					err = tx.Transaction(ctx, apply)
+				}
+				if err != nil {
+					*task = snapshot
+				}
+			}
+			if err != nil {
+				results[i].Status, results[i].Err = BulkResultFailed, err
+				if mode == BulkAtomic {
+					aborted = true
+					return ErrBulkAborted
+				}
+				continue
+			}
+
+			results[i].Status = BulkResultOK
+			if op.Op == BulkOpDelete {
+				deleted[op.TaskID] = true
+			} else {
+				touched[op.TaskID] = true
+			}
+		}
+		return nil
+	})
+	if aborted {
+		for i := range results {
+			if results[i].Status != BulkResultFailed {
+				results[i].Status = BulkResultRolledBack
+			}
+		}
+		return results, ErrBulkAborted
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deleted) > 0 || len(touched) > 0 {
+		changed := make([]uint, 0, len(deleted)+len(touched))
+		for id := range deleted {
+			changed = append(changed, id)
+		}
+		for id := range touched {
+			if !deleted[id] {
+				changed = append(changed, id)
+			}
+		}
+		s.invalidateUserTasks(ctx, userID, changed...)
+	}
+	for id := range touched {
+		if !deleted[id] {
+			s.reindex(ctx, id)
+		}
+	}
+	if s.search != nil {
+		for id := range deleted {
+			if e := s.search.Remove(ctx, id); e != nil {
+				log.Printf("search: remove task %d: %v", id, e)
+			}
+		}
+	}
+
+	// 依操作順序對每個任務只發出一次事件
+	published := map[uint]bool{}
+	for _, op := range ops {
+		id := op.TaskID
+		if published[id] || !(touched[id] || deleted[id]) {
+			continue
+		}
+		published[id] = true
+		if deleted[id] {
+			s.publish(ctx, EventTaskDeleted, tasks[id])
+		} else {
+			s.publishUpdate(ctx, wasCompleted[id], tasks[id])
+		}
+	}
+	return results, nil
+}
+
+// bulkRun 保存一次 BulkApply 的共用狀態：預先載入的任務、流程，以及已檢查過的專案。
+type bulkRun struct {
+	s        *taskService
+	userID   uint
+	wf       *model.Workflow
+	tasks    map[uint]*model.Task
+	projects map[uint]error
+}
+
+func (b *bulkRun) target(id uint, deleted map[uint]bool) (*model.Task, error) {
+	task, ok := b.tasks[id]
+	if !ok || deleted[id] {
+		return nil, ErrTaskNotFound
+	}
+	if task.UserID != b.userID {
+		return nil, ErrPermissionDenied
+	}
+	return task, nil
+}
+
+func (b *bulkRun) apply(ctx context.Context, r repository.TaskRepository, task *model.Task, op BulkOperation) error {
+	switch op.Op {
+	case BulkOpSetStatus:
+		current := *task
+		task.Status = op.Status
+		if err := transition(b.wf, &current, task); err != nil {
+			return err
+		}
+		return r.UpdateTask(ctx, task)
+
+	case BulkOpDelete:
+		return r.DeleteTask(ctx, task.ID)
+
+	case BulkOpMoveProject:
+		if op.ProjectID != nil {
+			if err := b.checkProject(ctx, *op.ProjectID); err != nil {
+				return err
+			}
+		}
+		task.ProjectID = op.ProjectID
+		return r.UpdateTask(ctx, task)
+
+	case BulkOpAddTag:
+		name := strings.TrimSpace(op.Tag)
+		if name == "" || len(name) > 50 {
+			return ErrInvalidBulkOp
+		}
+		return r.AddTag(ctx, task, name)
+
+	default:
+		return ErrInvalidBulkOp
+	}
+}
+
+func (b *bulkRun) checkProject(ctx context.Context, projectID uint) error {
+	if err, ok := b.projects[projectID]; ok {
+		return err
+	}
+	_, err := ownedProject(ctx, b.s.projects, b.userID, projectID)
+	b.projects[projectID] = err
+	return err
+}
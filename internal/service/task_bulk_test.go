@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/golang/mock/gomock"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/cache"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+// runInTx 讓 mock 的 Transaction 直接以同一個 mock 執行 fn。
+func runInTx(m *mock_repository.MockTaskRepository) func(context.Context, func(repository.TaskRepository) error) error {
+	return func(_ context.Context, fn func(repository.TaskRepository) error) error { return fn(m) }
+}
+
+func TestTaskService_BulkApply(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*mock_repository.MockTaskRepository, *mock_repository.MockProjectRepository, *redis.Client, service.TaskService) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+		mr, err := miniredis.Run()
+		require.NoError(t, err)
+		t.Cleanup(mr.Close)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+		repo := mock_repository.NewMockTaskRepository(ctrl)
+		projects := mock_repository.NewMockProjectRepository(ctrl)
+		svc := service.NewTaskService(repo, cache.NewRedisStore(rdb), time.Minute, service.WithProjectRepository(projects))
+		return repo, projects, rdb, svc
+	}
+
+	tasks := func() []*model.Task {
+		return []*model.Task{
+			{ID: 1, UserID: 7, Title: "a", Status: model.TaskStatusPending},
+			{ID: 2, UserID: 7, Title: "b", Status: model.TaskStatusPending},
+			{ID: 3, UserID: 8, Title: "not mine", Status: model.TaskStatusPending},
+		}
+	}
+
+	t.Run("atomic success invalidates once", func(t *testing.T) {
+		repo, projects, rdb, svc := setup(t)
+		projectID := uint(4)
+		_ = rdb.Set(ctx, cache.KeyTask(1), "{}", time.Minute).Err()
+		_ = rdb.Set(ctx, cache.KeyTask(2), "{}", time.Minute).Err()
+		repo.EXPECT().FindByIDs(ctx, []uint{1, 2, 2}).Return(tasks(), nil)
+		repo.EXPECT().Transaction(ctx, gomock.Any()).DoAndReturn(runInTx(repo))
+		repo.EXPECT().UpdateTask(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, task *model.Task) error {
+			assert.NotNil(t, task.CompletedAt)
+			return nil
+		})
+		projects.EXPECT().FindByID(ctx, uint(4)).Return(&model.Project{ID: 4, UserID: 7}, nil)
+		repo.EXPECT().UpdateTask(ctx, gomock.Any()).Return(nil)
+		repo.EXPECT().AddTag(ctx, gomock.Any(), "urgent").Return(nil)
+
+		results, err := svc.BulkApply(ctx, 7, service.BulkAtomic, []service.BulkOperation{
+			{Op: service.BulkOpSetStatus, TaskID: 1, Status: model.TaskStatusDone},
+			{Op: service.BulkOpMoveProject, TaskID: 2, ProjectID: &projectID},
+			{Op: service.BulkOpAddTag, TaskID: 2, Tag: " urgent "},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		for _, r := range results {
+			assert.Equal(t, service.BulkResultOK, r.Status)
+		}
+		gen, _ := rdb.Get(ctx, cache.KeyUserTasksGen(7)).Int64()
+		assert.Equal(t, int64(1), gen)
+		assert.Equal(t, int64(0), rdb.Exists(ctx, cache.KeyTask(1), cache.KeyTask(2)).Val())
+	})
+
+	t.Run("atomic failure rolls back", func(t *testing.T) {
+		repo, _, rdb, svc := setup(t)
+		repo.EXPECT().FindByIDs(ctx, []uint{1, 3}).Return(tasks(), nil)
+		repo.EXPECT().Transaction(ctx, gomock.Any()).DoAndReturn(runInTx(repo))
+		repo.EXPECT().DeleteTask(ctx, uint(1)).Return(nil)
+
+		results, err := svc.BulkApply(ctx, 7, service.BulkAtomic, []service.BulkOperation{
+			{Op: service.BulkOpDelete, TaskID: 1},
+			{Op: service.BulkOpDelete, TaskID: 3},
+		})
+		assert.ErrorIs(t, err, service.ErrBulkAborted)
+		require.Len(t, results, 2)
+		assert.Equal(t, service.BulkResultRolledBack, results[0].Status)
+		assert.Equal(t, service.BulkResultFailed, results[1].Status)
+		assert.ErrorIs(t, results[1].Err, service.ErrPermissionDenied)
+		assert.Equal(t, int64(0), rdb.Exists(ctx, cache.KeyUserTasksGen(7)).Val())
+	})
+
+	t.Run("best effort uses savepoints and skips failures", func(t *testing.T) {
+		repo, _, _, svc := setup(t)
+		repo.EXPECT().FindByIDs(ctx, []uint{1, 2, 9, 1}).Return(tasks(), nil)
+		repo.EXPECT().Transaction(ctx, gomock.Any()).DoAndReturn(runInTx(repo)).Times(4) // 外層交易 + 三個通過檢查的項目各一個 savepoint
+		repo.EXPECT().UpdateTask(ctx, gomock.Any()).Return(errors.New("db error"))
+		repo.EXPECT().DeleteTask(ctx, uint(2)).Return(nil)
+
+		results, err := svc.BulkApply(ctx, 7, service.BulkBestEffort, []service.BulkOperation{
+			{Op: service.BulkOpSetStatus, TaskID: 1, Status: model.TaskStatusDone},
+			{Op: service.BulkOpDelete, TaskID: 2},
+			{Op: service.BulkOpDelete, TaskID: 9},
+			{Op: service.BulkOpSetStatus, TaskID: 1, Status: "archived"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, service.BulkResultFailed, results[0].Status)
+		assert.Equal(t, service.BulkResultOK, results[1].Status)
+		assert.ErrorIs(t, results[2].Err, service.ErrTaskNotFound)
+		assert.ErrorIs(t, results[3].Err, service.ErrInvalidStatus)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, _, _, svc := setup(t)
+		_, err := svc.BulkApply(ctx, 7, "sometimes", []service.BulkOperation{{Op: service.BulkOpDelete, TaskID: 1}})
+		assert.ErrorIs(t, err, service.ErrInvalidBulk)
+		_, err = svc.BulkApply(ctx, 7, service.BulkAtomic, nil)
+		assert.ErrorIs(t, err, service.ErrInvalidBulk)
+	})
+}
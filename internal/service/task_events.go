@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+// 任務異動事件類型；webhook 以這些名稱訂閱。
+const (
+	EventTaskCreated   = "task.created"
+	EventTaskUpdated   = "task.updated"
+	EventTaskCompleted = "task.completed"
+	EventTaskDeleted   = "task.deleted"
+)
+
+// TaskEvents 是可訂閱的事件類型。
+var TaskEvents = []string{EventTaskCreated, EventTaskUpdated, EventTaskCompleted, EventTaskDeleted}
+
+// TaskEventPublisher 接收任務異動事件；實作不可在呼叫端等待外部系統（例如直接送出 HTTP）。
+type TaskEventPublisher interface {
+	PublishTaskEvent(ctx context.Context, event string, task *model.Task)
+}
+
+// WithEventPublisher 讓任務寫入成功後發出異動事件；可重複指定多個接收者。
+func WithEventPublisher(p TaskEventPublisher) TaskServiceOption {
+	return func(s *taskService) { s.events = append(s.events, p) }
+}
+
+func (s *taskService) publish(ctx context.Context, event string, task *model.Task) {
+	for _, p := range s.events {
+		p.PublishTaskEvent(ctx, event, task)
+	}
+}
+
+// publishUpdate 發出 task.updated；任務從未完成變成完成時另外發出 task.completed。
+func (s *taskService) publishUpdate(ctx context.Context, wasCompleted bool, task *model.Task) {
+	s.publish(ctx, EventTaskUpdated, task)
+	if !wasCompleted && task.CompletedAt != nil {
+		s.publish(ctx, EventTaskCompleted, task)
+	}
+}
@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
+)
+
+func TestTaskService_PublishesEvents(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockTaskRepository(ctrl)
+	events := mock_service.NewMockWebhookService(ctrl)
+	svc := service.NewTaskService(repo, nil, time.Minute, service.WithEventPublisher(events))
+
+	t.Run("create", func(t *testing.T) {
+		task := &model.Task{UserID: 7, Title: "a"}
+		repo.EXPECT().CreateTask(ctx, task).Return(nil)
+		events.EXPECT().PublishTaskEvent(ctx, service.EventTaskCreated, task)
+
+		require.NoError(t, svc.CreateTask(ctx, task))
+	})
+
+	t.Run("completing an update also publishes task.completed", func(t *testing.T) {
+		task := &model.Task{ID: 1, UserID: 7, Title: "a", Status: model.TaskStatusDone}
+		repo.EXPECT().FindByID(ctx, uint(1)).Return(&model.Task{ID: 1, UserID: 7, Title: "a", Status: model.TaskStatusPending}, nil)
+		repo.EXPECT().UpdateTask(ctx, task).Return(nil)
+		gomock.InOrder(
+			events.EXPECT().PublishTaskEvent(ctx, service.EventTaskUpdated, task),
+			events.EXPECT().PublishTaskEvent(ctx, service.EventTaskCompleted, task),
+		)
+
+		require.NoError(t, svc.UpdateTask(ctx, task))
+	})
+
+	t.Run("plain update", func(t *testing.T) {
+		task := &model.Task{ID: 1, UserID: 7, Title: "renamed", Status: model.TaskStatusPending}
+		repo.EXPECT().FindByID(ctx, uint(1)).Return(&model.Task{ID: 1, UserID: 7, Title: "a", Status: model.TaskStatusPending}, nil)
+		repo.EXPECT().UpdateTask(ctx, task).Return(nil)
+		events.EXPECT().PublishTaskEvent(ctx, service.EventTaskUpdated, task)
+
+		require.NoError(t, svc.UpdateTask(ctx, task))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		existing := &model.Task{ID: 1, UserID: 7, Title: "a"}
+		repo.EXPECT().FindByID(ctx, uint(1)).Return(existing, nil)
+		repo.EXPECT().DeleteTask(ctx, uint(1)).Return(nil)
+		events.EXPECT().PublishTaskEvent(ctx, service.EventTaskDeleted, existing)
+
+		require.NoError(t, svc.DeleteTask(ctx, 7, 1))
+	})
+
+	t.Run("failed write publishes nothing", func(t *testing.T) {
+		task := &model.Task{UserID: 7, Title: "a"}
+		repo.EXPECT().CreateTask(ctx, task).Return(errors.New("db down"))
+
+		require.Error(t, svc.CreateTask(ctx, task))
+	})
+}
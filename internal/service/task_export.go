@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+)
+
+const exportBatchSize = 500
+
+// ExportTasks 逐筆把使用者的任務交給 fn（依 id 順序），資料分批讀取，不經過快取。
+func (s *taskService) ExportTasks(ctx context.Context, userID uint, fn func(task *model.Task) error) error {
+	return s.repo.StreamByUserID(ctx, userID, exportBatchSize, func(batch []*model.Task) error {
+		for _, t := range batch {
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/SoliMark/gotasker-pro/internal/importer"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+// ErrImportInvalid 表示至少有一列未通過檢查，整批都不會寫入。
+var ErrImportInvalid = errors.New("import contains invalid rows")
+
+const (
+	importBatchSize = 500
+	// maxReportedRowErrors 限制回報的逐列錯誤數，避免整份壞檔產生過大的回應。
+	maxReportedRowErrors = 1000
+)
+
+// ImportOptions 控制匯入行為；Progress 在每批寫入後以已寫入筆數呼叫。
+type ImportOptions struct {
+	DryRun   bool
+	Progress func(done int)
+}
+
+type RowError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportReport 是一次匯入（或試跑）的結果；Valid 為通過檢查的列數，Imported 為實際寫入的筆數。
+type ImportReport struct {
+	Total    int        `json:"total"`
+	Valid    int        `json:"valid"`
+	Imported int        `json:"imported"`
+	Errors   []RowError `json:"errors"`
+}
+
+// ImportTasks 檢查每一列並在全部通過時於同一個交易中分批寫入；
+// 任一列錯誤時不寫入任何資料並回傳 ErrImportInvalid 與逐列錯誤。DryRun 只做檢查。
+func (s *taskService) ImportTasks(ctx context.Context, userID uint, rows []importer.Row, opts ImportOptions) (*ImportReport, error) {
+	wf, err := loadWorkflow(ctx, s.workflows, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{Total: len(rows), Errors: []RowError{}}
+	tasks := make([]*model.Task, 0, len(rows))
+	now := time.Now()
+	for _, row := range rows {
+		task := row.Task
+		if err := checkImportRow(wf, &task, row, now); err != nil {
+			if len(report.Errors) < maxReportedRowErrors {
+				report.Errors = append(report.Errors, RowError{Line: row.Line, Error: err.Error()})
+			}
+			continue
+		}
+		report.Valid++
+		tasks = append(tasks, &task)
+	}
+	if report.Valid < report.Total {
+		if opts.DryRun {
+			return report, nil
+		}
+		return report, ErrImportInvalid
+	}
+	if opts.DryRun || len(tasks) == 0 {
+		return report, nil
+	}
+
+	err = s.repo.Transaction(ctx, func(tx repository.TaskRepository) error {
+		return tx.CreateTasks(ctx, userID, tasks, importBatchSize, opts.Progress)
+	})
+	if err != nil {
+		return report, err
+	}
+	report.Imported = len(tasks)
+	s.rebalanceIfLong(ctx, userID, tasks[len(tasks)-1].Position)
+
+	ids := make([]uint, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	s.invalidateUserTasks(ctx, userID, ids...)
+	for _, id := range ids {
+		s.reindex(ctx, id)
+	}
+	return report, nil
+}
+
+// checkImportRow 套用與 CreateTask 相同的規則；沒有狀態但標記完成的列對應到流程中的第一個終止狀態。
+func checkImportRow(wf *model.Workflow, task *model.Task, row importer.Row, now time.Time) error {
+	if row.Err != nil {
+		return row.Err
+	}
+	task.Title = strings.TrimSpace(task.Title)
+	if task.Title == "" {
+		return ErrTitleRequired
+	}
+	if task.Priority < 0 || task.Priority > model.MaxTaskPriority {
+		return ErrInvalidPriority
+	}
+
+	if task.Status == "" {
+		task.Status = wf.InitialStatus
+		if row.Completed {
+			task.Status = ""
+			for _, st := range wf.States {
+				if st.Completes {
+					task.Status = st.Name
+					break
+				}
+			}
+			if task.Status == "" {
+				return errors.New("workflow has no completing status for completed task")
+			}
+		}
+	}
+	st := wf.State(task.Status)
+	if st == nil {
+		return ErrInvalidStatus
+	}
+	if st.Completes {
+		task.CompletedAt = &now
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/golang/mock/gomock"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/cache"
+	"github.com/SoliMark/gotasker-pro/internal/importer"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func TestTaskService_ImportTasks(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*mock_repository.MockTaskRepository, *redis.Client, service.TaskService) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+		mr, err := miniredis.Run()
+		require.NoError(t, err)
+		t.Cleanup(mr.Close)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+		repo := mock_repository.NewMockTaskRepository(ctrl)
+		return repo, rdb, service.NewTaskService(repo, cache.NewRedisStore(rdb), time.Minute)
+	}
+
+	rows := func() []importer.Row {
+		return []importer.Row{
+			{Line: 2, Task: model.Task{Title: " a "}},
+			{Line: 3, Task: model.Task{Title: "b", Priority: 2}, Completed: true},
+			{Line: 4, Task: model.Task{Title: "c", Status: model.TaskStatusDone}},
+		}
+	}
+
+	t.Run("commits in a transaction", func(t *testing.T) {
+		repo, rdb, svc := setup(t)
+		repo.EXPECT().Transaction(ctx, gomock.Any()).DoAndReturn(runInTx(repo))
+		repo.EXPECT().CreateTasks(ctx, uint(7), gomock.Any(), 500, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, tasks []*model.Task, _ int, progress func(int)) error {
+				require.Len(t, tasks, 3)
+				assert.Equal(t, "a", tasks[0].Title)
+				assert.Equal(t, model.TaskStatusPending, tasks[0].Status)
+				assert.Nil(t, tasks[0].CompletedAt)
+				assert.Equal(t, model.TaskStatusDone, tasks[1].Status)
+				assert.NotNil(t, tasks[1].CompletedAt)
+				assert.NotNil(t, tasks[2].CompletedAt)
+				progress(3)
+				return nil
+			})
+
+		var done int
+		report, err := svc.ImportTasks(ctx, 7, rows(), service.ImportOptions{Progress: func(n int) { done = n }})
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Imported)
+		assert.Equal(t, 3, done)
+		assert.Empty(t, report.Errors)
+
+		gen, err := rdb.Get(ctx, cache.KeyUserTasksGen(7)).Int()
+		require.NoError(t, err)
+		assert.Equal(t, 1, gen)
+	})
+
+	t.Run("dry run reports every invalid row", func(t *testing.T) {
+		_, _, svc := setup(t)
+		in := append(rows(),
+			importer.Row{Line: 5, Task: model.Task{Title: ""}},
+			importer.Row{Line: 6, Task: model.Task{Title: "x", Priority: 10}},
+			importer.Row{Line: 7, Task: model.Task{Title: "y", Status: "archived"}},
+			importer.Row{Line: 8, Err: assert.AnError},
+		)
+
+		report, err := svc.ImportTasks(ctx, 7, in, service.ImportOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, 7, report.Total)
+		assert.Equal(t, 3, report.Valid)
+		assert.Equal(t, 0, report.Imported)
+		assert.Equal(t, []service.RowError{
+			{Line: 5, Error: "title is required"},
+			{Line: 6, Error: service.ErrInvalidPriority.Error()},
+			{Line: 7, Error: service.ErrInvalidStatus.Error()},
+			{Line: 8, Error: assert.AnError.Error()},
+		}, report.Errors)
+	})
+
+	t.Run("invalid rows abort the import", func(t *testing.T) {
+		_, _, svc := setup(t)
+		in := append(rows(), importer.Row{Line: 5})
+
+		report, err := svc.ImportTasks(ctx, 7, in, service.ImportOptions{})
+		assert.ErrorIs(t, err, service.ErrImportInvalid)
+		assert.Len(t, report.Errors, 1)
+		assert.Equal(t, 0, report.Imported)
+	})
+}
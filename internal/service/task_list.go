@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SoliMark/gotasker-pro/internal/cache"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+var (
+	ErrInvalidSort   = errors.New("invalid sort")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+	DefaultTaskSort  = "-created_at"
+	maxSortFields    = 4
+)
+
+// TaskFilter 是列表的篩選條件，讓 handler 不需直接依賴 repository。
+type TaskFilter = repository.TaskFilter
+
+// TaskPageRequest 是分頁列表的查詢條件。
+// Sort 以逗號分隔欄位，前綴 "-" 表示遞減，例如 "status,-updated_at"；Cursor 為上一頁回傳的 NextCursor。
+type TaskPageRequest struct {
+	Filter TaskFilter
+	Sort   string
+	Limit  int
+	Cursor string
+}
+
+type TaskPage struct {
+	Tasks      []*model.Task `json:"tasks"`
+	NextCursor string        `json:"next_cursor"`
+	Total      int64         `json:"-"`
+}
+
+// pageCursor 是分頁游標的內容，編碼後對 client 不透明。
+// Query 為正規化查詢的雜湊，避免游標被拿到不同的篩選或排序條件下使用。
+type pageCursor struct {
+	Query  string   `json:"q"`
+	Values []string `json:"v"`
+}
+
+// ParseTaskSort 解析排序字串並檢查 allowlist；未含 id 時自動補上 id 作為 tie-breaker。
+func ParseTaskSort(s string) ([]repository.SortField, error) {
+	if strings.TrimSpace(s) == "" {
+		s = DefaultTaskSort
+	}
+
+	parts := strings.Split(s, ",")
+	if len(parts) > maxSortFields {
+		return nil, ErrInvalidSort
+	}
+
+	fields := make([]repository.SortField, 0, len(parts)+1)
+	seen := make(map[string]bool, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		f := repository.SortField{Column: strings.TrimPrefix(p, "-"), Desc: strings.HasPrefix(p, "-")}
+		if !repository.IsTaskSortColumn(f.Column) || seen[f.Column] {
+			return nil, ErrInvalidSort
+		}
+		seen[f.Column] = true
+		fields = append(fields, f)
+	}
+	if !seen["id"] {
+		fields = append(fields, repository.SortField{Column: "id", Desc: fields[len(fields)-1].Desc})
+	}
+	return fields, nil
+}
+
+func formatTaskSort(fields []repository.SortField) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = f.Column
+		if f.Desc {
+			parts[i] = "-" + f.Column
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// normalizeFilter 讓語意相同的篩選條件得到相同的表示（狀態去重排序、時間轉 UTC）。
+func normalizeFilter(f repository.TaskFilter) repository.TaskFilter {
+	if len(f.Statuses) > 0 {
+		set := make(map[string]bool, len(f.Statuses))
+		statuses := make([]string, 0, len(f.Statuses))
+		for _, st := range f.Statuses {
+			st = strings.TrimSpace(st)
+			if st != "" && !set[st] {
+				set[st] = true
+				statuses = append(statuses, st)
+			}
+		}
+		sort.Strings(statuses)
+		f.Statuses = statuses
+	}
+	for _, tp := range []**time.Time{&f.CreatedAfter, &f.CreatedBefore, &f.UpdatedAfter, &f.UpdatedBefore} {
+		if *tp != nil {
+			t := (*tp).UTC()
+			*tp = &t
+		}
+	}
+	return f
+}
+
+func queryFingerprint(filter repository.TaskFilter, sortFields []repository.SortField) string {
+	b, _ := json.Marshal(struct {
+		F repository.TaskFilter
+		S string
+	}{filter, formatTaskSort(sortFields)})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8])
+}
+
+// ListTaskPage 以 keyset 分頁列出任務。
+// 只有未篩選的檢視會被快取（每種排序各自一組 key），避免任意篩選組合讓快取 key 無限增長；
+// 寫入時遞增世代號即可讓所有頁面一起失效。
+func (s *taskService) ListTaskPage(ctx context.Context, userID uint, req TaskPageRequest) (*TaskPage, error) {
+	sortFields, err := ParseTaskSort(req.Sort)
+	if err != nil {
+		return nil, err
+	}
+	if req.Limit <= 0 {
+		req.Limit = DefaultPageLimit
+	}
+	if req.Limit > MaxPageLimit {
+		req.Limit = MaxPageLimit
+	}
+	filter := normalizeFilter(req.Filter)
+	fingerprint := queryFingerprint(filter, sortFields)
+
+	q := repository.TaskPageQuery{Filter: filter, Sort: sortFields, Limit: req.Limit + 1}
+	if req.Cursor != "" {
+		var cur pageCursor
+		if err := s.cursors.Decode(req.Cursor, &cur); err != nil ||
+			cur.Query != fingerprint || len(cur.Values) != len(sortFields) {
+			return nil, ErrInvalidCursor
+		}
+		q.After = cur.Values
+	}
+
+	if s.store == nil || !filter.IsZero() {
+		return s.loadTaskPage(ctx, userID, q, fingerprint, true)
+	}
+
+	gen, _ := cache.GetInt64(ctx, s.store, cache.KeyUserTasksGen(userID))
+	key := cache.KeyUserTasksPage(userID, gen, formatTaskSort(sortFields)+"|"+strconv.Itoa(req.Limit)+"|"+req.Cursor)
+
+	v, err, _ := s.sfGroup.Do(key, func() (interface{}, error) {
+		if page, ok := s.cachedPage(ctx, key); ok {
+			return page, nil
+		}
+		page, err := s.loadTaskPage(ctx, userID, q, fingerprint, false)
+		if err != nil {
+			return nil, err
+		}
+		if data, e := json.Marshal(page); e == nil {
+			_ = s.store.Set(ctx, key, data, s.ttl)
+		}
+		return page, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.countTasks(ctx, userID, gen)
+	if err != nil {
+		return nil, err
+	}
+	page := *v.(*TaskPage)
+	page.Total = total
+	return &page, nil
+}
+
+// loadTaskPage 多查一筆來判斷是否還有下一頁；withTotal 時一併查出符合條件的總數。
+func (s *taskService) loadTaskPage(ctx context.Context, userID uint, q repository.TaskPageQuery, fingerprint string, withTotal bool) (*TaskPage, error) {
+	limit := q.Limit - 1
+	tasks, err := s.repo.ListPage(ctx, userID, q)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &TaskPage{Tasks: tasks}
+	if len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+		cur := pageCursor{Query: fingerprint, Values: repository.SortValues(page.Tasks[limit-1], q.Sort)}
+		if page.NextCursor, err = s.cursors.Encode(cur); err != nil {
+			return nil, err
+		}
+	}
+
+	if withTotal {
+		if page.Total, err = s.repo.CountByUserID(ctx, userID, q.Filter); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+func (s *taskService) cachedPage(ctx context.Context, key string) (*TaskPage, bool) {
+	b, err := s.store.Get(ctx, key)
+	if err != nil || len(b) == 0 {
+		return nil, false
+	}
+	var page TaskPage
+	if json.Unmarshal(b, &page) != nil {
+		return nil, false
+	}
+	return &page, true
+}
+
+func (s *taskService) countTasks(ctx context.Context, userID uint, gen int64) (int64, error) {
+	key := cache.KeyUserTasksCount(userID, gen)
+	if n, err := cache.GetInt64(ctx, s.store, key); err == nil {
+		return n, nil
+	}
+	n, err := s.repo.CountByUserID(ctx, userID, repository.TaskFilter{})
+	if err != nil {
+		return 0, err
+	}
+	_ = cache.SetInt64(ctx, s.store, key, n, s.ttl)
+	return n, nil
+}
+
+// ListTaskTags 一次查出多個任務的標籤；不屬於 userID 的任務沒有標籤。
+func (s *taskService) ListTaskTags(ctx context.Context, userID uint, taskIDs []uint) (map[uint][]*model.Tag, error) {
+	return s.repo.ListTagsByTaskIDs(ctx, userID, taskIDs)
+}
+
+// ListSubtasks 一次查出多個任務的子任務，依父任務 id 分組。
+func (s *taskService) ListSubtasks(ctx context.Context, userID uint, parentIDs []uint) (map[uint][]*model.Task, error) {
+	tasks, err := s.repo.ListByParentIDs(ctx, userID, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[uint][]*model.Task, len(parentIDs))
+	for _, t := range tasks {
+		res[*t.ParentID] = append(res[*t.ParentID], t)
+	}
+	return res, nil
+}
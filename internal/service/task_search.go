@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+var ErrEmptyQuery = errors.New("search query is required")
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// SearchTasks 以全文檢索搜尋使用者的任務（標題、內容與留言），依相關度排序。
+// 未設定 SearchIndex 時回傳空結果。
+func (s *taskService) SearchTasks(ctx context.Context, userID uint, query string, limit int) ([]repository.SearchHit, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptyQuery
+	}
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+	if s.search == nil {
+		return []repository.SearchHit{}, nil
+	}
+	return s.search.Search(ctx, userID, query, limit)
+}
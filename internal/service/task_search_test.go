@@ -0,0 +1,82 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func TestTaskService_SearchTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockIndex := mock_repository.NewMockSearchIndex(ctrl)
+	svc := service.NewTaskService(mockRepo, nil, 60*time.Second, service.WithSearchIndex(mockIndex))
+	ctx := context.Background()
+
+	t.Run("empty query", func(t *testing.T) {
+		_, err := svc.SearchTasks(ctx, 1, "   ", 0)
+		assert.ErrorIs(t, err, service.ErrEmptyQuery)
+	})
+
+	t.Run("default and max limit", func(t *testing.T) {
+		hits := []repository.SearchHit{{Task: &model.Task{ID: 3}, Rank: 1, Snippet: "<mark>a</mark>"}}
+		mockIndex.EXPECT().Search(ctx, uint(1), "report", service.DefaultSearchLimit).Return(hits, nil)
+		got, err := svc.SearchTasks(ctx, 1, " report ", 0)
+		require.NoError(t, err)
+		assert.Equal(t, hits, got)
+
+		mockIndex.EXPECT().Search(ctx, uint(1), "report", service.MaxSearchLimit).Return(nil, nil)
+		_, err = svc.SearchTasks(ctx, 1, "report", 1000)
+		require.NoError(t, err)
+	})
+}
+
+func TestTaskService_SearchIndexSync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockIndex := mock_repository.NewMockSearchIndex(ctrl)
+	svc := service.NewTaskService(mockRepo, nil, 60*time.Second, service.WithSearchIndex(mockIndex))
+	ctx := context.Background()
+
+	t.Run("create indexes task", func(t *testing.T) {
+		mockRepo.EXPECT().CreateTask(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, task *model.Task) error {
+			task.ID = 10
+			return nil
+		})
+		mockIndex.EXPECT().Index(ctx, uint(10)).Return(nil)
+		require.NoError(t, svc.CreateTask(ctx, &model.Task{UserID: 1, Title: "t"}))
+	})
+
+	t.Run("update reindexes; index failure does not fail the write", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(ctx, uint(10)).Return(&model.Task{ID: 10, UserID: 1, Status: model.TaskStatusPending}, nil)
+		mockRepo.EXPECT().UpdateTask(ctx, gomock.Any()).Return(nil)
+		mockIndex.EXPECT().Index(ctx, uint(10)).Return(errors.New("index down"))
+		require.NoError(t, svc.UpdateTask(ctx, &model.Task{ID: 10, UserID: 1, Title: "new", Status: model.TaskStatusPending}))
+	})
+
+	t.Run("delete removes task", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(ctx, uint(10)).Return(&model.Task{ID: 10, UserID: 1}, nil)
+		mockRepo.EXPECT().DeleteTask(ctx, uint(10)).Return(nil)
+		mockIndex.EXPECT().Remove(ctx, uint(10)).Return(nil)
+		require.NoError(t, svc.DeleteTask(ctx, 1, 10))
+	})
+
+	t.Run("failed write does not touch index", func(t *testing.T) {
+		mockRepo.EXPECT().CreateTask(ctx, gomock.Any()).Return(errors.New("db error"))
+		assert.Error(t, svc.CreateTask(ctx, &model.Task{UserID: 1, Title: "t"}))
+	})
+}
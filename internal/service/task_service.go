@@ -2,75 +2,303 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"log"
 	"strings"
 	"time"
 
-	redis "github.com/redis/go-redis/v9"
 	"golang.org/x/sync/singleflight"
 
 	"github.com/SoliMark/gotasker-pro/internal/cache"
+	"github.com/SoliMark/gotasker-pro/internal/importer"
 	"github.com/SoliMark/gotasker-pro/internal/model"
 	"github.com/SoliMark/gotasker-pro/internal/repository"
+	"github.com/SoliMark/gotasker-pro/internal/util"
 )
 
 var (
-	ErrTaskNotFound     = errors.New("task not found")
-	ErrPermissionDenied = errors.New("permission denied")
+	ErrTaskNotFound      = errors.New("task not found")
+	ErrPermissionDenied  = errors.New("permission denied")
+	ErrInvalidStatus     = errors.New("invalid status")
+	ErrInvalidTransition = errors.New("status transition not allowed")
+	ErrInvalidMove       = errors.New("exactly one of before or after is required")
+	ErrInvalidPriority   = errors.New("priority must be between 0 and 9")
+	ErrInvalidParent     = errors.New("parent must be an existing top-level task of the same user")
+	ErrTitleRequired     = errors.New("title is required")
 )
 
+// maxPositionLength 超過此長度的 rank 會觸發整份清單重新平衡。
+const maxPositionLength = 32
+
+// MoveOptions 指定任務要移到哪個任務之前（Before）或之後（After），兩者擇一。
+type MoveOptions struct {
+	Before uint
+	After  uint
+}
+
 type TaskService interface {
 	CreateTask(ctx context.Context, task *model.Task) error
 	GetTask(ctx context.Context, id uint) (*model.Task, error)
 	ListTasks(ctx context.Context, userID uint) ([]*model.Task, error)
+	MoveTask(ctx context.Context, userID, taskID uint, opts MoveOptions) (*model.Task, error)
+	ListTaskPage(ctx context.Context, userID uint, req TaskPageRequest) (*TaskPage, error)
+	ListTaskTags(ctx context.Context, userID uint, taskIDs []uint) (map[uint][]*model.Tag, error)
+	ListSubtasks(ctx context.Context, userID uint, parentIDs []uint) (map[uint][]*model.Task, error)
+	SearchTasks(ctx context.Context, userID uint, query string, limit int) ([]repository.SearchHit, error)
+	BulkApply(ctx context.Context, userID uint, mode BulkMode, ops []BulkOperation) ([]BulkResult, error)
+	ExportTasks(ctx context.Context, userID uint, fn func(task *model.Task) error) error
+	ImportTasks(ctx context.Context, userID uint, rows []importer.Row, opts ImportOptions) (*ImportReport, error)
+	SyncChanges(ctx context.Context, userID uint, token string, limit int) (*SyncPage, error)
+	ApplySync(ctx context.Context, userID uint, mutations []SyncMutation) ([]SyncResult, error)
 	UpdateTask(ctx context.Context, task *model.Task) error
 	DeleteTask(ctx context.Context, userID, taskID uint) error
 }
 
 type taskService struct {
-	repo    repository.TaskRepository
-	rdb     *redis.Client
-	ttl     time.Duration
-	sfGroup singleflight.Group
+	repo      repository.TaskRepository
+	workflows repository.WorkflowRepository
+	cursors   *util.CursorCodec
+	search    repository.SearchIndex
+	projects  repository.ProjectRepository
+	events    []TaskEventPublisher
+	store     cache.Store
+	ttl       time.Duration
+	sfGroup   singleflight.Group
+}
+
+// TaskServiceOption 設定 taskService 的選用相依。
+type TaskServiceOption func(*taskService)
+
+// WithWorkflowRepository 讓狀態轉換依照使用者自訂的流程檢查；未設定時使用預設流程。
+func WithWorkflowRepository(r repository.WorkflowRepository) TaskServiceOption {
+	return func(s *taskService) { s.workflows = r }
+}
+
+// WithCursorCodec 設定分頁游標的簽章；未設定時使用程序內隨機金鑰（重啟後舊游標失效）。
+func WithCursorCodec(c *util.CursorCodec) TaskServiceOption {
+	return func(s *taskService) { s.cursors = c }
+}
+
+// WithSearchIndex 讓任務的新增、修改、刪除同步更新全文檢索索引。
+func WithSearchIndex(idx repository.SearchIndex) TaskServiceOption {
+	return func(s *taskService) { s.search = idx }
+}
+
+// WithProjectRepository 用於檢查任務要移入的專案是否屬於同一使用者。
+func WithProjectRepository(r repository.ProjectRepository) TaskServiceOption {
+	return func(s *taskService) { s.projects = r }
 }
 
-func NewTaskService(repo repository.TaskRepository, rdb *redis.Client, ttl time.Duration) TaskService {
-	return &taskService{
+// NewTaskService 建立任務服務；store 為 nil 時不快取任務清單與分頁。
+func NewTaskService(repo repository.TaskRepository, store cache.Store, ttl time.Duration, opts ...TaskServiceOption) TaskService {
+	s := &taskService{
 		repo:    repo,
-		rdb:     rdb,
+		store:   store,
 		ttl:     ttl,
 		sfGroup: singleflight.Group{},
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.cursors == nil {
+		s.cursors = util.NewCursorCodec(randomSecret())
+	}
+	return s
+}
+
+func randomSecret() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
 }
 
 func (s *taskService) CreateTask(ctx context.Context, task *model.Task) error {
-	if task.Title == "" {
-		return errors.New("title is required")
+	if strings.TrimSpace(task.Title) == "" {
+		return ErrTitleRequired
+	}
+	if task.Priority < 0 || task.Priority > model.MaxTaskPriority {
+		return ErrInvalidPriority
+	}
+	if err := s.checkParent(ctx, task); err != nil {
+		return err
+	}
+
+	wf, err := loadWorkflow(ctx, s.workflows, task.UserID)
+	if err != nil {
+		return err
+	}
+	if task.Status == "" {
+		task.Status = wf.InitialStatus
+	}
+	st := wf.State(task.Status)
+	if st == nil {
+		return ErrInvalidStatus
+	}
+	if st.Completes {
+		now := time.Now()
+		task.CompletedAt = &now
 	}
 
-	err := s.repo.CreateTask(ctx, task)
-	if err == nil && s.rdb != nil {
+	err = s.repo.CreateTask(ctx, task)
+	if err == nil {
+		if s.rebalanceIfLong(ctx, task.UserID, task.Position) {
+			if fresh, e := s.repo.FindByID(ctx, task.ID); e == nil && fresh != nil {
+				task.Position, task.ChangeSeq = fresh.Position, fresh.ChangeSeq
+			}
+		}
 		// Invalidate user's task cache after successful creation
-		key := cache.KeyUserTasks(task.UserID)
-		_ = s.rdb.Del(ctx, key).Err()
+		s.invalidateUserTasks(ctx, task.UserID, task.ID)
+		s.reindex(ctx, task.ID)
+		s.publish(ctx, EventTaskCreated, task)
 	}
 	return err
 }
 
+// checkParent 確認子任務的父任務存在、屬於同一個使用者，且本身不是子任務（只有一層）。
+func (s *taskService) checkParent(ctx context.Context, task *model.Task) error {
+	if task.ParentID == nil {
+		return nil
+	}
+	parent, err := s.repo.FindByID(ctx, *task.ParentID)
+	if err != nil {
+		return err
+	}
+	if parent == nil || parent.UserID != task.UserID || parent.ParentID != nil {
+		return ErrInvalidParent
+	}
+	return nil
+}
+
+// notFoundTTL 是「任務不存在」的快取時間，擋下對不存在 id 的重複查詢，又不會讓新建的任務長時間看不到。
+const notFoundTTL = 5 * time.Second
+
+// GetTask 以 read-through 方式讀取單筆任務；不存在時回傳 nil, nil。
+// 回傳的是副本，singleflight 合併的呼叫者修改任務時不會互相影響。
 func (s *taskService) GetTask(ctx context.Context, id uint) (*model.Task, error) {
-	return s.repo.FindByID(ctx, id)
+	if s.store == nil {
+		return s.repo.FindByID(ctx, id)
+	}
+
+	key := cache.KeyTask(id)
+	if entry, ok := s.cachedTask(ctx, key); ok && s.taskEntryFresh(ctx, entry) {
+		return copyTask(entry.Task), nil
+	}
+
+	v, err, _ := s.sfGroup.Do(key, func() (interface{}, error) {
+		if entry, ok := s.cachedTask(ctx, key); ok && s.taskEntryFresh(ctx, entry) {
+			return entry.Task, nil
+		}
+		return s.fillTask(ctx, key, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyTask(v.(*model.Task)), nil
+}
+
+// taskCacheEntry 是單筆任務快取的內容；Gen 是讀取資料庫之前任務擁有者的世代號（KeyUserTasksGen）。
+// Task 為 nil 表示不存在。
+type taskCacheEntry struct {
+	Gen  int64       `json:"gen"`
+	Task *model.Task `json:"task"`
+}
+
+// taskOwnerTTL 是任務擁有者提示的快取時間；任務不會換擁有者，可以保留較久。
+const taskOwnerTTL = 24 * time.Hour
+
+// fillTask 讀取任務並寫入快取，每次只查詢一次資料庫。世代號必須在讀取資料庫之前取得：
+// 讀取期間若有寫入，世代號已遞增，寫入快取的舊內容不會再被讀到。
+// 擁有者未知時（第一次讀取）只記下擁有者，不快取任務，下一次讀取才會填入。
+func (s *taskService) fillTask(ctx context.Context, key string, id uint) (*model.Task, error) {
+	ownerKey := cache.KeyTaskOwner(id)
+	owner, ownerErr := cache.GetInt64(ctx, s.store, ownerKey)
+	var gen int64
+	genOK := false
+	if ownerErr == nil {
+		g, err := cache.GetInt64(ctx, s.store, cache.KeyUserTasksGen(uint(owner)))
+		if err == nil || errors.Is(err, cache.ErrMiss) {
+			gen, genOK = g, true
+		}
+	}
+
+	task, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		// 不存在時沒有擁有者可比對，只短暫快取；建立任務時會清掉這個 key
+		ttl := min(notFoundTTL, s.ttl)
+		if data, e := json.Marshal(taskCacheEntry{}); e == nil {
+			_ = s.store.Set(ctx, key, data, ttl)
+		}
+		return nil, nil
+	}
+
+	if ownerErr != nil || uint(owner) != task.UserID {
+		_ = cache.SetInt64(ctx, s.store, ownerKey, int64(task.UserID), taskOwnerTTL)
+		return task, nil
+	}
+	if !genOK {
+		return task, nil
+	}
+	if data, e := json.Marshal(taskCacheEntry{Gen: gen, Task: task}); e == nil {
+		_ = s.store.Set(ctx, key, data, s.ttl)
+	}
+	return task, nil
+}
+
+// taskEntryFresh 判斷快取的任務在填入之後是否沒有寫入過。
+func (s *taskService) taskEntryFresh(ctx context.Context, entry *taskCacheEntry) bool {
+	if entry.Task == nil {
+		return true
+	}
+	gen, err := cache.GetInt64(ctx, s.store, cache.KeyUserTasksGen(entry.Task.UserID))
+	if err != nil && !errors.Is(err, cache.ErrMiss) {
+		return false
+	}
+	return gen == entry.Gen
+}
+
+// cachedTask 讀取單筆任務的快取。
+func (s *taskService) cachedTask(ctx context.Context, key string) (*taskCacheEntry, bool) {
+	b, err := s.store.Get(ctx, key)
+	if err != nil || len(b) == 0 {
+		return nil, false
+	}
+	var entry taskCacheEntry
+	if json.Unmarshal(b, &entry) != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func copyTask(t *model.Task) *model.Task {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	c.Tags = append([]model.Tag(nil), t.Tags...)
+	return &c
 }
 
 func (s *taskService) ListTasks(ctx context.Context, userID uint) ([]*model.Task, error) {
-	// fallback when cache is not enabled
-	if s.rdb == nil {
+	return s.cachedList(ctx, cache.KeyUserTasks(userID), func() ([]*model.Task, error) {
 		return s.repo.ListByUserID(ctx, userID)
+	})
+}
+
+// cachedList 以 cache-aside 方式讀取任務清單，並以 singleflight 合併同時發生的 miss。
+func (s *taskService) cachedList(ctx context.Context, key string, load func() ([]*model.Task, error)) ([]*model.Task, error) {
+	// fallback when cache is not enabled
+	if s.store == nil {
+		return load()
 	}
-	key := cache.KeyUserTasks(userID)
 
 	// fast path: cache hit
-	if b, err := s.rdb.Get(ctx, key).Bytes(); err == nil && len(b) > 0 {
+	if b, err := s.store.Get(ctx, key); err == nil && len(b) > 0 {
 		var tasks []*model.Task
 		if json.Unmarshal(b, &tasks) == nil {
 			return tasks, nil
@@ -80,21 +308,20 @@ func (s *taskService) ListTasks(ctx context.Context, userID uint) ([]*model.Task
 	// collapse concurrent misses
 	v, err, _ := s.sfGroup.Do(key, func() (interface{}, error) {
 		// double-check after acquiring singleflight
-		if b, err := s.rdb.Get(ctx, key).Bytes(); err == nil && len(b) > 0 {
+		if b, err := s.store.Get(ctx, key); err == nil && len(b) > 0 {
 			var tasks []*model.Task
 			if json.Unmarshal(b, &tasks) == nil {
 				return tasks, nil
 			}
 		}
 		// load from DB
-		list, err := s.repo.ListByUserID(ctx, userID)
+		list, err := load()
 		if err != nil {
 			return nil, err
 		}
-		// set cache with TTL jitter (±10%)
+		// set cache (the store applies TTL jitter)
 		if data, e := json.Marshal(list); e == nil {
-			jitter := cache.Jitter{}
-			_ = s.rdb.Set(ctx, key, data, jitter.TTL(s.ttl, 0.1)).Err()
+			_ = s.store.Set(ctx, key, data, s.ttl)
 		}
 		return list, nil
 	})
@@ -104,21 +331,81 @@ func (s *taskService) ListTasks(ctx context.Context, userID uint) ([]*model.Task
 	return v.([]*model.Task), nil
 }
 
+// reindex 更新任務的全文檢索文件；索引失敗不影響已完成的寫入，只記錄錯誤。
+func (s *taskService) reindex(ctx context.Context, taskID uint) {
+	if s.search == nil {
+		return
+	}
+	if err := s.search.Index(ctx, taskID); err != nil {
+		log.Printf("search: index task %d: %v", taskID, err)
+	}
+}
+
+// invalidateUserTasks 清除使用者所有任務清單的快取，以及 taskIDs 的單筆快取。
+// 新建的任務也要傳入 id，清掉先前查詢該 id 時留下的「不存在」。
+func (s *taskService) invalidateUserTasks(ctx context.Context, userID uint, taskIDs ...uint) {
+	if s.store == nil {
+		return
+	}
+	keys := make([]string, 0, len(taskIDs)+1)
+	keys = append(keys, cache.KeyUserTasks(userID))
+	for _, id := range taskIDs {
+		keys = append(keys, cache.KeyTask(id))
+	}
+	_ = s.store.Del(ctx, keys...)
+	// 分頁快取以世代號區隔，遞增後舊世代的頁面不會再被讀到
+	_, _ = s.store.Incr(ctx, cache.KeyUserTasksGen(userID))
+}
+
 func (s *taskService) UpdateTask(ctx context.Context, task *model.Task) error {
+	return s.updateTask(ctx, task, nil)
+}
+
+// updateTask 在 baseSeq 不為 nil 時以條件式寫入，任務在 baseSeq 之後被改過則回傳 repository.ErrTaskChanged。
+func (s *taskService) updateTask(ctx context.Context, task *model.Task, baseSeq *int64) error {
 	if strings.TrimSpace(task.Title) == "" {
-		return errors.New("title is required")
+		return ErrTitleRequired
+	}
+	if task.Priority < 0 || task.Priority > model.MaxTaskPriority {
+		return ErrInvalidPriority
+	}
+
+	current, err := s.repo.FindByID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return ErrTaskNotFound
+	}
+	// task 可能是從快取讀出的舊副本：只採用可修改的欄位，其餘以資料庫目前的值為準
+	edited := *task
+	*task = *copyTask(current)
+	task.Title, task.Content, task.Status = edited.Title, edited.Content, edited.Status
+	task.Priority, task.DueAt = edited.Priority, edited.DueAt
+	if err := s.applyTransition(ctx, current, task); err != nil {
+		return err
 	}
 
-	err := s.repo.UpdateTask(ctx, task)
-	if err == nil && s.rdb != nil {
+	if baseSeq != nil {
+		err = s.repo.UpdateTaskIfUnchanged(ctx, task, *baseSeq)
+	} else {
+		err = s.repo.UpdateTask(ctx, task)
+	}
+	if err == nil {
 		// Invalidate user's task cache after successful update
-		key := cache.KeyUserTasks(task.UserID)
-		_ = s.rdb.Del(ctx, key).Err()
+		s.invalidateUserTasks(ctx, task.UserID, task.ID)
+		s.reindex(ctx, task.ID)
+		s.publishUpdate(ctx, current.CompletedAt != nil, task)
 	}
 	return err
 }
 
 func (s *taskService) DeleteTask(ctx context.Context, userID, taskID uint) error {
+	return s.deleteTask(ctx, userID, taskID, nil)
+}
+
+// deleteTask 的 baseSeq 與 updateTask 相同。
+func (s *taskService) deleteTask(ctx context.Context, userID, taskID uint, baseSeq *int64) error {
 	t, err := s.repo.FindByID(ctx, taskID)
 	if err != nil {
 		return err
@@ -130,11 +417,180 @@ func (s *taskService) DeleteTask(ctx context.Context, userID, taskID uint) error
 		return ErrPermissionDenied
 	}
 
-	err = s.repo.DeleteTask(ctx, taskID)
-	if err == nil && s.rdb != nil {
+	if baseSeq != nil {
+		err = s.repo.DeleteTaskIfUnchanged(ctx, taskID, *baseSeq)
+	} else {
+		err = s.repo.DeleteTask(ctx, taskID)
+	}
+	if err == nil {
 		// Invalidate user's task cache after successful deletion
-		key := cache.KeyUserTasks(userID)
-		_ = s.rdb.Del(ctx, key).Err()
+		s.invalidateUserTasks(ctx, userID, taskID)
+		if s.search != nil {
+			if e := s.search.Remove(ctx, taskID); e != nil {
+				log.Printf("search: remove task %d: %v", taskID, e)
+			}
+		}
+		s.publish(ctx, EventTaskDeleted, t)
 	}
 	return err
 }
+
+// applyTransition 檢查 current → task 的狀態轉換是否符合流程，並維護 CompletedAt。
+// 目前狀態不在流程內（例如流程被修改過）時，允許移到流程中的任一狀態。
+func (s *taskService) applyTransition(ctx context.Context, current, task *model.Task) error {
+	if current.Status == task.Status {
+		return nil
+	}
+
+	wf, err := loadWorkflow(ctx, s.workflows, task.UserID)
+	if err != nil {
+		return err
+	}
+	return transition(wf, current, task)
+}
+
+// transition 以指定流程檢查並套用 current → task 的狀態轉換。
+func transition(wf *model.Workflow, current, task *model.Task) error {
+	if current.Status == task.Status {
+		return nil
+	}
+	to := wf.State(task.Status)
+	if to == nil {
+		return ErrInvalidStatus
+	}
+	if wf.State(current.Status) != nil && !wf.CanTransition(current.Status, task.Status) {
+		return ErrInvalidTransition
+	}
+
+	if to.Completes {
+		now := time.Now()
+		task.CompletedAt = &now
+	} else {
+		task.CompletedAt = nil
+	}
+	return nil
+}
+
+// MoveTask 將任務移到指定任務的前面或後面，只更新被移動任務的 position。
+func (s *taskService) MoveTask(ctx context.Context, userID, taskID uint, opts MoveOptions) (*model.Task, error) {
+	anchorID := opts.Before
+	if (opts.Before == 0) == (opts.After == 0) {
+		return nil, ErrInvalidMove
+	}
+	if anchorID == 0 {
+		anchorID = opts.After
+	}
+	if anchorID == taskID {
+		return nil, ErrInvalidMove
+	}
+
+	task, err := s.ownedTask(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	anchor, err := s.ownedTask(ctx, userID, anchorID)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := s.positionNear(ctx, userID, task.ID, anchor, opts.Before != 0)
+	if errors.Is(err, util.ErrInvalidRankRange) {
+		// 舊資料沒有 position 或 rank 重複時無法插入：重新平衡後再試一次
+		if err := s.rebalance(ctx, userID); err != nil {
+			return nil, err
+		}
+		if anchor, err = s.ownedTask(ctx, userID, anchorID); err != nil {
+			return nil, err
+		}
+		pos, err = s.positionNear(ctx, userID, task.ID, anchor, opts.Before != 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdatePosition(ctx, task.ID, pos); err != nil {
+		return nil, err
+	}
+	task.Position = pos
+
+	if len(pos) > maxPositionLength {
+		if err := s.rebalance(ctx, userID); err != nil {
+			return nil, err
+		}
+		if task, err = s.ownedTask(ctx, userID, taskID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.invalidateUserTasks(ctx, userID, task.ID)
+	s.publish(ctx, EventTaskUpdated, task)
+	return task, nil
+}
+
+// rebalance 重新配置使用者所有任務的 position；每筆任務都會改變，因此清除所有任務的單筆快取。
+// 重新平衡很少發生，多查一次任務清單可以接受。
+func (s *taskService) rebalance(ctx context.Context, userID uint) error {
+	if err := s.repo.Rebalance(ctx, userID); err != nil {
+		return err
+	}
+	if s.store == nil {
+		return nil
+	}
+	tasks, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		log.Printf("cache: list tasks of user %d after rebalance: %v", userID, err)
+		return nil
+	}
+	ids := make([]uint, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	s.invalidateUserTasks(ctx, userID, ids...)
+	return nil
+}
+
+// rebalanceIfLong 在新附加的 rank 超過 maxPositionLength 時重新平衡，回傳是否已重新平衡。
+// 任務已寫入成功，重新平衡失敗只記錄，下次新增或移動時會再嘗試。
+func (s *taskService) rebalanceIfLong(ctx context.Context, userID uint, pos string) bool {
+	if len(pos) <= maxPositionLength {
+		return false
+	}
+	if err := s.rebalance(ctx, userID); err != nil {
+		log.Printf("rebalance positions of user %d: %v", userID, err)
+		return false
+	}
+	return true
+}
+
+// positionNear 計算緊鄰 anchor 之前（before=true）或之後的 rank。
+func (s *taskService) positionNear(ctx context.Context, userID, taskID uint, anchor *model.Task, before bool) (string, error) {
+	if anchor.Position == "" {
+		return "", util.ErrInvalidRankRange
+	}
+	if before {
+		prev, err := s.repo.PrevPosition(ctx, userID, anchor.Position, taskID)
+		if err != nil {
+			return "", err
+		}
+		return util.RankBetween(prev, anchor.Position)
+	}
+	next, err := s.repo.NextPosition(ctx, userID, anchor.Position, taskID)
+	if err != nil {
+		return "", err
+	}
+	return util.RankBetween(anchor.Position, next)
+}
+
+func (s *taskService) ownedTask(ctx context.Context, userID, taskID uint) (*model.Task, error) {
+	t, err := s.repo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTaskNotFound
+	}
+	if t.UserID != userID {
+		return nil, ErrPermissionDenied
+	}
+	return t, nil
+}
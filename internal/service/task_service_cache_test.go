@@ -3,6 +3,7 @@ package service_test
 import (
 	"context"
 	"encoding/json"
+	"sync"
 	"testing"
 	"time"
 
@@ -42,7 +43,7 @@ func TestTaskService_ListTasks_CacheMiss(t *testing.T) {
 		{ID: 2, UserID: userID, Title: "Task 2", Status: model.TaskStatusDone},
 	}
 
-	service := service.NewTaskService(mockRepo, rdb, 60*time.Second)
+	service := service.NewTaskService(mockRepo, cache.NewRedisStore(rdb), 60*time.Second)
 
 	// Expect repository call on cache miss
 	mockRepo.EXPECT().
@@ -95,7 +96,7 @@ func TestTaskService_ListTasks_CacheHit(t *testing.T) {
 	err = rdb.Set(context.Background(), key, cachedData, 60*time.Second).Err()
 	require.NoError(t, err)
 
-	service := service.NewTaskService(mockRepo, rdb, 60*time.Second)
+	service := service.NewTaskService(mockRepo, cache.NewRedisStore(rdb), 60*time.Second)
 
 	// Should not call repository when cache exists
 	// (no mock expectations set)
@@ -155,7 +156,7 @@ func TestTaskService_ListTasks_CacheErrorHandling(t *testing.T) {
 		{ID: 1, UserID: userID, Title: "Task 1", Status: model.TaskStatusPending},
 	}
 
-	service := service.NewTaskService(mockRepo, rdb, 60*time.Second)
+	service := service.NewTaskService(mockRepo, cache.NewRedisStore(rdb), 60*time.Second)
 
 	t.Run("should fallback to DB when cache fails", func(t *testing.T) {
 		// Should call repository when cache fails
@@ -191,7 +192,7 @@ func TestTaskService_ListTasks_Singleflight(t *testing.T) {
 		{ID: 1, UserID: userID, Title: "Task 1", Status: model.TaskStatusPending},
 	}
 
-	service := service.NewTaskService(mockRepo, rdb, 60*time.Second)
+	service := service.NewTaskService(mockRepo, cache.NewRedisStore(rdb), 60*time.Second)
 
 	t.Run("concurrent cache misses should only call repository once", func(t *testing.T) {
 		// Expect repository to be called only once for concurrent requests
@@ -252,7 +253,7 @@ func TestTaskService_CreateTask_CacheInvalidation(t *testing.T) {
 	err = rdb.Set(context.Background(), key, cachedData, 60*time.Second).Err()
 	require.NoError(t, err)
 
-	service := service.NewTaskService(mockRepo, rdb, 60*time.Second)
+	service := service.NewTaskService(mockRepo, cache.NewRedisStore(rdb), 60*time.Second)
 
 	// Expect repository call for task creation
 	mockRepo.EXPECT().
@@ -303,9 +304,12 @@ func TestTaskService_UpdateTask_CacheInvalidation(t *testing.T) {
 	err = rdb.Set(context.Background(), key, cachedData, 60*time.Second).Err()
 	require.NoError(t, err)
 
-	service := service.NewTaskService(mockRepo, rdb, 60*time.Second)
+	service := service.NewTaskService(mockRepo, cache.NewRedisStore(rdb), 60*time.Second)
 
-	// Expect repository call for task update
+	// Expect repository calls for task update
+	mockRepo.EXPECT().
+		FindByID(gomock.Any(), updatedTask.ID).
+		Return(&model.Task{ID: 1, UserID: userID, Title: "Original Task", Status: model.TaskStatusPending}, nil)
 	mockRepo.EXPECT().
 		UpdateTask(gomock.Any(), updatedTask).
 		Return(nil)
@@ -350,7 +354,7 @@ func TestTaskService_DeleteTask_CacheInvalidation(t *testing.T) {
 	err = rdb.Set(context.Background(), key, cachedData, 60*time.Second).Err()
 	require.NoError(t, err)
 
-	service := service.NewTaskService(mockRepo, rdb, 60*time.Second)
+	service := service.NewTaskService(mockRepo, cache.NewRedisStore(rdb), 60*time.Second)
 
 	// Expect repository calls for task deletion
 	existingTask := &model.Task{ID: taskID, UserID: userID, Title: "Task to Delete"}
@@ -395,3 +399,158 @@ func TestTaskService_CacheInvalidation_WhenCacheDisabled(t *testing.T) {
 	err := service.CreateTask(context.Background(), newTask)
 	require.NoError(t, err)
 }
+
+func TestTaskService_GetTask_Cache(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*mock_repository.MockTaskRepository, *miniredis.Miniredis, service.TaskService) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+		mr, err := miniredis.Run()
+		require.NoError(t, err)
+		t.Cleanup(mr.Close)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = rdb.Close() })
+
+		repo := mock_repository.NewMockTaskRepository(ctrl)
+		return repo, mr, service.NewTaskService(repo, cache.NewRedisStore(rdb), time.Minute)
+	}
+
+	t.Run("hit does not query repository", func(t *testing.T) {
+		repo, _, svc := setup(t)
+		task := &model.Task{ID: 1, UserID: 7, Title: "a", Status: model.TaskStatusPending}
+		// 第一次 miss 只記下擁有者；第二次先讀擁有者的世代號再查詢並快取
+		repo.EXPECT().FindByID(gomock.Any(), uint(1)).Return(task, nil).Times(2)
+
+		for i := 0; i < 2; i++ {
+			got, err := svc.GetTask(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, task, got)
+		}
+
+		// 呼叫端修改回傳值不影響快取
+		got, _ := svc.GetTask(ctx, 1)
+		got.Title = "changed"
+		got, _ = svc.GetTask(ctx, 1)
+		assert.Equal(t, "a", got.Title)
+	})
+
+	t.Run("concurrent misses load once", func(t *testing.T) {
+		repo, _, svc := setup(t)
+		release := make(chan struct{})
+		repo.EXPECT().FindByID(gomock.Any(), uint(1)).DoAndReturn(func(context.Context, uint) (*model.Task, error) {
+			<-release
+			return &model.Task{ID: 1, UserID: 7, Title: "a"}, nil
+		}).Times(1)
+
+		done := make(chan *model.Task, 5)
+		for i := 0; i < 5; i++ {
+			go func() {
+				got, err := svc.GetTask(ctx, 1)
+				assert.NoError(t, err)
+				done <- got
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+
+		seen := map[*model.Task]bool{}
+		for i := 0; i < 5; i++ {
+			got := <-done
+			require.NotNil(t, got)
+			seen[got] = true
+		}
+		assert.Len(t, seen, 5, "each caller gets its own copy")
+	})
+
+	t.Run("write during a load does not leave the old task cached", func(t *testing.T) {
+		repo, _, svc := setup(t)
+		var mu sync.Mutex
+		stored := model.Task{ID: 1, UserID: 7, Title: "old", Status: model.TaskStatusPending}
+		loads := 0
+		repo.EXPECT().FindByID(gomock.Any(), uint(1)).DoAndReturn(func(context.Context, uint) (*model.Task, error) {
+			mu.Lock()
+			snapshot := stored
+			loads++
+			n := loads
+			mu.Unlock()
+			if n == 2 {
+				// GetTask 讀出舊內容之後、寫入快取之前，另一個請求完成更新
+				require.NoError(t, svc.UpdateTask(ctx, &model.Task{ID: 1, UserID: 7, Title: "new", Status: model.TaskStatusPending}))
+			}
+			return &snapshot, nil
+		}).AnyTimes()
+		repo.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *model.Task) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stored = *task
+			return nil
+		})
+
+		// 第一次讀取記下擁有者；第二次讀取才會寫入快取
+		got, err := svc.GetTask(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "old", got.Title)
+
+		got, err = svc.GetTask(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "old", got.Title, "the in-flight read returns what it loaded")
+
+		got, err = svc.GetTask(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "new", got.Title)
+	})
+
+	t.Run("not found is cached briefly", func(t *testing.T) {
+		repo, mr, svc := setup(t)
+		repo.EXPECT().FindByID(gomock.Any(), uint(9)).Return(nil, nil).Times(1)
+
+		for i := 0; i < 3; i++ {
+			got, err := svc.GetTask(ctx, 9)
+			require.NoError(t, err)
+			assert.Nil(t, got)
+		}
+		ttl := mr.TTL(cache.KeyTask(9))
+		assert.True(t, ttl > 0 && ttl <= 5*time.Second, "ttl = %v", ttl)
+
+		// 建立同 id 的任務會清掉「不存在」
+		repo.EXPECT().CreateTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *model.Task) error {
+			task.ID = 9
+			return nil
+		})
+		require.NoError(t, svc.CreateTask(ctx, &model.Task{UserID: 7, Title: "new", Status: model.TaskStatusPending}))
+		assert.False(t, mr.Exists(cache.KeyTask(9)))
+	})
+
+	t.Run("update and delete invalidate", func(t *testing.T) {
+		repo, mr, svc := setup(t)
+		task := &model.Task{ID: 1, UserID: 7, Title: "a", Status: model.TaskStatusPending}
+		repo.EXPECT().FindByID(gomock.Any(), uint(1)).Return(task, nil).AnyTimes()
+		repo.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().DeleteTask(gomock.Any(), uint(1)).Return(nil)
+
+		_, err := svc.GetTask(ctx, 1)
+		require.NoError(t, err)
+		assert.False(t, mr.Exists(cache.KeyTask(1)), "first read only records the owner")
+		_, err = svc.GetTask(ctx, 1)
+		require.NoError(t, err)
+		require.True(t, mr.Exists(cache.KeyTask(1)))
+		require.NoError(t, svc.UpdateTask(ctx, &model.Task{ID: 1, UserID: 7, Title: "b", Status: model.TaskStatusPending}))
+		assert.False(t, mr.Exists(cache.KeyTask(1)))
+
+		_, err = svc.GetTask(ctx, 1)
+		require.NoError(t, err)
+		require.True(t, mr.Exists(cache.KeyTask(1)))
+		require.NoError(t, svc.DeleteTask(ctx, 7, 1))
+		assert.False(t, mr.Exists(cache.KeyTask(1)))
+	})
+
+	t.Run("repository error is not cached", func(t *testing.T) {
+		repo, mr, svc := setup(t)
+		repo.EXPECT().FindByID(gomock.Any(), uint(1)).Return(nil, assert.AnError)
+
+		_, err := svc.GetTask(ctx, 1)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.False(t, mr.Exists(cache.KeyTask(1)))
+	})
+}
@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func TestTaskService_MoveTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTaskRepository(ctrl)
+	svc := service.NewTaskService(mockRepo, nil, 60*time.Second)
+	ctx := context.Background()
+
+	t.Run("before anchor", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(ctx, uint(1)).Return(&model.Task{ID: 1, UserID: 7, Position: "x"}, nil)
+		mockRepo.EXPECT().FindByID(ctx, uint(2)).Return(&model.Task{ID: 2, UserID: 7, Position: "m"}, nil)
+		mockRepo.EXPECT().PrevPosition(ctx, uint(7), "m", uint(1)).Return("c", nil)
+		mockRepo.EXPECT().UpdatePosition(ctx, uint(1), gomock.Any()).Return(nil)
+
+		task, err := svc.MoveTask(ctx, 7, 1, service.MoveOptions{Before: 2})
+		require.NoError(t, err)
+		assert.True(t, "c" < task.Position && task.Position < "m")
+	})
+
+	t.Run("after anchor at end of list", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(ctx, uint(1)).Return(&model.Task{ID: 1, UserID: 7, Position: "a"}, nil)
+		mockRepo.EXPECT().FindByID(ctx, uint(3)).Return(&model.Task{ID: 3, UserID: 7, Position: "m"}, nil)
+		mockRepo.EXPECT().NextPosition(ctx, uint(7), "m", uint(1)).Return("", nil)
+		mockRepo.EXPECT().UpdatePosition(ctx, uint(1), gomock.Any()).Return(nil)
+
+		task, err := svc.MoveTask(ctx, 7, 1, service.MoveOptions{After: 3})
+		require.NoError(t, err)
+		assert.Greater(t, task.Position, "m")
+	})
+
+	t.Run("anchor without position triggers rebalance", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(ctx, uint(1)).Return(&model.Task{ID: 1, UserID: 7}, nil)
+		mockRepo.EXPECT().FindByID(ctx, uint(4)).Return(&model.Task{ID: 4, UserID: 7}, nil)
+		mockRepo.EXPECT().Rebalance(ctx, uint(7)).Return(nil)
+		mockRepo.EXPECT().FindByID(ctx, uint(4)).Return(&model.Task{ID: 4, UserID: 7, Position: "i"}, nil)
+		mockRepo.EXPECT().NextPosition(ctx, uint(7), "i", uint(1)).Return("r", nil)
+		mockRepo.EXPECT().UpdatePosition(ctx, uint(1), gomock.Any()).Return(nil)
+
+		task, err := svc.MoveTask(ctx, 7, 1, service.MoveOptions{After: 4})
+		require.NoError(t, err)
+		assert.True(t, "i" < task.Position && task.Position < "r")
+	})
+
+	t.Run("long rank triggers rebalance", func(t *testing.T) {
+		long := strings.Repeat("h", 32)
+		mockRepo.EXPECT().FindByID(ctx, uint(1)).Return(&model.Task{ID: 1, UserID: 7, Position: "z"}, nil)
+		mockRepo.EXPECT().FindByID(ctx, uint(5)).Return(&model.Task{ID: 5, UserID: 7, Position: long + "1"}, nil)
+		mockRepo.EXPECT().PrevPosition(ctx, uint(7), long+"1", uint(1)).Return(long, nil)
+		mockRepo.EXPECT().UpdatePosition(ctx, uint(1), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().Rebalance(ctx, uint(7)).Return(nil)
+		mockRepo.EXPECT().FindByID(ctx, uint(1)).Return(&model.Task{ID: 1, UserID: 7, Position: "3"}, nil)
+
+		task, err := svc.MoveTask(ctx, 7, 1, service.MoveOptions{Before: 5})
+		require.NoError(t, err)
+		assert.Equal(t, "3", task.Position)
+	})
+
+	t.Run("requires exactly one anchor", func(t *testing.T) {
+		_, err := svc.MoveTask(ctx, 7, 1, service.MoveOptions{})
+		assert.ErrorIs(t, err, service.ErrInvalidMove)
+
+		_, err = svc.MoveTask(ctx, 7, 1, service.MoveOptions{Before: 2, After: 3})
+		assert.ErrorIs(t, err, service.ErrInvalidMove)
+	})
+
+	t.Run("anchor owned by another user", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(ctx, uint(1)).Return(&model.Task{ID: 1, UserID: 7, Position: "a"}, nil)
+		mockRepo.EXPECT().FindByID(ctx, uint(9)).Return(&model.Task{ID: 9, UserID: 8, Position: "b"}, nil)
+
+		_, err := svc.MoveTask(ctx, 7, 1, service.MoveOptions{Before: 9})
+		assert.ErrorIs(t, err, service.ErrPermissionDenied)
+	})
+}
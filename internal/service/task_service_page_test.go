@@ -0,0 +1,197 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/golang/mock/gomock"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/cache"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/util"
+)
+
+func TestTaskService_ListTaskPage_WithoutCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTaskRepository(ctrl)
+	svc := service.NewTaskService(mockRepo, nil, 60*time.Second,
+		service.WithCursorCodec(util.NewCursorCodec("secret")),
+	)
+	ctx := context.Background()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 第一頁：多查一筆代表還有下一頁
+	newest := []repository.SortField{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}}
+	mockRepo.EXPECT().ListPage(ctx, uint(1), repository.TaskPageQuery{Sort: newest, Limit: 3}).
+		Return([]*model.Task{
+			{ID: 9, CreatedAt: created.Add(3 * time.Minute)},
+			{ID: 8, CreatedAt: created.Add(2 * time.Minute)},
+			{ID: 7, CreatedAt: created.Add(time.Minute)},
+		}, nil)
+	mockRepo.EXPECT().CountByUserID(ctx, uint(1), repository.TaskFilter{}).Return(int64(5), nil)
+
+	page, err := svc.ListTaskPage(ctx, 1, service.TaskPageRequest{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, page.Tasks, 2)
+	assert.Equal(t, int64(5), page.Total)
+	require.NotEmpty(t, page.NextCursor)
+
+	// 第二頁：游標帶入上一頁最後一筆的排序值
+	mockRepo.EXPECT().ListPage(ctx, uint(1), repository.TaskPageQuery{
+		Sort:  newest,
+		Limit: 3,
+		After: []string{created.Add(2 * time.Minute).Format(time.RFC3339Nano), "8"},
+	}).Return([]*model.Task{{ID: 7}}, nil)
+	mockRepo.EXPECT().CountByUserID(ctx, uint(1), repository.TaskFilter{}).Return(int64(5), nil)
+
+	page, err = svc.ListTaskPage(ctx, 1, service.TaskPageRequest{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Len(t, page.Tasks, 1)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestTaskService_ListTaskPage_InvalidInput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTaskRepository(ctrl)
+	svc := service.NewTaskService(mockRepo, nil, 60*time.Second)
+	ctx := context.Background()
+
+	_, err := svc.ListTaskPage(ctx, 1, service.TaskPageRequest{Sort: "password"})
+	assert.ErrorIs(t, err, service.ErrInvalidSort)
+
+	_, err = svc.ListTaskPage(ctx, 1, service.TaskPageRequest{Cursor: "forged.cursor"})
+	assert.ErrorIs(t, err, service.ErrInvalidCursor)
+
+	// 以另一把金鑰簽出的游標也會被拒絕
+	other, _ := util.NewCursorCodec("other").Encode(map[string]interface{}{"q": "x", "v": []string{"x", "1"}})
+	_, err = svc.ListTaskPage(ctx, 1, service.TaskPageRequest{Cursor: other})
+	assert.ErrorIs(t, err, service.ErrInvalidCursor)
+}
+
+func TestTaskService_ListTaskPage_Cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTaskRepository(ctrl)
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	svc := service.NewTaskService(mockRepo, cache.NewRedisStore(rdb), 60*time.Second)
+	ctx := context.Background()
+	req := service.TaskPageRequest{Sort: "position", Limit: 10}
+
+	// 第一次 miss：查 DB 並寫入快取
+	mockRepo.EXPECT().ListPage(gomock.Any(), uint(1), gomock.Any()).
+		Return([]*model.Task{{ID: 1, UserID: 1, Title: "A", Position: "a"}}, nil).Times(1)
+	mockRepo.EXPECT().CountByUserID(gomock.Any(), uint(1), repository.TaskFilter{}).Return(int64(1), nil).Times(1)
+
+	page, err := svc.ListTaskPage(ctx, 1, req)
+	require.NoError(t, err)
+	assert.Equal(t, "A", page.Tasks[0].Title)
+
+	// 第二次 hit：不應再查 DB
+	page, err = svc.ListTaskPage(ctx, 1, req)
+	require.NoError(t, err)
+	assert.Equal(t, "A", page.Tasks[0].Title)
+	assert.Equal(t, int64(1), page.Total)
+
+	// 寫入後世代號遞增，舊頁面不再被讀到
+	mockRepo.EXPECT().CreateTask(gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, svc.CreateTask(ctx, &model.Task{UserID: 1, Title: "B"}))
+
+	mockRepo.EXPECT().ListPage(gomock.Any(), uint(1), gomock.Any()).
+		Return([]*model.Task{{ID: 1, Title: "A"}, {ID: 2, Title: "B"}}, nil)
+	mockRepo.EXPECT().CountByUserID(gomock.Any(), uint(1), repository.TaskFilter{}).Return(int64(2), nil)
+
+	page, err = svc.ListTaskPage(ctx, 1, req)
+	require.NoError(t, err)
+	assert.Len(t, page.Tasks, 2)
+	assert.Equal(t, int64(2), page.Total)
+}
+
+func TestParseTaskSort(t *testing.T) {
+	fields, err := service.ParseTaskSort("")
+	require.NoError(t, err)
+	assert.Equal(t, []repository.SortField{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}}, fields)
+
+	fields, err = service.ParseTaskSort("status,-updated_at")
+	require.NoError(t, err)
+	assert.Equal(t, []repository.SortField{
+		{Column: "status"},
+		{Column: "updated_at", Desc: true},
+		{Column: "id", Desc: true},
+	}, fields)
+
+	// README 與需求中的範例
+	fields, err = service.ParseTaskSort("-priority,created_at")
+	require.NoError(t, err)
+	assert.Equal(t, []repository.SortField{
+		{Column: "priority", Desc: true},
+		{Column: "created_at"},
+		{Column: "id"},
+	}, fields)
+
+	for _, bad := range []string{"password", "status,status", "-", "title,status,position,created_at,updated_at"} {
+		_, err = service.ParseTaskSort(bad)
+		assert.ErrorIs(t, err, service.ErrInvalidSort, bad)
+	}
+}
+
+func TestTaskService_ListTaskPage_Filtered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTaskRepository(ctrl)
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	svc := service.NewTaskService(mockRepo, cache.NewRedisStore(rdb), 60*time.Second)
+	ctx := context.Background()
+
+	// 篩選條件會被正規化，且篩選結果不寫入快取
+	want := repository.TaskFilter{Statuses: []string{"done", "todo"}}
+	mockRepo.EXPECT().ListPage(gomock.Any(), uint(1), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uint, q repository.TaskPageQuery) ([]*model.Task, error) {
+			assert.Equal(t, want, q.Filter)
+			return []*model.Task{{ID: 1, Status: "done"}, {ID: 2, Status: "todo"}}, nil
+		}).Times(2)
+	mockRepo.EXPECT().CountByUserID(gomock.Any(), uint(1), want).Return(int64(2), nil).Times(2)
+
+	req := service.TaskPageRequest{Filter: service.TaskFilter{Statuses: []string{"todo", "done", "todo"}}, Limit: 1}
+	page, err := svc.ListTaskPage(ctx, 1, req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), page.Total)
+	require.NotEmpty(t, page.NextCursor)
+	assert.Empty(t, mr.Keys())
+
+	// 游標綁定原本的查詢條件
+	req.Cursor = page.NextCursor
+	_, err = svc.ListTaskPage(ctx, 1, req)
+	require.NoError(t, err)
+
+	req.Filter = service.TaskFilter{}
+	_, err = svc.ListTaskPage(ctx, 1, req)
+	assert.ErrorIs(t, err, service.ErrInvalidCursor)
+}
@@ -3,11 +3,13 @@ package service_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/SoliMark/gotasker-pro/internal/model"
 	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
@@ -34,6 +36,56 @@ func TestTaskService_CreateTask(t *testing.T) {
 		task := &model.Task{UserID: 2, Title: ""}
 		err := svc.CreateTask(ctx, task)
 		assert.EqualError(t, err, "title is required")
+
+		err = svc.CreateTask(ctx, &model.Task{UserID: 2, Title: " \t"})
+		assert.ErrorIs(t, err, service.ErrTitleRequired)
+	})
+
+	t.Run("priority out of range", func(t *testing.T) {
+		task := &model.Task{UserID: 2, Title: "P", Priority: 10}
+		err := svc.CreateTask(ctx, task)
+		assert.ErrorIs(t, err, service.ErrInvalidPriority)
+	})
+
+	t.Run("subtask", func(t *testing.T) {
+		parent := uint(5)
+		task := &model.Task{UserID: 2, Title: "Sub", ParentID: &parent}
+		mockRepo.EXPECT().FindByID(ctx, parent).Return(&model.Task{ID: 5, UserID: 2}, nil)
+		mockRepo.EXPECT().CreateTask(ctx, task).Return(nil)
+
+		assert.NoError(t, svc.CreateTask(ctx, task))
+	})
+
+	t.Run("invalid parent", func(t *testing.T) {
+		grandparent := uint(4)
+		parents := map[string]*model.Task{
+			"missing":       nil,
+			"another user":  {ID: 5, UserID: 3},
+			"nested parent": {ID: 5, UserID: 2, ParentID: &grandparent},
+		}
+		for name, p := range parents {
+			t.Run(name, func(t *testing.T) {
+				parent := uint(5)
+				mockRepo.EXPECT().FindByID(ctx, parent).Return(p, nil)
+
+				err := svc.CreateTask(ctx, &model.Task{UserID: 2, Title: "Sub", ParentID: &parent})
+				assert.ErrorIs(t, err, service.ErrInvalidParent)
+			})
+		}
+	})
+
+	t.Run("long position triggers rebalance", func(t *testing.T) {
+		task := &model.Task{UserID: 4, Title: "Last"}
+		mockRepo.EXPECT().CreateTask(ctx, task).DoAndReturn(func(_ context.Context, task *model.Task) error {
+			task.ID, task.Position = 9, strings.Repeat("z", 33)
+			return nil
+		})
+		mockRepo.EXPECT().Rebalance(ctx, uint(4)).Return(nil)
+		mockRepo.EXPECT().FindByID(ctx, uint(9)).Return(&model.Task{ID: 9, UserID: 4, Position: "y", ChangeSeq: 3}, nil)
+
+		require.NoError(t, svc.CreateTask(ctx, task))
+		assert.Equal(t, "y", task.Position)
+		assert.Equal(t, int64(3), task.ChangeSeq)
 	})
 
 	t.Run("repo returns error", func(t *testing.T) {
@@ -60,10 +112,66 @@ func TestTaskService_UpdateTask(t *testing.T) {
 			Title:  "New Title",
 			Status: model.TaskStatusDone,
 		}
+		mockRepo.EXPECT().FindByID(ctx, uint(10)).
+			Return(&model.Task{ID: 10, UserID: 1, Title: "Old", Status: model.TaskStatusPending}, nil)
+		mockRepo.EXPECT().UpdateTask(ctx, task).Return(nil)
+
+		err := svc.UpdateTask(ctx, task)
+		assert.NoError(t, err)
+		assert.NotNil(t, task.CompletedAt) // done 為終止狀態
+	})
+
+	t.Run("reopen clears completed_at", func(t *testing.T) {
+		completed := time.Now()
+		task := &model.Task{
+			ID:          10,
+			UserID:      1,
+			Title:       "Again",
+			Status:      model.TaskStatusPending,
+			CompletedAt: &completed,
+		}
+		mockRepo.EXPECT().FindByID(ctx, uint(10)).
+			Return(&model.Task{ID: 10, UserID: 1, Title: "Again", Status: model.TaskStatusDone}, nil)
 		mockRepo.EXPECT().UpdateTask(ctx, task).Return(nil)
 
 		err := svc.UpdateTask(ctx, task)
 		assert.NoError(t, err)
+		assert.Nil(t, task.CompletedAt)
+	})
+
+	t.Run("stale copy keeps fields it cannot edit", func(t *testing.T) {
+		projectID := uint(4)
+		completed := time.Now().Add(-time.Hour)
+		// task 是移動、換專案之前從快取讀出的副本
+		task := &model.Task{ID: 10, UserID: 1, Title: "Renamed", Status: model.TaskStatusDone, Position: "a"}
+		mockRepo.EXPECT().FindByID(ctx, uint(10)).Return(&model.Task{
+			ID: 10, UserID: 1, Title: "Old", Status: model.TaskStatusDone,
+			Position: "m", ProjectID: &projectID, CompletedAt: &completed, ChangeSeq: 9,
+		}, nil)
+		mockRepo.EXPECT().UpdateTask(ctx, task).Return(nil)
+
+		require.NoError(t, svc.UpdateTask(ctx, task))
+		assert.Equal(t, "Renamed", task.Title)
+		assert.Equal(t, "m", task.Position)
+		assert.Equal(t, &projectID, task.ProjectID)
+		assert.Equal(t, &completed, task.CompletedAt, "status unchanged keeps completed_at")
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		task := &model.Task{ID: 10, UserID: 1, Title: "X", Status: "weird"}
+		mockRepo.EXPECT().FindByID(ctx, uint(10)).
+			Return(&model.Task{ID: 10, UserID: 1, Title: "X", Status: model.TaskStatusPending}, nil)
+
+		err := svc.UpdateTask(ctx, task)
+		assert.ErrorIs(t, err, service.ErrInvalidStatus)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		task := &model.Task{ID: 11, UserID: 1, Title: "X"}
+		mockRepo.EXPECT().FindByID(ctx, uint(11)).Return(nil, nil)
+
+		err := svc.UpdateTask(ctx, task)
+		assert.ErrorIs(t, err, service.ErrTaskNotFound)
 	})
 
 	t.Run("empty title -> error", func(t *testing.T) {
@@ -74,6 +182,9 @@ func TestTaskService_UpdateTask(t *testing.T) {
 		}
 		err := svc.UpdateTask(ctx, task)
 		assert.EqualError(t, err, "title is required")
+
+		task.Title = "   "
+		assert.ErrorIs(t, svc.UpdateTask(ctx, task), service.ErrTitleRequired)
 	})
 
 	t.Run("repo error", func(t *testing.T) {
@@ -82,6 +193,8 @@ func TestTaskService_UpdateTask(t *testing.T) {
 			UserID: 1,
 			Title:  "X",
 		}
+		mockRepo.EXPECT().FindByID(ctx, uint(10)).
+			Return(&model.Task{ID: 10, UserID: 1, Title: "X"}, nil)
 		mockRepo.EXPECT().UpdateTask(ctx, task).Return(errors.New("db err"))
 
 		err := svc.UpdateTask(ctx, task)
@@ -89,6 +202,83 @@ func TestTaskService_UpdateTask(t *testing.T) {
 	})
 }
 
+func TestTaskService_CustomWorkflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockWorkflows := mock_repository.NewMockWorkflowRepository(ctrl)
+	svc := service.NewTaskService(mockRepo, nil, 60*time.Second,
+		service.WithWorkflowRepository(mockWorkflows),
+	)
+	ctx := context.Background()
+
+	wf := &model.Workflow{
+		UserID:        1,
+		Name:          "kanban",
+		InitialStatus: "todo",
+		States: []model.WorkflowState{
+			{Name: "todo"},
+			{Name: "in_progress"},
+			{Name: "review"},
+			{Name: "done", Completes: true},
+		},
+		Transitions: []model.WorkflowTransition{
+			{FromStatus: "todo", ToStatus: "in_progress"},
+			{FromStatus: "in_progress", ToStatus: "review"},
+			{FromStatus: "review", ToStatus: "done"},
+		},
+	}
+	mockWorkflows.EXPECT().FindByUserID(gomock.Any(), uint(1)).Return(wf, nil).AnyTimes()
+
+	t.Run("create uses initial status", func(t *testing.T) {
+		task := &model.Task{UserID: 1, Title: "T"}
+		mockRepo.EXPECT().CreateTask(ctx, task).Return(nil)
+
+		err := svc.CreateTask(ctx, task)
+		assert.NoError(t, err)
+		assert.Equal(t, "todo", task.Status)
+		assert.Nil(t, task.CompletedAt)
+	})
+
+	t.Run("allowed transition", func(t *testing.T) {
+		task := &model.Task{ID: 20, UserID: 1, Title: "T", Status: "in_progress"}
+		mockRepo.EXPECT().FindByID(ctx, uint(20)).
+			Return(&model.Task{ID: 20, UserID: 1, Title: "T", Status: "todo"}, nil)
+		mockRepo.EXPECT().UpdateTask(ctx, task).Return(nil)
+
+		assert.NoError(t, svc.UpdateTask(ctx, task))
+	})
+
+	t.Run("skipping a step is rejected", func(t *testing.T) {
+		task := &model.Task{ID: 21, UserID: 1, Title: "T", Status: "done"}
+		mockRepo.EXPECT().FindByID(ctx, uint(21)).
+			Return(&model.Task{ID: 21, UserID: 1, Title: "T", Status: "todo"}, nil)
+
+		err := svc.UpdateTask(ctx, task)
+		assert.ErrorIs(t, err, service.ErrInvalidTransition)
+	})
+
+	t.Run("reaching completing state sets completed_at", func(t *testing.T) {
+		task := &model.Task{ID: 22, UserID: 1, Title: "T", Status: "done"}
+		mockRepo.EXPECT().FindByID(ctx, uint(22)).
+			Return(&model.Task{ID: 22, UserID: 1, Title: "T", Status: "review"}, nil)
+		mockRepo.EXPECT().UpdateTask(ctx, task).Return(nil)
+
+		assert.NoError(t, svc.UpdateTask(ctx, task))
+		assert.NotNil(t, task.CompletedAt)
+	})
+
+	t.Run("legacy status outside workflow can move anywhere", func(t *testing.T) {
+		task := &model.Task{ID: 23, UserID: 1, Title: "T", Status: "review"}
+		mockRepo.EXPECT().FindByID(ctx, uint(23)).
+			Return(&model.Task{ID: 23, UserID: 1, Title: "T", Status: model.TaskStatusPending}, nil)
+		mockRepo.EXPECT().UpdateTask(ctx, task).Return(nil)
+
+		assert.NoError(t, svc.UpdateTask(ctx, task))
+	})
+}
+
 func TestTaskService_DeleteTask(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+var (
+	ErrInvalidSyncToken = errors.New("invalid sync token")
+	ErrInvalidSync      = errors.New("invalid sync request")
+	ErrInvalidMutation  = errors.New("invalid mutation")
+)
+
+const (
+	DefaultSyncLimit = 500
+	MaxSyncLimit     = 1000
+	MaxSyncMutations = 500
+)
+
+const (
+	SyncOpCreate = "create"
+	SyncOpUpdate = "update"
+	SyncOpDelete = "delete"
+)
+
+const (
+	SyncResultApplied  = "applied"
+	SyncResultConflict = "conflict"
+	SyncResultFailed   = "failed"
+)
+
+// syncToken 是增量同步的位置；Kind 用來拒絕被誤傳進來的分頁游標。
+type syncToken struct {
+	Kind string `json:"k"`
+	Seq  int64  `json:"s"`
+	ID   uint   `json:"i"`
+}
+
+const syncTokenKind = "sync"
+
+// SyncPage 是一次增量同步的結果；HasMore 為 true 時應立即以 Token 再取下一批。
+type SyncPage struct {
+	Changes []repository.TaskChange
+	Token   string
+	HasMore bool
+}
+
+// TaskPatch 描述要修改的欄位，nil 表示不變；ClearDueAt 清除截止時間，不能與 DueAt 同時使用。
+type TaskPatch struct {
+	Title      *string
+	Content    *string
+	Status     *string
+	Priority   *int
+	DueAt      *time.Time
+	ClearDueAt bool
+}
+
+func (p TaskPatch) apply(t *model.Task) {
+	if p.Title != nil {
+		t.Title = *p.Title
+	}
+	if p.Content != nil {
+		t.Content = *p.Content
+	}
+	if p.Status != nil {
+		t.Status = *p.Status
+	}
+	if p.Priority != nil {
+		t.Priority = *p.Priority
+	}
+	if p.DueAt != nil {
+		t.DueAt = p.DueAt
+	}
+	if p.ClearDueAt {
+		t.DueAt = nil
+	}
+}
+
+// SyncMutation 是 client 離線時累積的一筆修改。BaseSeq 為 client 最後看到的 change_seq，
+// 伺服器上的任務在那之後被改過就回報衝突而不套用；檢查與寫入在同一個交易內完成。
+type SyncMutation struct {
+	Op       string
+	ClientID string
+	TaskID   uint
+	BaseSeq  int64
+	Patch    TaskPatch
+}
+
+// SyncResult 與 SyncMutation 一一對應。applied 時 Task 為寫入後的任務；
+// conflict 時 Task 為伺服器目前的版本，nil 表示任務已在伺服器上刪除；failed 時 Err 為原因。
+type SyncResult struct {
+	ClientID string
+	TaskID   uint
+	Status   string
+	Task     *model.Task
+	Err      error
+}
+
+// SyncChanges 回傳 token 之後新增、修改或刪除的任務；token 為空時從頭開始（完整同步）。
+func (s *taskService) SyncChanges(ctx context.Context, userID uint, token string, limit int) (*SyncPage, error) {
+	if limit < 1 || limit > MaxSyncLimit {
+		return nil, ErrInvalidSync
+	}
+	var after repository.ChangeKey
+	if token != "" {
+		var tok syncToken
+		if err := s.cursors.Decode(token, &tok); err != nil || tok.Kind != syncTokenKind {
+			return nil, ErrInvalidSyncToken
+		}
+		after = repository.ChangeKey{Seq: tok.Seq, ID: tok.ID}
+	}
+
+	changes, err := s.repo.ListChanges(ctx, userID, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &SyncPage{Changes: changes}
+	if len(changes) > limit {
+		page.Changes, page.HasMore = changes[:limit], true
+	}
+	if n := len(page.Changes); n > 0 {
+		after = page.Changes[n-1].Key
+	}
+	page.Token, err = s.cursors.Encode(syncToken{Kind: syncTokenKind, Seq: after.Seq, ID: after.ID})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// ApplySync 依序套用 client 的修改，每一筆各自成功或失敗，不會整批回滾。
+// 寫入經由 CreateTask / UpdateTask / DeleteTask，快取、索引與事件的處理與一般 API 相同。
+func (s *taskService) ApplySync(ctx context.Context, userID uint, mutations []SyncMutation) ([]SyncResult, error) {
+	if len(mutations) == 0 || len(mutations) > MaxSyncMutations {
+		return nil, ErrInvalidSync
+	}
+	results := make([]SyncResult, len(mutations))
+	for i, m := range mutations {
+		res := &results[i]
+		res.ClientID, res.TaskID = m.ClientID, m.TaskID
+		task, conflict, err := s.applyMutation(ctx, userID, m)
+		switch {
+		case err != nil:
+			res.Status, res.Err = SyncResultFailed, err
+		case conflict:
+			res.Status, res.Task = SyncResultConflict, task
+		default:
+			res.Status, res.Task = SyncResultApplied, task
+		}
+		if res.Task != nil {
+			res.TaskID = res.Task.ID
+		}
+	}
+	return results, nil
+}
+
+func (s *taskService) applyMutation(ctx context.Context, userID uint, m SyncMutation) (task *model.Task, conflict bool, err error) {
+	if m.Patch.Title != nil && strings.TrimSpace(*m.Patch.Title) == "" {
+		return nil, false, ErrInvalidMutation
+	}
+	if m.Patch.DueAt != nil && m.Patch.ClearDueAt {
+		return nil, false, ErrInvalidMutation
+	}
+
+	if m.Op == SyncOpCreate {
+		if m.Patch.Title == nil {
+			return nil, false, ErrInvalidMutation
+		}
+		task = &model.Task{UserID: userID}
+		m.Patch.apply(task)
+		if err := s.CreateTask(ctx, task); err != nil {
+			return nil, false, err
+		}
+		return task, false, nil
+	}
+	if m.Op != SyncOpUpdate && m.Op != SyncOpDelete {
+		return nil, false, ErrInvalidMutation
+	}
+
+	current, err := s.ownedTask(ctx, userID, m.TaskID)
+	if errors.Is(err, ErrTaskNotFound) {
+		// 已在伺服器上刪除：刪除視為已完成，修改則回報衝突
+		return nil, m.Op == SyncOpUpdate, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if current.ChangeSeq > m.BaseSeq {
+		return current, true, nil
+	}
+
+	// 上面的檢查只是捷徑；寫入本身帶 base_seq 條件，期間有其他寫入時在這裡回報衝突
+	if m.Op == SyncOpDelete {
+		err = s.deleteTask(ctx, userID, current.ID, &m.BaseSeq)
+	} else {
+		m.Patch.apply(current)
+		err = s.updateTask(ctx, current, &m.BaseSeq)
+	}
+	if errors.Is(err, repository.ErrTaskChanged) || errors.Is(err, ErrTaskNotFound) {
+		return s.syncConflict(ctx, userID, m)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if m.Op == SyncOpDelete {
+		return nil, false, nil
+	}
+	return current, false, nil
+}
+
+// syncConflict 重新讀取伺服器目前的版本作為衝突回應；任務已被刪除時，刪除視為已完成。
+func (s *taskService) syncConflict(ctx context.Context, userID uint, m SyncMutation) (*model.Task, bool, error) {
+	latest, err := s.ownedTask(ctx, userID, m.TaskID)
+	if errors.Is(err, ErrTaskNotFound) {
+		return nil, m.Op == SyncOpUpdate, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return latest, true, nil
+}
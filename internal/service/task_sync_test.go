@@ -0,0 +1,183 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/util"
+)
+
+func TestTaskService_SyncChanges(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockTaskRepository(ctrl)
+	codec := util.NewCursorCodec("secret")
+	svc := service.NewTaskService(repo, nil, time.Minute, service.WithCursorCodec(codec))
+
+	deletedAt := time.Now()
+	changes := []repository.TaskChange{
+		{Key: repository.ChangeKey{Seq: 1, ID: 1}, Task: &model.Task{ID: 1, ChangeSeq: 1}},
+		{Key: repository.ChangeKey{Seq: 2, ID: 2}, DeletedAt: &deletedAt},
+		{Key: repository.ChangeKey{Seq: 3, ID: 3}, Task: &model.Task{ID: 3, ChangeSeq: 3}},
+	}
+	repo.EXPECT().ListChanges(ctx, uint(7), repository.ChangeKey{}, 3).Return(changes, nil)
+
+	page, err := svc.SyncChanges(ctx, 7, "", 2)
+	require.NoError(t, err)
+	assert.True(t, page.HasMore)
+	require.Len(t, page.Changes, 2)
+
+	// 下一次從上一批最後一筆接續
+	repo.EXPECT().ListChanges(ctx, uint(7), repository.ChangeKey{Seq: 2, ID: 2}, 3).Return(changes[2:], nil)
+	page, err = svc.SyncChanges(ctx, 7, page.Token, 2)
+	require.NoError(t, err)
+	assert.False(t, page.HasMore)
+	assert.Len(t, page.Changes, 1)
+
+	// 沒有新變更時 token 不變
+	repo.EXPECT().ListChanges(ctx, uint(7), repository.ChangeKey{Seq: 3, ID: 3}, 3).Return(nil, nil)
+	next, err := svc.SyncChanges(ctx, 7, page.Token, 2)
+	require.NoError(t, err)
+	assert.Equal(t, page.Token, next.Token)
+
+	_, err = svc.SyncChanges(ctx, 7, "garbage", 2)
+	assert.ErrorIs(t, err, service.ErrInvalidSyncToken)
+	pageCursor, err := codec.Encode(map[string]any{"q": "x", "v": []string{"1"}})
+	require.NoError(t, err)
+	_, err = svc.SyncChanges(ctx, 7, pageCursor, 2)
+	assert.ErrorIs(t, err, service.ErrInvalidSyncToken)
+	_, err = svc.SyncChanges(ctx, 7, "", service.MaxSyncLimit+1)
+	assert.ErrorIs(t, err, service.ErrInvalidSync)
+}
+
+func TestTaskService_ApplySync(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockTaskRepository(ctrl)
+	svc := service.NewTaskService(repo, nil, time.Minute)
+
+	title := "offline edit"
+	empty := " "
+	stale := &model.Task{ID: 1, UserID: 7, Title: "server edit", Status: model.TaskStatusPending, ChangeSeq: 9}
+	fresh := &model.Task{ID: 2, UserID: 7, Title: "old", Status: model.TaskStatusPending, ChangeSeq: 4}
+	toDelete := &model.Task{ID: 3, UserID: 7, Title: "x", Status: model.TaskStatusPending, ChangeSeq: 4}
+	notMine := &model.Task{ID: 4, UserID: 8, Title: "y", ChangeSeq: 1}
+
+	gomock.InOrder(
+		repo.EXPECT().CreateTask(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, task *model.Task) error {
+			task.ID, task.ChangeSeq = 10, 11
+			return nil
+		}),
+		repo.EXPECT().FindByID(ctx, uint(1)).Return(stale, nil),
+		repo.EXPECT().FindByID(ctx, uint(2)).Return(fresh, nil),
+		repo.EXPECT().FindByID(ctx, uint(2)).Return(fresh, nil),
+		repo.EXPECT().UpdateTaskIfUnchanged(ctx, gomock.Any(), int64(4)).Return(nil),
+		repo.EXPECT().FindByID(ctx, uint(3)).Return(toDelete, nil),
+		repo.EXPECT().FindByID(ctx, uint(3)).Return(toDelete, nil),
+		repo.EXPECT().DeleteTaskIfUnchanged(ctx, uint(3), int64(4)).Return(nil),
+		repo.EXPECT().FindByID(ctx, uint(5)).Return(nil, nil),
+		repo.EXPECT().FindByID(ctx, uint(6)).Return(nil, nil),
+		repo.EXPECT().FindByID(ctx, uint(4)).Return(notMine, nil),
+	)
+
+	results, err := svc.ApplySync(ctx, 7, []service.SyncMutation{
+		{Op: service.SyncOpCreate, ClientID: "tmp-1", Patch: service.TaskPatch{Title: &title}},
+		{Op: service.SyncOpUpdate, TaskID: 1, BaseSeq: 5, Patch: service.TaskPatch{Title: &title}},
+		{Op: service.SyncOpUpdate, TaskID: 2, BaseSeq: 4, Patch: service.TaskPatch{Title: &title}},
+		{Op: service.SyncOpDelete, TaskID: 3, BaseSeq: 4},
+		{Op: service.SyncOpUpdate, TaskID: 5, BaseSeq: 1, Patch: service.TaskPatch{Title: &title}},
+		{Op: service.SyncOpDelete, TaskID: 6, BaseSeq: 1},
+		{Op: service.SyncOpDelete, TaskID: 4, BaseSeq: 1},
+		{Op: service.SyncOpUpdate, TaskID: 2, Patch: service.TaskPatch{Title: &empty}},
+		{Op: "rename", TaskID: 2},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 9)
+
+	assert.Equal(t, service.SyncResultApplied, results[0].Status)
+	assert.Equal(t, "tmp-1", results[0].ClientID)
+	assert.Equal(t, uint(10), results[0].TaskID)
+
+	// 伺服器上的版本比 base_seq 新：不套用，回傳伺服器版本
+	assert.Equal(t, service.SyncResultConflict, results[1].Status)
+	assert.Equal(t, "server edit", results[1].Task.Title)
+
+	assert.Equal(t, service.SyncResultApplied, results[2].Status)
+	assert.Equal(t, title, results[2].Task.Title)
+	assert.Equal(t, service.SyncResultApplied, results[3].Status)
+
+	// 修改已刪除的任務是衝突，刪除已刪除的任務視為完成
+	assert.Equal(t, service.SyncResultConflict, results[4].Status)
+	assert.Nil(t, results[4].Task)
+	assert.Equal(t, service.SyncResultApplied, results[5].Status)
+
+	assert.Equal(t, service.SyncResultFailed, results[6].Status)
+	assert.ErrorIs(t, results[6].Err, service.ErrPermissionDenied)
+	assert.ErrorIs(t, results[7].Err, service.ErrInvalidMutation)
+	assert.ErrorIs(t, results[8].Err, service.ErrInvalidMutation)
+
+	_, err = svc.ApplySync(ctx, 7, nil)
+	assert.ErrorIs(t, err, service.ErrInvalidSync)
+}
+
+func TestTaskService_ApplySync_ConcurrentWrite(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockTaskRepository(ctrl)
+	svc := service.NewTaskService(repo, nil, time.Minute)
+
+	due := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	base := func() *model.Task {
+		return &model.Task{ID: 2, UserID: 7, Title: "old", Status: model.TaskStatusPending, DueAt: &due, ChangeSeq: 4}
+	}
+	latest := &model.Task{ID: 2, UserID: 7, Title: "server edit", Status: model.TaskStatusPending, ChangeSeq: 6}
+
+	// 讀取時還是 base_seq，寫入前被其他請求改過：條件式寫入失敗，回報衝突與最新版本
+	gomock.InOrder(
+		repo.EXPECT().FindByID(ctx, uint(2)).Return(base(), nil),
+		repo.EXPECT().FindByID(ctx, uint(2)).Return(base(), nil),
+		repo.EXPECT().UpdateTaskIfUnchanged(ctx, gomock.Any(), int64(4)).Return(repository.ErrTaskChanged),
+		repo.EXPECT().FindByID(ctx, uint(2)).Return(latest, nil),
+		repo.EXPECT().FindByID(ctx, uint(2)).Return(base(), nil),
+		repo.EXPECT().FindByID(ctx, uint(2)).Return(base(), nil),
+		repo.EXPECT().DeleteTaskIfUnchanged(ctx, uint(2), int64(4)).Return(repository.ErrTaskChanged),
+		repo.EXPECT().FindByID(ctx, uint(2)).Return(latest, nil),
+	)
+	title := "offline edit"
+	results, err := svc.ApplySync(ctx, 7, []service.SyncMutation{
+		{Op: service.SyncOpUpdate, TaskID: 2, BaseSeq: 4, Patch: service.TaskPatch{Title: &title}},
+		{Op: service.SyncOpDelete, TaskID: 2, BaseSeq: 4},
+	})
+	require.NoError(t, err)
+	for _, r := range results {
+		assert.Equal(t, service.SyncResultConflict, r.Status)
+		assert.Equal(t, "server edit", r.Task.Title)
+	}
+
+	// clear_due_at 清除截止時間，與 due_at 同時提供則無效
+	gomock.InOrder(
+		repo.EXPECT().FindByID(ctx, uint(2)).Return(base(), nil),
+		repo.EXPECT().FindByID(ctx, uint(2)).Return(base(), nil),
+		repo.EXPECT().UpdateTaskIfUnchanged(ctx, gomock.Any(), int64(4)).DoAndReturn(func(_ context.Context, task *model.Task, _ int64) error {
+			assert.Nil(t, task.DueAt)
+			return nil
+		}),
+	)
+	results, err = svc.ApplySync(ctx, 7, []service.SyncMutation{
+		{Op: service.SyncOpUpdate, TaskID: 2, BaseSeq: 4, Patch: service.TaskPatch{ClearDueAt: true}},
+		{Op: service.SyncOpUpdate, TaskID: 2, BaseSeq: 4, Patch: service.TaskPatch{DueAt: &due, ClearDueAt: true}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, service.SyncResultApplied, results[0].Status)
+	assert.Nil(t, results[0].Task.DueAt)
+	assert.ErrorIs(t, results[1].Err, service.ErrInvalidMutation)
+}
@@ -13,11 +13,13 @@ import (
 var (
 	ErrUserNotFound      = errors.New("user not found")
 	ErrInvalidCredential = errors.New("invaild credentials")
+	ErrEmailTaken        = errors.New("email already registered")
 )
 
 type UserService interface {
 	CreateUser(ctx context.Context, user *model.User) error
 	AuthenticateUser(ctx context.Context, email, passord string) (string, error)
+	GetUsers(ctx context.Context, ids []uint) ([]*model.User, error)
 }
 
 type userService struct {
@@ -35,7 +37,7 @@ func NewUserService(r repository.UserRepository, jwtMaker *util.JWTMaker) UserSe
 func (s *userService) CreateUser(ctx context.Context, user *model.User) error {
 	existing, _ := s.repo.FindByEmail(ctx, user.Email)
 	if existing != nil {
-		return errors.New("email already registered")
+		return ErrEmailTaken
 	}
 
 	hashedPassword, err := util.HashPassword(user.PasswordHash)
@@ -66,3 +68,7 @@ func (s *userService) AuthenticateUser(ctx context.Context, email, passord strin
 	}
 	return token, nil
 }
+
+func (s *userService) GetUsers(ctx context.Context, ids []uint) ([]*model.User, error) {
+	return s.repo.FindByIDs(ctx, ids)
+}
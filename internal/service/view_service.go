@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/SoliMark/gotasker-pro/internal/cache"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+var (
+	ErrViewNotFound = errors.New("view not found")
+	ErrInvalidView  = errors.New("invalid view")
+)
+
+// ViewInput 是建立或修改 saved view 的內容；儲存前會正規化 Filter 與 Sort。
+type ViewInput struct {
+	Name   string
+	Filter TaskFilter
+	Sort   string
+}
+
+type ViewService interface {
+	CreateView(ctx context.Context, userID uint, in ViewInput) (*model.SavedView, error)
+	GetView(ctx context.Context, userID, viewID uint) (*model.SavedView, error)
+	ListViews(ctx context.Context, userID uint) ([]*model.SavedView, error)
+	UpdateView(ctx context.Context, userID, viewID uint, in ViewInput) (*model.SavedView, error)
+	DeleteView(ctx context.Context, userID, viewID uint) error
+	RunView(ctx context.Context, userID, viewID uint, limit int, cursor string) (*TaskPage, error)
+}
+
+type viewService struct {
+	repo    repository.SavedViewRepository
+	tasks   TaskService
+	store   cache.Store
+	ttl     time.Duration
+	sfGroup singleflight.Group
+}
+
+// NewViewService 建立 saved view 服務；執行 view 時透過 tasks.ListTaskPage 查詢，store 為 nil 時不快取。
+func NewViewService(repo repository.SavedViewRepository, tasks TaskService, store cache.Store, ttl time.Duration) ViewService {
+	return &viewService{repo: repo, tasks: tasks, store: store, ttl: ttl}
+}
+
+// ViewFilter 解出 view 儲存的篩選條件。
+func ViewFilter(v *model.SavedView) (TaskFilter, error) {
+	var f TaskFilter
+	if v.Filter == "" {
+		return f, nil
+	}
+	err := json.Unmarshal([]byte(v.Filter), &f)
+	return f, err
+}
+
+func (s *viewService) CreateView(ctx context.Context, userID uint, in ViewInput) (*model.SavedView, error) {
+	view := &model.SavedView{UserID: userID}
+	if err := applyViewInput(view, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, view); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+func (s *viewService) GetView(ctx context.Context, userID, viewID uint) (*model.SavedView, error) {
+	return s.ownedView(ctx, userID, viewID)
+}
+
+func (s *viewService) ListViews(ctx context.Context, userID uint) ([]*model.SavedView, error) {
+	return s.repo.ListByUserID(ctx, userID)
+}
+
+func (s *viewService) UpdateView(ctx context.Context, userID, viewID uint, in ViewInput) (*model.SavedView, error) {
+	view, err := s.ownedView(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyViewInput(view, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, view); err != nil {
+		return nil, err
+	}
+	s.invalidateView(ctx, userID, viewID)
+	return view, nil
+}
+
+func (s *viewService) DeleteView(ctx context.Context, userID, viewID uint) error {
+	if _, err := s.ownedView(ctx, userID, viewID); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, viewID); err != nil {
+		return err
+	}
+	s.invalidateView(ctx, userID, viewID)
+	return nil
+}
+
+// RunView 以 view 的條件列出任務。
+// 使用者的 view 數量有限，因此每個 view 都有自己的分頁快取；key 同時帶任務世代號與 view 世代號，
+// 任務寫入（既有的世代號遞增）或 view 被修改都會讓舊頁面失效。
+func (s *viewService) RunView(ctx context.Context, userID, viewID uint, limit int, cursor string) (*TaskPage, error) {
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+
+	if s.store == nil {
+		return s.loadViewPage(ctx, userID, viewID, limit, cursor)
+	}
+
+	// 先讀世代號再讀 view，避免把新條件的結果寫進舊世代以外的 key
+	taskGen, _ := cache.GetInt64(ctx, s.store, cache.KeyUserTasksGen(userID))
+	viewGen, _ := cache.GetInt64(ctx, s.store, cache.KeyUserViewGen(userID, viewID))
+	key := cache.KeyUserViewPage(userID, viewID, taskGen, viewGen, strconv.Itoa(limit)+"|"+cursor)
+
+	if page, ok := s.cachedViewPage(ctx, key); ok {
+		return page, nil
+	}
+
+	v, err, _ := s.sfGroup.Do(key, func() (interface{}, error) {
+		if page, ok := s.cachedViewPage(ctx, key); ok {
+			return page, nil
+		}
+		page, err := s.loadViewPage(ctx, userID, viewID, limit, cursor)
+		if err != nil {
+			return nil, err
+		}
+		if data, e := json.Marshal(viewPageEntry{Tasks: page.Tasks, NextCursor: page.NextCursor, Total: page.Total}); e == nil {
+			_ = s.store.Set(ctx, key, data, s.ttl)
+		}
+		return page, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	page := *v.(*TaskPage)
+	return &page, nil
+}
+
+// viewPageEntry 是 view 分頁的快取格式；TaskPage.Total 不輸出 JSON，因此另外存一份。
+type viewPageEntry struct {
+	Tasks      []*model.Task `json:"tasks"`
+	NextCursor string        `json:"next_cursor"`
+	Total      int64         `json:"total"`
+}
+
+func (s *viewService) cachedViewPage(ctx context.Context, key string) (*TaskPage, bool) {
+	b, err := s.store.Get(ctx, key)
+	if err != nil || len(b) == 0 {
+		return nil, false
+	}
+	var entry viewPageEntry
+	if json.Unmarshal(b, &entry) != nil {
+		return nil, false
+	}
+	return &TaskPage{Tasks: entry.Tasks, NextCursor: entry.NextCursor, Total: entry.Total}, true
+}
+
+func (s *viewService) loadViewPage(ctx context.Context, userID, viewID uint, limit int, cursor string) (*TaskPage, error) {
+	view, err := s.ownedView(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := ViewFilter(view)
+	if err != nil {
+		return nil, err
+	}
+	return s.tasks.ListTaskPage(ctx, userID, TaskPageRequest{
+		Filter: filter,
+		Sort:   view.Sort,
+		Limit:  limit,
+		Cursor: cursor,
+	})
+}
+
+func (s *viewService) ownedView(ctx context.Context, userID, viewID uint) (*model.SavedView, error) {
+	view, err := s.repo.FindByID(ctx, viewID)
+	if err != nil {
+		return nil, err
+	}
+	if view == nil {
+		return nil, ErrViewNotFound
+	}
+	if view.UserID != userID {
+		return nil, ErrPermissionDenied
+	}
+	return view, nil
+}
+
+func (s *viewService) invalidateView(ctx context.Context, userID, viewID uint) {
+	if s.store == nil {
+		return
+	}
+	_, _ = s.store.Incr(ctx, cache.KeyUserViewGen(userID, viewID))
+}
+
+// applyViewInput 驗證並正規化 view 的名稱、篩選與排序後寫入 view。
+func applyViewInput(view *model.SavedView, in ViewInput) error {
+	name := strings.TrimSpace(in.Name)
+	if name == "" || len(name) > 100 {
+		return ErrInvalidView
+	}
+	sortFields, err := ParseTaskSort(in.Sort)
+	if err != nil {
+		return err
+	}
+	filter, err := json.Marshal(normalizeFilter(in.Filter))
+	if err != nil {
+		return err
+	}
+
+	view.Name = name
+	view.Filter = string(filter)
+	view.Sort = formatTaskSort(sortFields)
+	return nil
+}
@@ -0,0 +1,124 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/golang/mock/gomock"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/cache"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+	"github.com/SoliMark/gotasker-pro/internal/service/mock_service"
+)
+
+func TestViewService_CreateView_Normalizes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockSavedViewRepository(ctrl)
+	svc := service.NewViewService(mockRepo, nil, nil, time.Minute)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	after := time.Date(2024, 1, 1, 8, 0, 0, 0, time.FixedZone("UTC+8", 8*3600))
+	view, err := svc.CreateView(ctx, 1, service.ViewInput{
+		Name:   " Open work ",
+		Filter: service.TaskFilter{Statuses: []string{"pending", "doing", "pending"}, CreatedAfter: &after},
+		Sort:   "status",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Open work", view.Name)
+	assert.Equal(t, "status,id", view.Sort)
+
+	f, err := service.ViewFilter(view)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doing", "pending"}, f.Statuses)
+	assert.Equal(t, time.UTC, f.CreatedAfter.Location())
+	assert.True(t, f.CreatedAfter.Equal(after))
+
+	_, err = svc.CreateView(ctx, 1, service.ViewInput{Name: " "})
+	assert.ErrorIs(t, err, service.ErrInvalidView)
+	_, err = svc.CreateView(ctx, 1, service.ViewInput{Name: "x", Sort: "password"})
+	assert.ErrorIs(t, err, service.ErrInvalidSort)
+}
+
+func TestViewService_Ownership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockSavedViewRepository(ctrl)
+	svc := service.NewViewService(mockRepo, nil, nil, time.Minute)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().FindByID(ctx, uint(3)).Return(nil, nil)
+	_, err := svc.GetView(ctx, 1, 3)
+	assert.ErrorIs(t, err, service.ErrViewNotFound)
+
+	mockRepo.EXPECT().FindByID(ctx, uint(4)).Return(&model.SavedView{ID: 4, UserID: 2}, nil)
+	err = svc.DeleteView(ctx, 1, 4)
+	assert.ErrorIs(t, err, service.ErrPermissionDenied)
+}
+
+func TestViewService_RunView_Cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	mockRepo := mock_repository.NewMockSavedViewRepository(ctrl)
+	mockTasks := mock_service.NewMockTaskService(ctrl)
+	svc := service.NewViewService(mockRepo, mockTasks, cache.NewRedisStore(rdb), time.Minute)
+	ctx := context.Background()
+
+	view := &model.SavedView{ID: 7, UserID: 1, Name: "done", Filter: `{"Statuses":["done"]}`, Sort: "-updated_at,-id"}
+	page := &service.TaskPage{Tasks: []*model.Task{{ID: 1, UserID: 1, Title: "a"}}, NextCursor: "next", Total: 3}
+
+	// 第一次：讀 DB 並寫入快取
+	mockRepo.EXPECT().FindByID(gomock.Any(), uint(7)).Return(view, nil)
+	mockTasks.EXPECT().ListTaskPage(gomock.Any(), uint(1), service.TaskPageRequest{
+		Filter: service.TaskFilter{Statuses: []string{"done"}},
+		Sort:   "-updated_at,-id",
+		Limit:  10,
+	}).Return(page, nil)
+
+	got, err := svc.RunView(ctx, 1, 7, 10, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), got.Total)
+
+	// 第二次：命中快取，不再查詢
+	got, err = svc.RunView(ctx, 1, 7, 10, "")
+	require.NoError(t, err)
+	assert.Equal(t, "next", got.NextCursor)
+	assert.Equal(t, int64(3), got.Total)
+	require.Len(t, got.Tasks, 1)
+
+	// 任務寫入遞增任務世代號 → 重新查詢
+	require.NoError(t, rdb.Incr(ctx, cache.KeyUserTasksGen(1)).Err())
+	mockRepo.EXPECT().FindByID(gomock.Any(), uint(7)).Return(view, nil)
+	mockTasks.EXPECT().ListTaskPage(gomock.Any(), uint(1), gomock.Any()).Return(page, nil)
+	_, err = svc.RunView(ctx, 1, 7, 10, "")
+	require.NoError(t, err)
+
+	// 修改 view → 遞增 view 世代號 → 重新查詢
+	mockRepo.EXPECT().FindByID(gomock.Any(), uint(7)).Return(view, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	_, err = svc.UpdateView(ctx, 1, 7, service.ViewInput{Name: "done", Sort: "title"})
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), uint(7)).Return(view, nil)
+	mockTasks.EXPECT().ListTaskPage(gomock.Any(), uint(1), gomock.Any()).Return(page, nil)
+	_, err = svc.RunView(ctx, 1, 7, 10, "")
+	require.NoError(t, err)
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+const (
+	// MaxWebhookAttempts 次失敗後停止重試，投遞標記為 failed（之後仍可手動重送）。
+	MaxWebhookAttempts = 8
+	webhookBaseDelay   = 30 * time.Second
+	webhookTimeout     = 10 * time.Second
+	// webhookLease 是認領後其他 worker 不會重送的時間，需大於單次請求的逾時。
+	webhookLease       = time.Minute
+	webhookPollEvery   = 5 * time.Second
+	webhookBatchSize   = 50
+	webhookConcurrency = 4
+	maxErrorLength     = 255
+)
+
+// WebhookBackoff 回傳第 attempt 次失敗後到下次重試的間隔：30s、1m、2m…，每次加倍。
+func WebhookBackoff(attempt int) time.Duration {
+	return webhookBaseDelay << (attempt - 1)
+}
+
+// SignWebhook 計算簽章標頭的值：t=<unix 秒>,v1=<hex(HMAC-SHA256(secret, "<t>.<body>"))>。
+// 時間戳一起簽入，接收端可拒絕過舊的請求以防重放。
+func SignWebhook(secret string, ts time.Time, body []byte) string {
+	t := strconv.FormatInt(ts.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookDispatcher 在背景送出待投遞的 webhook，讓接收端的速度不影響 API 請求。
+// 投遞狀態都存在資料表，程序重啟或多個實例同時執行時以 ClaimDelivery 避免重複送出。
+type WebhookDispatcher struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	wake   chan struct{}
+	now    func() time.Time
+}
+
+// NewWebhookDispatcher 建立 dispatcher；client 為 nil 時使用只允許連到外部位址的預設 client。
+func NewWebhookDispatcher(repo repository.WebhookRepository, client *http.Client) *WebhookDispatcher {
+	if client == nil {
+		client = newWebhookClient()
+	}
+	return &WebhookDispatcher{
+		repo:   repo,
+		client: client,
+		wake:   make(chan struct{}, 1),
+		now:    time.Now,
+	}
+}
+
+// Notify 喚醒 worker 立即處理新的投遞，不會阻塞。
+func (d *WebhookDispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run 持續處理到期的投遞，直到 ctx 結束。
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollEvery)
+	defer ticker.Stop()
+	for {
+		for {
+			n, err := d.DispatchDue(ctx)
+			if err != nil {
+				log.Printf("webhook: dispatch: %v", err)
+			}
+			// 一批滿了表示可能還有積壓，繼續處理
+			if err != nil || n < webhookBatchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue 送出一批到期的投遞，回傳取得的筆數。
+func (d *WebhookDispatcher) DispatchDue(ctx context.Context) (int, error) {
+	due, err := d.repo.DueDeliveries(ctx, d.now(), webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, webhookConcurrency)
+	for _, delivery := range due {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery *model.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			d.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+	return len(due), nil
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *model.WebhookDelivery) {
+	ok, err := d.repo.ClaimDelivery(ctx, delivery, d.now().Add(webhookLease))
+	if err != nil || !ok {
+		if err != nil {
+			log.Printf("webhook: claim delivery %d: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	hook, err := d.repo.FindByID(ctx, delivery.WebhookID)
+	if err != nil {
+		log.Printf("webhook: load hook %d: %v", delivery.WebhookID, err)
+		return // 租約到期後重試
+	}
+	if hook == nil {
+		delivery.Status = model.DeliveryFailed
+		delivery.Error = "webhook deleted"
+	} else {
+		code, err := d.send(ctx, hook, delivery)
+		delivery.ResponseCode = code
+		delivery.Error = ""
+		switch {
+		case err == nil:
+			delivery.Status = model.DeliverySucceeded
+		case delivery.Attempts >= MaxWebhookAttempts:
+			delivery.Status = model.DeliveryFailed
+			delivery.Error = truncate(err.Error(), maxErrorLength)
+		default:
+			delivery.Error = truncate(err.Error(), maxErrorLength)
+			delivery.NextAttemptAt = d.now().Add(WebhookBackoff(delivery.Attempts))
+		}
+	}
+	if err := d.repo.UpdateDelivery(ctx, delivery); err != nil && !errors.Is(err, repository.ErrDeliveryGone) {
+		log.Printf("webhook: update delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// send 送出一次請求；2xx 以外的回應都視為失敗。
+func (d *WebhookDispatcher) send(ctx context.Context, hook *model.Webhook, delivery *model.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set(constant.HeaderContentType, constant.ContentTypeJSON)
+	req.Header.Set(constant.HeaderUserAgent, "gotasker-pro-webhook/1")
+	req.Header.Set(constant.HeaderWebhookEvent, delivery.Event)
+	req.Header.Set(constant.HeaderWebhookDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(constant.HeaderWebhookSignature, SignWebhook(hook.Secret, d.now(), body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/constant"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func TestSignWebhook(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	body := []byte(`{"event":"ping"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(body)))
+
+	sig := service.SignWebhook("secret", ts, body)
+	assert.Equal(t, "t=1700000000,v1="+hex.EncodeToString(mac.Sum(nil)), sig)
+	assert.NotEqual(t, sig, service.SignWebhook("other", ts, []byte(`{"event":"ping"}`)))
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, service.WebhookBackoff(1))
+	assert.Equal(t, time.Minute, service.WebhookBackoff(2))
+	assert.Equal(t, 32*time.Minute, service.WebhookBackoff(7))
+}
+
+func TestWebhookDispatcher_DispatchDue(t *testing.T) {
+	ctx := context.Background()
+
+	type request struct {
+		header http.Header
+		body   []byte
+	}
+
+	setup := func(t *testing.T, status int) (*mock_repository.MockWebhookRepository, *service.WebhookDispatcher, <-chan request) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+		received := make(chan request, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			received <- request{header: r.Header, body: body}
+			w.WriteHeader(status)
+		}))
+		t.Cleanup(srv.Close)
+
+		repo := mock_repository.NewMockWebhookRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&model.Webhook{ID: 1, URL: srv.URL, Secret: "s"}, nil).AnyTimes()
+		return repo, service.NewWebhookDispatcher(repo, srv.Client()), received
+	}
+
+	claim := func(_ context.Context, d *model.WebhookDelivery, until time.Time) (bool, error) {
+		d.Attempts++
+		d.NextAttemptAt = until
+		return true, nil
+	}
+
+	t.Run("signed delivery succeeds", func(t *testing.T) {
+		repo, dispatcher, received := setup(t, http.StatusNoContent)
+		d := &model.WebhookDelivery{ID: 4, WebhookID: 1, Event: service.EventTaskCreated, Payload: `{"event":"task.created"}`, Status: model.DeliveryPending}
+		repo.EXPECT().DueDeliveries(ctx, gomock.Any(), gomock.Any()).Return([]*model.WebhookDelivery{d}, nil)
+		repo.EXPECT().ClaimDelivery(ctx, d, gomock.Any()).DoAndReturn(claim)
+		repo.EXPECT().UpdateDelivery(ctx, d).DoAndReturn(func(_ context.Context, d *model.WebhookDelivery) error {
+			assert.Equal(t, model.DeliverySucceeded, d.Status)
+			assert.Equal(t, http.StatusNoContent, d.ResponseCode)
+			return nil
+		})
+
+		n, err := dispatcher.DispatchDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		r := <-received
+		assert.Equal(t, service.EventTaskCreated, r.header.Get(constant.HeaderWebhookEvent))
+		assert.Equal(t, "4", r.header.Get(constant.HeaderWebhookDelivery))
+		assert.Equal(t, `{"event":"task.created"}`, string(r.body))
+
+		// 接收端可以用 secret 重算簽章
+		sig := r.header.Get(constant.HeaderWebhookSignature)
+		var ts int64
+		_, err = fmt.Sscanf(sig, "t=%d,", &ts)
+		require.NoError(t, err)
+		assert.Equal(t, service.SignWebhook("s", time.Unix(ts, 0), r.body), sig)
+	})
+
+	t.Run("failure schedules a retry with backoff", func(t *testing.T) {
+		repo, dispatcher, _ := setup(t, http.StatusInternalServerError)
+		d := &model.WebhookDelivery{ID: 4, WebhookID: 1, Payload: `{}`, Status: model.DeliveryPending, Attempts: 1}
+		repo.EXPECT().DueDeliveries(ctx, gomock.Any(), gomock.Any()).Return([]*model.WebhookDelivery{d}, nil)
+		repo.EXPECT().ClaimDelivery(ctx, d, gomock.Any()).DoAndReturn(claim)
+		repo.EXPECT().UpdateDelivery(ctx, d).DoAndReturn(func(_ context.Context, d *model.WebhookDelivery) error {
+			assert.Equal(t, model.DeliveryPending, d.Status)
+			assert.Equal(t, 2, d.Attempts)
+			assert.Equal(t, http.StatusInternalServerError, d.ResponseCode)
+			assert.Equal(t, "unexpected status 500", d.Error)
+			assert.WithinDuration(t, time.Now().Add(time.Minute), d.NextAttemptAt, 5*time.Second)
+			return nil
+		})
+
+		_, err := dispatcher.DispatchDue(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("last attempt marks failed", func(t *testing.T) {
+		repo, dispatcher, _ := setup(t, http.StatusBadGateway)
+		d := &model.WebhookDelivery{ID: 4, WebhookID: 1, Payload: `{}`, Status: model.DeliveryPending, Attempts: service.MaxWebhookAttempts - 1}
+		repo.EXPECT().DueDeliveries(ctx, gomock.Any(), gomock.Any()).Return([]*model.WebhookDelivery{d}, nil)
+		repo.EXPECT().ClaimDelivery(ctx, d, gomock.Any()).DoAndReturn(claim)
+		repo.EXPECT().UpdateDelivery(ctx, d).DoAndReturn(func(_ context.Context, d *model.WebhookDelivery) error {
+			assert.Equal(t, model.DeliveryFailed, d.Status)
+			return nil
+		})
+
+		_, err := dispatcher.DispatchDue(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("claimed by another worker", func(t *testing.T) {
+		repo, dispatcher, received := setup(t, http.StatusOK)
+		d := &model.WebhookDelivery{ID: 4, WebhookID: 1, Payload: `{}`, Status: model.DeliveryPending}
+		repo.EXPECT().DueDeliveries(ctx, gomock.Any(), gomock.Any()).Return([]*model.WebhookDelivery{d}, nil)
+		repo.EXPECT().ClaimDelivery(ctx, d, gomock.Any()).Return(false, nil)
+
+		_, err := dispatcher.DispatchDue(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, received)
+	})
+
+	t.Run("default client refuses internal addresses", func(t *testing.T) {
+		repo, _, received := setup(t, http.StatusOK)
+		dispatcher := service.NewWebhookDispatcher(repo, nil) // 測試伺服器在 127.0.0.1
+		d := &model.WebhookDelivery{ID: 4, WebhookID: 1, Payload: `{}`, Status: model.DeliveryPending}
+		repo.EXPECT().DueDeliveries(ctx, gomock.Any(), gomock.Any()).Return([]*model.WebhookDelivery{d}, nil)
+		repo.EXPECT().ClaimDelivery(ctx, d, gomock.Any()).DoAndReturn(claim)
+		repo.EXPECT().UpdateDelivery(ctx, d).DoAndReturn(func(_ context.Context, d *model.WebhookDelivery) error {
+			assert.Equal(t, model.DeliveryPending, d.Status)
+			assert.Zero(t, d.ResponseCode)
+			assert.Contains(t, d.Error, "not allowed")
+			return nil
+		})
+
+		_, err := dispatcher.DispatchDue(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, received)
+	})
+}
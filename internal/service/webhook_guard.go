@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+// errForbiddenWebhookTarget 表示連線目標是內部位址，投遞時記錄在 delivery.Error。
+var errForbiddenWebhookTarget = errors.New("webhook target address is not allowed")
+
+// sharedAddressSpace 是 100.64.0.0/10（CGNAT），部分雲端的 metadata 服務也在這個範圍。
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// forbiddenWebhookAddr 判斷位址是否為 loopback、私有、link-local、未指定或 multicast 位址。
+func forbiddenWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() ||
+		sharedAddressSpace.Contains(addr)
+}
+
+// forbiddenWebhookHost 在註冊時檢查 URL 的 host：IP 與 localhost 直接判斷，不查 DNS。
+// 網域名稱指向的位址可能之後才改變（DNS rebinding），由投遞時的 dial 檢查把關。
+func forbiddenWebhookHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return forbiddenWebhookAddr(addr)
+	}
+	return false
+}
+
+// guardWebhookDial 是 net.Dialer.Control：DNS 解析後、實際連線前檢查目標位址，
+// 因此重新導向或 DNS rebinding 都無法連到內部位址。
+func guardWebhookDial(_, address string, _ syscall.RawConn) error {
+	ap, err := netip.ParseAddrPort(address)
+	if err != nil || forbiddenWebhookAddr(ap.Addr()) {
+		return errForbiddenWebhookTarget
+	}
+	return nil
+}
+
+// newWebhookClient 建立投遞用的 client：只連外部位址、不走環境變數的 proxy、不跟隨重新導向
+// （3xx 視為失敗並記錄狀態碼）。
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{Timeout: webhookTimeout, Control: guardWebhookDial}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
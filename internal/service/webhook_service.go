@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SoliMark/gotasker-pro/internal/export"
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrInvalidWebhook   = errors.New("invalid webhook")
+	ErrDeliveryNotFound = errors.New("delivery not found")
+)
+
+const (
+	// EventPing 只由測試端點送出，不需要訂閱。
+	EventPing = "ping"
+
+	maxWebhooksPerUser  = 20
+	deliveryLogLimit    = 50
+	webhookSecretPrefix = "whsec_"
+	// minWebhookSecretBytes 是自訂 secret 的最短長度，與 HMAC-SHA256 的輸出等長。
+	minWebhookSecretBytes = 32
+	maxWebhookSecretBytes = 255
+)
+
+// WebhookInput 是建立 webhook 的內容；Secret 留空時自動產生，自訂時至少 32 bytes。
+type WebhookInput struct {
+	URL    string
+	Events []string
+	Secret string
+}
+
+// WebhookPayload 是送給接收端的 JSON 內容。
+type WebhookPayload struct {
+	Event      string      `json:"event"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// WebhookService 同時實作 TaskEventPublisher，把任務事件轉成待投遞紀錄。
+type WebhookService interface {
+	PublishTaskEvent(ctx context.Context, event string, task *model.Task)
+	CreateWebhook(ctx context.Context, userID uint, in WebhookInput) (*model.Webhook, error)
+	ListWebhooks(ctx context.Context, userID uint) ([]*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, userID, webhookID uint) error
+	ListDeliveries(ctx context.Context, userID, webhookID uint) ([]*model.WebhookDelivery, error)
+	// Redeliver 以原本的 payload 建立一筆新的投遞。
+	Redeliver(ctx context.Context, userID, webhookID, deliveryID uint) (*model.WebhookDelivery, error)
+	// Ping 送出一個 ping 事件，用來確認接收端與簽章設定。
+	Ping(ctx context.Context, userID, webhookID uint) (*model.WebhookDelivery, error)
+}
+
+type webhookService struct {
+	repo       repository.WebhookRepository
+	dispatcher *WebhookDispatcher
+}
+
+// NewWebhookService 建立 webhook 服務；dispatcher 為 nil 時投遞只寫入資料表，等 worker 輪詢時送出。
+func NewWebhookService(repo repository.WebhookRepository, dispatcher *WebhookDispatcher) WebhookService {
+	return &webhookService{repo: repo, dispatcher: dispatcher}
+}
+
+func (s *webhookService) CreateWebhook(ctx context.Context, userID uint, in WebhookInput) (*model.Webhook, error) {
+	u, err := url.Parse(strings.TrimSpace(in.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return nil, ErrInvalidWebhook
+	}
+	if forbiddenWebhookHost(u.Hostname()) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, errForbiddenWebhookTarget)
+	}
+	events, err := normalizeEvents(in.Events)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(in.Secret); n > 0 && (n < minWebhookSecretBytes || n > maxWebhookSecretBytes) {
+		return nil, fmt.Errorf("%w: secret must be %d to %d bytes", ErrInvalidWebhook, minWebhookSecretBytes, maxWebhookSecretBytes)
+	}
+
+	existing, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxWebhooksPerUser {
+		return nil, ErrInvalidWebhook
+	}
+
+	secret := in.Secret
+	if secret == "" {
+		b := make([]byte, minWebhookSecretBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		secret = webhookSecretPrefix + hex.EncodeToString(b)
+	}
+
+	hook := &model.Webhook{
+		UserID: userID,
+		URL:    u.String(),
+		Secret: secret,
+		Events: strings.Join(events, ","),
+	}
+	if err := s.repo.Create(ctx, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// normalizeEvents 檢查事件類型並去除重複，至少要訂閱一種。
+func normalizeEvents(events []string) ([]string, error) {
+	var out []string
+	for _, e := range events {
+		e = strings.TrimSpace(e)
+		if !slices.Contains(TaskEvents, e) {
+			return nil, ErrInvalidWebhook
+		}
+		if !slices.Contains(out, e) {
+			out = append(out, e)
+		}
+	}
+	if len(out) == 0 {
+		return nil, ErrInvalidWebhook
+	}
+	return out, nil
+}
+
+func (s *webhookService) ListWebhooks(ctx context.Context, userID uint) ([]*model.Webhook, error) {
+	return s.repo.ListByUserID(ctx, userID)
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, userID, webhookID uint) error {
+	if _, err := s.ownedWebhook(ctx, userID, webhookID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, webhookID)
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, userID, webhookID uint) ([]*model.WebhookDelivery, error) {
+	if _, err := s.ownedWebhook(ctx, userID, webhookID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDeliveries(ctx, webhookID, deliveryLogLimit)
+}
+
+func (s *webhookService) Redeliver(ctx context.Context, userID, webhookID, deliveryID uint) (*model.WebhookDelivery, error) {
+	if _, err := s.ownedWebhook(ctx, userID, webhookID); err != nil {
+		return nil, err
+	}
+	orig, err := s.repo.FindDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if orig == nil || orig.WebhookID != webhookID {
+		return nil, ErrDeliveryNotFound
+	}
+
+	d := newDelivery(webhookID, orig.Event, orig.Payload)
+	if err := s.enqueue(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *webhookService) Ping(ctx context.Context, userID, webhookID uint) (*model.WebhookDelivery, error) {
+	if _, err := s.ownedWebhook(ctx, userID, webhookID); err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(WebhookPayload{
+		Event:      EventPing,
+		OccurredAt: time.Now().UTC(),
+		Data:       map[string]uint{"webhook_id": webhookID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d := newDelivery(webhookID, EventPing, string(payload))
+	if err := s.enqueue(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// PublishTaskEvent 為每個訂閱此事件的 webhook 建立一筆待投遞紀錄；實際的 HTTP 請求由 dispatcher 在背景送出。
+// 失敗只記錄，不影響已完成的任務寫入。
+func (s *webhookService) PublishTaskEvent(ctx context.Context, event string, task *model.Task) {
+	hooks, err := s.repo.ListByUserID(ctx, task.UserID)
+	if err != nil {
+		log.Printf("webhook: list hooks for user %d: %v", task.UserID, err)
+		return
+	}
+
+	var deliveries []*model.WebhookDelivery
+	var payload []byte
+	for _, h := range hooks {
+		if !slices.Contains(strings.Split(h.Events, ","), event) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(WebhookPayload{
+				Event:      event,
+				OccurredAt: time.Now().UTC(),
+				Data:       export.NewRecord(task),
+			}); err != nil {
+				log.Printf("webhook: encode %s for task %d: %v", event, task.ID, err)
+				return
+			}
+		}
+		deliveries = append(deliveries, newDelivery(h.ID, event, string(payload)))
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		log.Printf("webhook: enqueue %s for task %d: %v", event, task.ID, err)
+		return
+	}
+	s.notify()
+}
+
+func (s *webhookService) enqueue(ctx context.Context, d *model.WebhookDelivery) error {
+	if err := s.repo.CreateDeliveries(ctx, []*model.WebhookDelivery{d}); err != nil {
+		return err
+	}
+	s.notify()
+	return nil
+}
+
+func (s *webhookService) notify() {
+	if s.dispatcher != nil {
+		s.dispatcher.Notify()
+	}
+}
+
+// ownedWebhook 確認 webhook 存在且屬於 userID；不屬於自己的一律視為不存在。
+func (s *webhookService) ownedWebhook(ctx context.Context, userID, webhookID uint) (*model.Webhook, error) {
+	hook, err := s.repo.FindByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if hook == nil || hook.UserID != userID {
+		return nil, ErrWebhookNotFound
+	}
+	return hook, nil
+}
+
+func newDelivery(webhookID uint, event, payload string) *model.WebhookDelivery {
+	return &model.WebhookDelivery{
+		WebhookID:     webhookID,
+		Event:         event,
+		Payload:       payload,
+		Status:        model.DeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+}
@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock_repository.NewMockWebhookRepository(ctrl)
+	svc := service.NewWebhookService(repo, nil)
+
+	repo.EXPECT().ListByUserID(ctx, uint(7)).Return(nil, nil)
+	repo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	hook, err := svc.CreateWebhook(ctx, 7, service.WebhookInput{
+		URL:    "https://example.com/hook",
+		Events: []string{service.EventTaskCreated, " task.completed ", service.EventTaskCreated},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "task.created,task.completed", hook.Events)
+	assert.Contains(t, hook.Secret, "whsec_")
+
+	// 自訂 secret 至少 32 bytes
+	repo.EXPECT().ListByUserID(ctx, uint(7)).Return(nil, nil)
+	repo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+	secret := strings.Repeat("s", 32)
+	hook, err = svc.CreateWebhook(ctx, 7, service.WebhookInput{
+		URL: "https://example.com/hook", Events: []string{service.EventTaskCreated}, Secret: secret,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, secret, hook.Secret)
+
+	for name, in := range map[string]service.WebhookInput{
+		"bad scheme":    {URL: "ftp://example.com", Events: []string{service.EventTaskCreated}},
+		"no host":       {URL: "https://", Events: []string{service.EventTaskCreated}},
+		"no events":     {URL: "https://example.com"},
+		"unknown event": {URL: "https://example.com", Events: []string{"task.exploded"}},
+		"loopback":      {URL: "http://127.0.0.1:8080/hook", Events: []string{service.EventTaskCreated}},
+		"localhost":     {URL: "http://LocalHost./hook", Events: []string{service.EventTaskCreated}},
+		"metadata":      {URL: "http://169.254.169.254/latest/meta-data", Events: []string{service.EventTaskCreated}},
+		"private":       {URL: "https://10.0.0.8/hook", Events: []string{service.EventTaskCreated}},
+		"unspecified":   {URL: "http://0.0.0.0/hook", Events: []string{service.EventTaskCreated}},
+		"ipv6 loopback": {URL: "http://[::1]/hook", Events: []string{service.EventTaskCreated}},
+		"ipv4-mapped":   {URL: "http://[::ffff:192.168.1.1]/hook", Events: []string{service.EventTaskCreated}},
+		"short secret":  {URL: "https://example.com", Events: []string{service.EventTaskCreated}, Secret: "too-short"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.CreateWebhook(ctx, 7, in)
+			assert.ErrorIs(t, err, service.ErrInvalidWebhook)
+		})
+	}
+}
+
+func TestWebhookService_PublishTaskEvent(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock_repository.NewMockWebhookRepository(ctrl)
+	svc := service.NewWebhookService(repo, nil)
+
+	repo.EXPECT().ListByUserID(ctx, uint(7)).Return([]*model.Webhook{
+		{ID: 1, UserID: 7, Events: "task.created,task.deleted"},
+		{ID: 2, UserID: 7, Events: "task.updated"},
+		{ID: 3, UserID: 7, Events: "task.deleted"},
+	}, nil)
+	repo.EXPECT().CreateDeliveries(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, ds []*model.WebhookDelivery) error {
+		require.Len(t, ds, 2)
+		assert.Equal(t, uint(1), ds[0].WebhookID)
+		assert.Equal(t, uint(3), ds[1].WebhookID)
+		assert.Equal(t, model.DeliveryPending, ds[0].Status)
+
+		var payload struct {
+			Event string `json:"event"`
+			Data  struct {
+				ID    uint   `json:"id"`
+				Title string `json:"title"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(ds[0].Payload), &payload))
+		assert.Equal(t, service.EventTaskDeleted, payload.Event)
+		assert.Equal(t, uint(9), payload.Data.ID)
+		assert.Equal(t, "gone", payload.Data.Title)
+		return nil
+	})
+
+	svc.PublishTaskEvent(ctx, service.EventTaskDeleted, &model.Task{ID: 9, UserID: 7, Title: "gone"})
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock_repository.NewMockWebhookRepository(ctrl)
+	svc := service.NewWebhookService(repo, nil)
+
+	repo.EXPECT().FindByID(ctx, uint(1)).Return(&model.Webhook{ID: 1, UserID: 7}, nil).AnyTimes()
+	repo.EXPECT().FindDelivery(ctx, uint(5)).Return(&model.WebhookDelivery{ID: 5, WebhookID: 1, Event: "task.created", Payload: `{"a":1}`, Status: model.DeliveryFailed, Attempts: 8}, nil)
+	repo.EXPECT().FindDelivery(ctx, uint(6)).Return(&model.WebhookDelivery{ID: 6, WebhookID: 2}, nil)
+	repo.EXPECT().CreateDeliveries(ctx, gomock.Any()).Return(nil)
+
+	d, err := svc.Redeliver(ctx, 7, 1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, model.DeliveryPending, d.Status)
+	assert.Equal(t, 0, d.Attempts)
+	assert.Equal(t, `{"a":1}`, d.Payload)
+
+	// 其他 webhook 的投遞
+	_, err = svc.Redeliver(ctx, 7, 1, 6)
+	assert.ErrorIs(t, err, service.ErrDeliveryNotFound)
+
+	// 別人的 webhook
+	_, err = svc.Redeliver(ctx, 8, 1, 5)
+	assert.ErrorIs(t, err, service.ErrWebhookNotFound)
+}
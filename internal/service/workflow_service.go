@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository"
+)
+
+var (
+	ErrInvalidWorkflow = errors.New("invalid workflow")
+)
+
+type WorkflowService interface {
+	GetWorkflow(ctx context.Context, userID uint) (*model.Workflow, error)
+	SaveWorkflow(ctx context.Context, wf *model.Workflow) error
+}
+
+type workflowService struct {
+	repo repository.WorkflowRepository
+}
+
+func NewWorkflowService(repo repository.WorkflowRepository) WorkflowService {
+	return &workflowService{repo: repo}
+}
+
+// GetWorkflow 回傳使用者的流程；尚未自訂時回傳預設流程。
+func (s *workflowService) GetWorkflow(ctx context.Context, userID uint) (*model.Workflow, error) {
+	return loadWorkflow(ctx, s.repo, userID)
+}
+
+func (s *workflowService) SaveWorkflow(ctx context.Context, wf *model.Workflow) error {
+	if err := validateWorkflow(wf); err != nil {
+		return err
+	}
+	return s.repo.Save(ctx, wf)
+}
+
+func loadWorkflow(ctx context.Context, repo repository.WorkflowRepository, userID uint) (*model.Workflow, error) {
+	if repo == nil {
+		return model.DefaultWorkflow(), nil
+	}
+	wf, err := repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if wf == nil {
+		wf = model.DefaultWorkflow()
+		wf.UserID = userID
+	}
+	return wf, nil
+}
+
+func validateWorkflow(wf *model.Workflow) error {
+	if strings.TrimSpace(wf.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidWorkflow)
+	}
+	if len(wf.States) == 0 {
+		return fmt.Errorf("%w: at least one state is required", ErrInvalidWorkflow)
+	}
+
+	// 名稱一律去除前後空白後保存，避免 "done" 與 "done " 看起來是同一個狀態
+	wf.Name = strings.TrimSpace(wf.Name)
+	wf.InitialStatus = strings.TrimSpace(wf.InitialStatus)
+	seen := make(map[string]bool, len(wf.States))
+	hasCompleting := false
+	for i := range wf.States {
+		st := &wf.States[i]
+		st.Name = strings.TrimSpace(st.Name)
+		if st.Name == "" {
+			return fmt.Errorf("%w: state name is required", ErrInvalidWorkflow)
+		}
+		if seen[st.Name] {
+			return fmt.Errorf("%w: duplicate state: %s", ErrInvalidWorkflow, st.Name)
+		}
+		seen[st.Name] = true
+		hasCompleting = hasCompleting || st.Completes
+	}
+	if !hasCompleting {
+		return fmt.Errorf("%w: at least one completing state is required", ErrInvalidWorkflow)
+	}
+	if !seen[wf.InitialStatus] {
+		return fmt.Errorf("%w: unknown initial status: %s", ErrInvalidWorkflow, wf.InitialStatus)
+	}
+	for i := range wf.Transitions {
+		t := &wf.Transitions[i]
+		t.FromStatus, t.ToStatus = strings.TrimSpace(t.FromStatus), strings.TrimSpace(t.ToStatus)
+		if !seen[t.FromStatus] || !seen[t.ToStatus] {
+			return fmt.Errorf("%w: transition references unknown state: %s -> %s", ErrInvalidWorkflow, t.FromStatus, t.ToStatus)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SoliMark/gotasker-pro/internal/model"
+	"github.com/SoliMark/gotasker-pro/internal/repository/mock_repository"
+	"github.com/SoliMark/gotasker-pro/internal/service"
+)
+
+func TestWorkflowService_GetWorkflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockWorkflowRepository(ctrl)
+	svc := service.NewWorkflowService(mockRepo)
+	ctx := context.Background()
+
+	t.Run("falls back to default", func(t *testing.T) {
+		mockRepo.EXPECT().FindByUserID(ctx, uint(1)).Return(nil, nil)
+
+		wf, err := svc.GetWorkflow(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, model.TaskStatusPending, wf.InitialStatus)
+		assert.True(t, wf.State(model.TaskStatusDone).Completes)
+	})
+
+	t.Run("custom workflow", func(t *testing.T) {
+		custom := &model.Workflow{UserID: 2, Name: "kanban", InitialStatus: "todo"}
+		mockRepo.EXPECT().FindByUserID(ctx, uint(2)).Return(custom, nil)
+
+		wf, err := svc.GetWorkflow(ctx, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, custom, wf)
+	})
+}
+
+func TestWorkflowService_SaveWorkflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockWorkflowRepository(ctrl)
+	svc := service.NewWorkflowService(mockRepo)
+	ctx := context.Background()
+
+	valid := func() *model.Workflow {
+		return &model.Workflow{
+			UserID:        1,
+			Name:          "kanban",
+			InitialStatus: "todo",
+			States: []model.WorkflowState{
+				{Name: "todo"},
+				{Name: "in_progress"},
+				{Name: "done", Completes: true},
+			},
+			Transitions: []model.WorkflowTransition{
+				{FromStatus: "todo", ToStatus: "in_progress"},
+				{FromStatus: "in_progress", ToStatus: "done"},
+			},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		wf := valid()
+		mockRepo.EXPECT().Save(ctx, wf).Return(nil)
+		assert.NoError(t, svc.SaveWorkflow(ctx, wf))
+	})
+
+	t.Run("trims names before saving", func(t *testing.T) {
+		wf := valid()
+		wf.InitialStatus = " todo"
+		wf.States[2].Name = "done "
+		wf.Transitions[1].ToStatus = " done"
+		mockRepo.EXPECT().Save(ctx, wf).Return(nil)
+		assert.NoError(t, svc.SaveWorkflow(ctx, wf))
+		assert.Equal(t, "todo", wf.InitialStatus)
+		assert.Equal(t, "done", wf.States[2].Name)
+		assert.Equal(t, "done", wf.Transitions[1].ToStatus)
+	})
+
+	tests := []struct {
+		name   string
+		mutate func(wf *model.Workflow)
+	}{
+		{"missing name", func(wf *model.Workflow) { wf.Name = " " }},
+		{"no states", func(wf *model.Workflow) { wf.States = nil }},
+		{"duplicate state", func(wf *model.Workflow) { wf.States = append(wf.States, model.WorkflowState{Name: "todo"}) }},
+		{"duplicate after trimming", func(wf *model.Workflow) { wf.States = append(wf.States, model.WorkflowState{Name: "done "}) }},
+		{"no completing state", func(wf *model.Workflow) { wf.States[2].Completes = false }},
+		{"unknown initial", func(wf *model.Workflow) { wf.InitialStatus = "backlog" }},
+		{"unknown transition target", func(wf *model.Workflow) {
+			wf.Transitions = append(wf.Transitions, model.WorkflowTransition{FromStatus: "done", ToStatus: "archived"})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf := valid()
+			tt.mutate(wf)
+			err := svc.SaveWorkflow(ctx, wf)
+			assert.ErrorIs(t, err, service.ErrInvalidWorkflow)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorCodec(t *testing.T) {
+	type payload struct {
+		Key string `json:"k"`
+		ID  uint   `json:"i"`
+	}
+	codec := NewCursorCodec("cursor_secret")
+
+	s, err := codec.Encode(payload{Key: "abc", ID: 42})
+	require.NoError(t, err)
+
+	var got payload
+	require.NoError(t, codec.Decode(s, &got))
+	require.Equal(t, payload{Key: "abc", ID: 42}, got)
+
+	// 簽章不符
+	other := NewCursorCodec("other_secret")
+	require.ErrorIs(t, other.Decode(s, &got), ErrInvalidCursor)
+
+	// 內容被竄改
+	require.ErrorIs(t, codec.Decode("x"+s, &got), ErrInvalidCursor)
+	require.ErrorIs(t, codec.Decode("garbage", &got), ErrInvalidCursor)
+}
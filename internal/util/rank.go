@@ -0,0 +1,109 @@
+package util
+
+import (
+	"errors"
+	"strings"
+)
+
+// 排序用的字典序 rank：以 base36 字元表示 (0, 1) 之間的小數，
+// 任兩個 rank 之間永遠可以再插入一個新的 rank，不需要重新編號整個清單。
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const rankBase = len(rankDigits)
+
+// ErrInvalidRankRange 表示 lo 不小於 hi，或兩者之間沒有任何 rank（例如 "a" 與 "a0"）。
+var ErrInvalidRankRange = errors.New("rank: no rank between lower and upper bound")
+
+// RankBetween 回傳嚴格介於 lo 與 hi 之間的 rank。
+// lo 為空字串表示沒有下界，hi 為空字串表示沒有上界。
+func RankBetween(lo, hi string) (string, error) {
+	if hi != "" && lo >= hi {
+		return "", ErrInvalidRankRange
+	}
+
+	var b strings.Builder
+	for i := 0; ; i++ {
+		if hi != "" && i >= len(hi) {
+			// 目前的結果已等於 hi：lo 是 hi 去掉結尾 '0' 的前綴，兩者之間沒有空間
+			return "", ErrInvalidRankRange
+		}
+		lower := 0
+		if i < len(lo) {
+			lower = strings.IndexByte(rankDigits, lo[i])
+		}
+		upper := rankBase
+		if hi != "" && i < len(hi) {
+			upper = strings.IndexByte(rankDigits, hi[i])
+		}
+		if lower < 0 || upper < 0 {
+			return "", errors.New("rank: invalid character")
+		}
+
+		if lower == upper {
+			b.WriteByte(rankDigits[lower])
+			continue
+		}
+		if upper-lower > 1 {
+			b.WriteByte(rankDigits[(lower+upper)/2])
+			return b.String(), nil
+		}
+		// 相鄰的兩個字元之間沒有空間：沿用下界字元，之後只需大於 lo 的剩餘部分。
+		b.WriteByte(rankDigits[lower])
+		hi = ""
+	}
+}
+
+// RankAfter 回傳接在 last 後面的 rank，用於把新項目加在清單最後。
+// 把 last 補零到寬度 w（1、2、4、8…中不小於 len(last) 者）後當成 base36 整數加一；
+// 該寬度已全是 'z' 時寬度加倍。連續附加時長度只隨筆數對數成長，不會像二分一樣越來越長。
+func RankAfter(last string) (string, error) {
+	if last == "" {
+		return RankBetween("", "")
+	}
+	if strings.Trim(last, rankDigits) != "" {
+		return "", errors.New("rank: invalid character")
+	}
+
+	w := 1
+	for w < len(last) {
+		w *= 2
+	}
+	for {
+		buf := []byte(last + strings.Repeat("0", w-len(last)))
+		for i := w - 1; i >= 0; i-- {
+			d := strings.IndexByte(rankDigits, buf[i])
+			if d < rankBase-1 {
+				buf[i] = rankDigits[d+1]
+				return string(buf), nil
+			}
+			buf[i] = rankDigits[0]
+		}
+		w *= 2
+	}
+}
+
+// EvenRanks 產生 n 個等距、等長的 rank，用於重新平衡整個清單。
+func EvenRanks(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	width, space := 1, rankBase
+	for space <= n {
+		width++
+		space *= rankBase
+	}
+
+	ranks := make([]string, n)
+	step := space / (n + 1)
+	for i := range ranks {
+		v := (i + 1) * step
+		buf := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			buf[j] = rankDigits[v%rankBase]
+			v /= rankBase
+		}
+		ranks[i] = string(buf)
+	}
+	return ranks
+}
@@ -0,0 +1,100 @@
+package util
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankBetween(t *testing.T) {
+	cases := []struct {
+		lo, hi string
+	}{
+		{"", ""},
+		{"", "i"},
+		{"i", ""},
+		{"a", "b"},
+		{"a", "a1"},
+		{"9z", "a0"},
+		{"az", "b"},
+		{"", "01"},
+	}
+	for _, tc := range cases {
+		got, err := RankBetween(tc.lo, tc.hi)
+		require.NoError(t, err)
+		require.Greater(t, got, tc.lo, "lo=%q hi=%q", tc.lo, tc.hi)
+		if tc.hi != "" {
+			require.Less(t, got, tc.hi, "lo=%q hi=%q", tc.lo, tc.hi)
+		}
+	}
+
+	// lo >= hi，或 hi 只是 lo 後面多了 '0'：兩者之間沒有 rank
+	for _, tc := range []struct{ lo, hi string }{
+		{"b", "a"},
+		{"a", "a"},
+		{"", "0"},
+		{"", "00"},
+		{"a", "a0"},
+		{"a", "a00"},
+		{"a1", "a10"},
+	} {
+		_, err := RankBetween(tc.lo, tc.hi)
+		require.ErrorIs(t, err, ErrInvalidRankRange, "lo=%q hi=%q", tc.lo, tc.hi)
+	}
+}
+
+func TestRankAfter(t *testing.T) {
+	cases := map[string]string{
+		"":      "i",
+		"i":     "j",
+		"az":    "b0",
+		"z":     "z1",
+		"zz":    "zz01",
+		"k5h":   "k5h1",
+		"zzzz":  "zzzz0001",
+		"a0zzz": "a0zzz001",
+	}
+	for last, want := range cases {
+		got, err := RankAfter(last)
+		require.NoError(t, err)
+		require.Equal(t, want, got, "last=%q", last)
+	}
+
+	_, err := RankAfter("A")
+	require.Error(t, err)
+}
+
+func TestRankAfter_GrowsLogarithmically(t *testing.T) {
+	last := ""
+	for i := 0; i < 100000; i++ {
+		next, err := RankAfter(last)
+		require.NoError(t, err)
+		if next <= last {
+			t.Fatalf("rank %d: %q <= %q", i, next, last)
+		}
+		last = next
+	}
+	require.LessOrEqual(t, len(last), 8)
+}
+
+func TestRankBetween_RepeatedInsertKeepsOrder(t *testing.T) {
+	lo, hi := "a", "b"
+	for i := 0; i < 50; i++ {
+		mid, err := RankBetween(lo, hi)
+		require.NoError(t, err)
+		require.True(t, lo < mid && mid < hi)
+		hi = mid
+	}
+}
+
+func TestEvenRanks(t *testing.T) {
+	for _, n := range []int{1, 10, 35, 36, 1000} {
+		ranks := EvenRanks(n)
+		require.Len(t, ranks, n)
+		require.True(t, sort.StringsAreSorted(ranks))
+		for i := 1; i < n; i++ {
+			require.NotEqual(t, ranks[i-1], ranks[i])
+		}
+	}
+}
@@ -133,7 +133,25 @@ func (ts *ContainerTestSuite) setupApplication() {
 // runMigrations 執行數據庫遷移
 func (ts *ContainerTestSuite) runMigrations() {
 	// 執行 GORM 自動遷移
-	err := ts.db.AutoMigrate(&model.User{}, &model.Task{})
+	err := ts.db.AutoMigrate(
+		&model.User{},
+		&model.Task{},
+		&model.Workflow{},
+		&model.WorkflowState{},
+		&model.WorkflowTransition{},
+		&model.Comment{},
+		&model.SavedView{},
+		&model.Project{},
+		&model.ProjectMember{},
+		&model.Tag{},
+		&model.IdempotencyRecord{},
+		&model.ImportJob{},
+		&model.FeedToken{},
+		&model.Webhook{},
+		&model.WebhookDelivery{},
+		&model.SyncCounter{},
+		&model.TaskTombstone{},
+	)
 	if err != nil {
 		log.Printf("Migration failed: %v", err)
 	}
@@ -142,7 +160,23 @@ func (ts *ContainerTestSuite) runMigrations() {
 
 // cleanupDatabase 清理數據庫
 func (ts *ContainerTestSuite) cleanupDatabase() {
+	ts.db.Exec("DELETE FROM task_search_documents WHERE 1=1")
+	ts.db.Exec("DELETE FROM comments WHERE 1=1")
+	ts.db.Exec("DELETE FROM task_tags WHERE 1=1")
+	ts.db.Exec("DELETE FROM tags WHERE 1=1")
+	ts.db.Exec("DELETE FROM saved_views WHERE 1=1")
 	ts.db.Exec("DELETE FROM tasks WHERE 1=1")
+	ts.db.Exec("DELETE FROM workflow_transitions WHERE 1=1")
+	ts.db.Exec("DELETE FROM workflow_states WHERE 1=1")
+	ts.db.Exec("DELETE FROM workflows WHERE 1=1")
+	ts.db.Exec("DELETE FROM projects WHERE 1=1")
+	ts.db.Exec("DELETE FROM idempotency_records WHERE 1=1")
+	ts.db.Exec("DELETE FROM import_jobs WHERE 1=1")
+	ts.db.Exec("DELETE FROM feed_tokens WHERE 1=1")
+	ts.db.Exec("DELETE FROM webhook_deliveries WHERE 1=1")
+	ts.db.Exec("DELETE FROM webhooks WHERE 1=1")
+	ts.db.Exec("DELETE FROM task_tombstones WHERE 1=1")
+	ts.db.Exec("DELETE FROM sync_counters WHERE 1=1")
 	ts.db.Exec("DELETE FROM users WHERE 1=1")
 	ts.db.Exec("ALTER SEQUENCE IF EXISTS users_id_seq RESTART WITH 1")
 	ts.db.Exec("ALTER SEQUENCE IF EXISTS tasks_id_seq RESTART WITH 1")